@@ -0,0 +1,300 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Package blas wraps a subset of the gsl level 1, 2 and 3 BLAS functions
+// operating on the vectors and matrices of package matrix.
+//
+// The dimensions of all operands are checked before calling gsl; an
+// incompatible operand results in a *gsl.Error with code gsl.EBADLEN, a
+// non square matrix where a square one is required in code gsl.ENOTSQR
+// and an invalid Transpose, Uplo, Diag or Side value in code gsl.EINVAL.
+package blas
+
+// #cgo pkg-config: gsl
+// #include "blas_wrap.h"
+import "C"
+
+import (
+  "unsafe"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/matrix"
+)
+
+// Transpose selects whether a matrix operand is used as is or transposed
+type Transpose int
+
+// available Transpose values
+const (
+  NoTrans Transpose = C.CblasNoTrans
+  Trans   Transpose = C.CblasTrans
+)
+
+// Uplo selects the upper or lower triangle of a matrix operand
+type Uplo int
+
+// available Uplo values
+const (
+  Upper Uplo = C.CblasUpper
+  Lower Uplo = C.CblasLower
+)
+
+// Diag selects whether the diagonal of a triangular matrix is used or
+// assumed to be unity
+type Diag int
+
+// available Diag values
+const (
+  NonUnit Diag = C.CblasNonUnit
+  Unit    Diag = C.CblasUnit
+)
+
+// Side selects whether a matrix operand is multiplied from the left or
+// from the right
+type Side int
+
+// available Side values
+const (
+  Left  Side = C.CblasLeft
+  Right Side = C.CblasRight
+)
+
+// vptr returns a C pointer to the first element of x
+func vptr(x *matrix.Vector) *C.double {
+  return (*C.double)(unsafe.Pointer(&x.Data()[0]))
+}
+
+// mptr returns a C pointer to the first element of a
+func mptr(a *matrix.Matrix) *C.double {
+  return (*C.double)(unsafe.Pointer(&a.Data()[0]))
+}
+
+// checkLen returns an error unless x and y have the same length
+func checkLen(x, y *matrix.Vector) error {
+  if x.Len() != y.Len() {
+    return gsl.NewError(gsl.EBADLEN, "Vector lengths %d and %d differ.",
+      x.Len(), y.Len())
+  }
+  return nil
+}
+
+// checkSquare returns an error unless a is square and returns its size
+func checkSquare(a *matrix.Matrix) (int, error) {
+  rows, cols := a.Dims()
+  if rows != cols {
+    return 0, gsl.NewError(gsl.ENOTSQR, "Matrix of dimensions %d x %d is "+
+      "not square.", rows, cols)
+  }
+  return rows, nil
+}
+
+// opDims returns the dimensions of op(a) for the given transpose value
+func opDims(t Transpose, a *matrix.Matrix) (int, int, error) {
+  rows, cols := a.Dims()
+  switch t {
+  case NoTrans:
+    return rows, cols, nil
+  case Trans:
+    return cols, rows, nil
+  }
+  return 0, 0, gsl.NewError(gsl.EINVAL, "Invalid transpose value %d.", t)
+}
+
+// checkTriangular returns an error if uplo or diag are invalid
+func checkTriangular(uplo Uplo, diag Diag) error {
+  if uplo != Upper && uplo != Lower {
+    return gsl.NewError(gsl.EINVAL, "Invalid uplo value %d.", uplo)
+  }
+  if diag != NonUnit && diag != Unit {
+    return gsl.NewError(gsl.EINVAL, "Invalid diag value %d.", diag)
+  }
+  return nil
+}
+
+// call runs the gsl function f and converts a non-zero status into an
+// error
+func call(f func() C.int) error {
+  var status C.int
+  err := gsl.Protect(func() {
+    status = f()
+  })
+  if err == nil && status != 0 {
+    err = gsl.NewError(gsl.Errno(status), "BLAS operation failed.")
+  }
+  return err
+}
+
+// Ddot returns the scalar product x^T y
+func Ddot(x, y *matrix.Vector) (float64, error) {
+  if err := checkLen(x, y); err != nil {
+    return 0, err
+  }
+  var result C.double
+  err := call(func() C.int {
+    return C.blas_ddot(vptr(x), C.size_t(x.Stride()), vptr(y),
+      C.size_t(y.Stride()), C.size_t(x.Len()), &result)
+  })
+  return float64(result), err
+}
+
+// Daxpy computes y = alpha x + y
+func Daxpy(alpha float64, x, y *matrix.Vector) error {
+  if err := checkLen(x, y); err != nil {
+    return err
+  }
+  return call(func() C.int {
+    return C.blas_daxpy(C.double(alpha), vptr(x), C.size_t(x.Stride()),
+      vptr(y), C.size_t(y.Stride()), C.size_t(x.Len()))
+  })
+}
+
+// Dnrm2 returns the Euclidean norm ||x||_2 = sqrt(sum_i x_i^2)
+func Dnrm2(x *matrix.Vector) float64 {
+  return float64(C.blas_dnrm2(vptr(x), C.size_t(x.Stride()),
+    C.size_t(x.Len())))
+}
+
+// Dasum returns the absolute sum sum_i |x_i|
+func Dasum(x *matrix.Vector) float64 {
+  return float64(C.blas_dasum(vptr(x), C.size_t(x.Stride()),
+    C.size_t(x.Len())))
+}
+
+// Dgemv computes y = alpha op(A) x + beta y where op(A) is A or A^T
+// depending on trans
+func Dgemv(trans Transpose, alpha float64, a *matrix.Matrix,
+  x *matrix.Vector, beta float64, y *matrix.Vector) error {
+  rows, cols, err := opDims(trans, a)
+  if err != nil {
+    return err
+  }
+  if x.Len() != cols || y.Len() != rows {
+    return gsl.NewError(gsl.EBADLEN, "Vector lengths %d and %d do not "+
+      "match matrix of dimensions %d x %d.", x.Len(), y.Len(), rows, cols)
+  }
+  n1, n2 := a.Dims()
+  return call(func() C.int {
+    return C.blas_dgemv(C.CBLAS_TRANSPOSE_t(trans), C.double(alpha),
+      mptr(a), C.size_t(n1), C.size_t(n2), C.size_t(a.Tda()), vptr(x),
+      C.size_t(x.Stride()), C.size_t(x.Len()), C.double(beta), vptr(y),
+      C.size_t(y.Stride()), C.size_t(y.Len()))
+  })
+}
+
+// Dtrsv computes x = inv(op(A)) x, i.e., solves op(A) y = x for the
+// triangular part of A selected by uplo. If diag is Unit the diagonal of
+// A is taken to be unity.
+func Dtrsv(uplo Uplo, trans Transpose, diag Diag, a *matrix.Matrix,
+  x *matrix.Vector) error {
+  if err := checkTriangular(uplo, diag); err != nil {
+    return err
+  }
+  if _, _, err := opDims(trans, a); err != nil {
+    return err
+  }
+  n, err := checkSquare(a)
+  if err != nil {
+    return err
+  }
+  if x.Len() != n {
+    return gsl.NewError(gsl.EBADLEN, "Vector length %d does not match "+
+      "matrix of dimensions %d x %d.", x.Len(), n, n)
+  }
+  return call(func() C.int {
+    return C.blas_dtrsv(C.CBLAS_UPLO_t(uplo), C.CBLAS_TRANSPOSE_t(trans),
+      C.CBLAS_DIAG_t(diag), mptr(a), C.size_t(n), C.size_t(a.Tda()),
+      vptr(x), C.size_t(x.Stride()))
+  })
+}
+
+// Dgemm computes C = alpha op(A) op(B) + beta C where op(A) and op(B) are
+// A, B or their transposes depending on transA and transB
+func Dgemm(transA, transB Transpose, alpha float64, a, b *matrix.Matrix,
+  beta float64, c *matrix.Matrix) error {
+  aRows, aCols, err := opDims(transA, a)
+  if err != nil {
+    return err
+  }
+  bRows, bCols, err := opDims(transB, b)
+  if err != nil {
+    return err
+  }
+  cRows, cCols := c.Dims()
+  if aCols != bRows || cRows != aRows || cCols != bCols {
+    return gsl.NewError(gsl.EBADLEN, "Matrix dimensions %d x %d, %d x %d "+
+      "and %d x %d are incompatible.", aRows, aCols, bRows, bCols, cRows,
+      cCols)
+  }
+  a1, a2 := a.Dims()
+  b1, b2 := b.Dims()
+  return call(func() C.int {
+    return C.blas_dgemm(C.CBLAS_TRANSPOSE_t(transA),
+      C.CBLAS_TRANSPOSE_t(transB), C.double(alpha), mptr(a), C.size_t(a1),
+      C.size_t(a2), C.size_t(a.Tda()), mptr(b), C.size_t(b1), C.size_t(b2),
+      C.size_t(b.Tda()), C.double(beta), mptr(c), C.size_t(cRows),
+      C.size_t(cCols), C.size_t(c.Tda()))
+  })
+}
+
+// Dsyrk computes the symmetric rank k update C = alpha A A^T + beta C if
+// trans is NoTrans and C = alpha A^T A + beta C if trans is Trans. Only
+// the triangle of C selected by uplo is referenced and updated.
+func Dsyrk(uplo Uplo, trans Transpose, alpha float64, a *matrix.Matrix,
+  beta float64, c *matrix.Matrix) error {
+  if err := checkTriangular(uplo, NonUnit); err != nil {
+    return err
+  }
+  rows, _, err := opDims(trans, a)
+  if err != nil {
+    return err
+  }
+  n, err := checkSquare(c)
+  if err != nil {
+    return err
+  }
+  if rows != n {
+    return gsl.NewError(gsl.EBADLEN, "Matrix dimensions %d x %d do not "+
+      "match update of rank %d.", n, n, rows)
+  }
+  a1, a2 := a.Dims()
+  return call(func() C.int {
+    return C.blas_dsyrk(C.CBLAS_UPLO_t(uplo), C.CBLAS_TRANSPOSE_t(trans),
+      C.double(alpha), mptr(a), C.size_t(a1), C.size_t(a2),
+      C.size_t(a.Tda()), C.double(beta), mptr(c), C.size_t(n),
+      C.size_t(c.Tda()))
+  })
+}
+
+// Dtrsm computes B = alpha inv(op(A)) B if side is Left and
+// B = alpha B inv(op(A)) if side is Right, i.e., solves a triangular
+// system with multiple right hand sides. Only the triangle of A selected
+// by uplo is referenced; if diag is Unit its diagonal is taken to be
+// unity.
+func Dtrsm(side Side, uplo Uplo, transA Transpose, diag Diag, alpha float64,
+  a, b *matrix.Matrix) error {
+  if side != Left && side != Right {
+    return gsl.NewError(gsl.EINVAL, "Invalid side value %d.", side)
+  }
+  if err := checkTriangular(uplo, diag); err != nil {
+    return err
+  }
+  if _, _, err := opDims(transA, a); err != nil {
+    return err
+  }
+  n, err := checkSquare(a)
+  if err != nil {
+    return err
+  }
+  rows, cols := b.Dims()
+  if (side == Left && rows != n) || (side == Right && cols != n) {
+    return gsl.NewError(gsl.EBADLEN, "Matrix dimensions %d x %d do not "+
+      "match triangular matrix of dimensions %d x %d.", rows, cols, n, n)
+  }
+  return call(func() C.int {
+    return C.blas_dtrsm(C.CBLAS_SIDE_t(side), C.CBLAS_UPLO_t(uplo),
+      C.CBLAS_TRANSPOSE_t(transA), C.CBLAS_DIAG_t(diag), C.double(alpha),
+      mptr(a), C.size_t(n), C.size_t(a.Tda()), mptr(b), C.size_t(rows),
+      C.size_t(cols), C.size_t(b.Tda()))
+  })
+}
@@ -0,0 +1,208 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Package blas wraps a subset of the gsl level 1, 2 and 3 BLAS functions
+// operating on the vectors and matrices of package matrix.
+//
+// The dimensions of all operands are checked before calling gsl; an
+// incompatible operand results in a *gsl.Error with code gsl.EBADLEN, a
+// non square matrix where a square one is required in code gsl.ENOTSQR
+// and an invalid Transpose, Uplo, Diag or Side value in code gsl.EINVAL.
+package blas
+
+import (
+  "errors"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/matrix"
+)
+
+// vector returns a vector wrapping data with stride or fails the test
+func vector(t *testing.T, data []float64, stride int) *matrix.Vector {
+  v, err := matrix.NewVectorFrom(data, stride)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return v
+}
+
+// dense returns a rows x cols matrix wrapping data or fails the test
+func dense(t *testing.T, data []float64, rows, cols int) *matrix.Matrix {
+  m, err := matrix.NewMatrixFrom(data, rows, cols)
+  if err != nil {
+    t.Fatal(err)
+  }
+  return m
+}
+
+// equalSlices checks that a and b contain the same elements
+func equalSlices(a, b []float64) bool {
+  if len(a) != len(b) {
+    return false
+  }
+  for i := range a {
+    if a[i] != b[i] {
+      return false
+    }
+  }
+  return true
+}
+
+// test set 1
+func Test_blas_1(t *testing.T) {
+
+  x := vector(t, []float64{1, 2, 3}, 1)
+  y := vector(t, []float64{4, 0, 5, 0, 6}, 2)
+
+  // test 1
+  dot, err := Ddot(x, y)
+  if err != nil || dot != 32 {
+    t.Error("Test 1: Wrong scalar product", dot, err)
+  }
+
+  // test 2
+  if err := Daxpy(2, x, y); err != nil {
+    t.Error("Test 2: Unexpected error", err)
+  }
+  if !equalSlices(y.Slice(), []float64{6, 9, 12}) {
+    t.Error("Test 2: Wrong axpy result", y.Slice())
+  }
+
+  // test 3
+  if n := Dnrm2(vector(t, []float64{3, 4}, 1)); n != 5 {
+    t.Error("Test 3: Wrong Euclidean norm", n)
+  }
+  if s := Dasum(vector(t, []float64{-1, 2, -3}, 1)); s != 6 {
+    t.Error("Test 3: Wrong absolute sum", s)
+  }
+
+  // test 4
+  z := vector(t, []float64{1, 2}, 1)
+  if _, err := Ddot(x, z); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 4: Expected EBADLEN for different lengths, got", err)
+  }
+  if err := Daxpy(1, x, z); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 4: Expected EBADLEN for different lengths, got", err)
+  }
+}
+
+// test set 2
+func Test_blas_2(t *testing.T) {
+
+  a := dense(t, []float64{1, 2, 3, 4, 5, 6}, 2, 3)
+
+  // test 1
+  x := vector(t, []float64{1, 1, 1}, 1)
+  y := vector(t, []float64{1, 1}, 1)
+  if err := Dgemv(NoTrans, 1, a, x, 2, y); err != nil {
+    t.Error("Test 1: Unexpected error", err)
+  }
+  if !equalSlices(y.Slice(), []float64{8, 17}) {
+    t.Error("Test 1: Wrong gemv result", y.Slice())
+  }
+
+  // test 2
+  y = vector(t, []float64{1, 1}, 1)
+  if err := Dgemv(Trans, 1, a, y, 0, x); err != nil {
+    t.Error("Test 2: Unexpected error", err)
+  }
+  if !equalSlices(x.Slice(), []float64{5, 7, 9}) {
+    t.Error("Test 2: Wrong transposed gemv result", x.Slice())
+  }
+
+  // test 3
+  if err := Dgemv(NoTrans, 1, a, y, 0, x); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 3: Expected EBADLEN for wrong vector lengths, got", err)
+  }
+  if err := Dgemv(Transpose(42), 1, a, x, 0, y); !errors.Is(err,
+    gsl.EINVAL) {
+    t.Error("Test 3: Expected EINVAL for invalid transpose, got", err)
+  }
+
+  // test 4
+  l := dense(t, []float64{2, 0, 1, 4}, 2, 2)
+  b := vector(t, []float64{2, 9}, 1)
+  if err := Dtrsv(Lower, NoTrans, NonUnit, l, b); err != nil {
+    t.Error("Test 4: Unexpected error", err)
+  }
+  if !equalSlices(b.Slice(), []float64{1, 2}) {
+    t.Error("Test 4: Wrong trsv result", b.Slice())
+  }
+  if err := Dtrsv(Lower, NoTrans, NonUnit, a, x); !errors.Is(err,
+    gsl.ENOTSQR) {
+    t.Error("Test 4: Expected ENOTSQR for non square matrix, got", err)
+  }
+  if err := Dtrsv(Lower, NoTrans, NonUnit, l, x); !errors.Is(err,
+    gsl.EBADLEN) {
+    t.Error("Test 4: Expected EBADLEN for wrong vector length, got", err)
+  }
+
+  // test 5
+  sub := a.Submatrix(0, 1, 2, 2)
+  col := a.Col(0)
+  if err := Dgemv(NoTrans, 1, sub, col, 0, y); err != nil {
+    t.Error("Test 5: Unexpected error", err)
+  }
+  if !equalSlices(y.Slice(), []float64{14, 29}) {
+    t.Error("Test 5: Wrong gemv result on views", y.Slice())
+  }
+}
+
+// test set 3
+func Test_blas_3(t *testing.T) {
+
+  a := dense(t, []float64{1, 2, 3, 4, 5, 6}, 2, 3)
+  b := dense(t, []float64{7, 8, 9, 10, 11, 12}, 3, 2)
+
+  // test 1
+  c, _ := matrix.NewMatrix(2, 2)
+  if err := Dgemm(NoTrans, NoTrans, 1, a, b, 0, c); err != nil {
+    t.Error("Test 1: Unexpected error", err)
+  }
+  if !equalSlices(c.Data(), []float64{58, 64, 139, 154}) {
+    t.Error("Test 1: Wrong gemm result", c.Data())
+  }
+  if err := Dgemm(Trans, NoTrans, 1, a, b, 0, c); !errors.Is(err,
+    gsl.EBADLEN) {
+    t.Error("Test 1: Expected EBADLEN for incompatible matrices, got", err)
+  }
+
+  // test 2
+  s := dense(t, []float64{0, 0, -1, 0}, 2, 2)
+  if err := Dsyrk(Upper, NoTrans, 1, a, 0, s); err != nil {
+    t.Error("Test 2: Unexpected error", err)
+  }
+  if !equalSlices(s.Data(), []float64{14, 32, -1, 77}) {
+    t.Error("Test 2: Wrong syrk result", s.Data())
+  }
+  if err := Dsyrk(Upper, Trans, 1, a, 0, s); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 2: Expected EBADLEN for wrong update size, got", err)
+  }
+  if err := Dsyrk(Upper, NoTrans, 1, a, 0, b); !errors.Is(err,
+    gsl.ENOTSQR) {
+    t.Error("Test 2: Expected ENOTSQR for non square result, got", err)
+  }
+
+  // test 3
+  l := dense(t, []float64{2, 0, 1, 4}, 2, 2)
+  rhs := dense(t, []float64{2, 4, 9, 10}, 2, 2)
+  if err := Dtrsm(Left, Lower, NoTrans, NonUnit, 1, l, rhs); err != nil {
+    t.Error("Test 3: Unexpected error", err)
+  }
+  if !equalSlices(rhs.Data(), []float64{1, 2, 2, 2}) {
+    t.Error("Test 3: Wrong trsm result", rhs.Data())
+  }
+  if err := Dtrsm(Left, Lower, NoTrans, NonUnit, 1, l, b); !errors.Is(err,
+    gsl.EBADLEN) {
+    t.Error("Test 3: Expected EBADLEN for wrong right hand side, got", err)
+  }
+  if err := Dtrsm(Right, Lower, NoTrans, NonUnit, 1, l, b); err != nil {
+    t.Error("Test 3: Unexpected error", err)
+  }
+  if err := Dtrsm(Side(7), Lower, NoTrans, NonUnit, 1, l, b); !errors.Is(
+    err, gsl.EINVAL) {
+    t.Error("Test 3: Expected EINVAL for invalid side, got", err)
+  }
+}
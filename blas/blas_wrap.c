@@ -0,0 +1,125 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * blas_wrap provides gsl blas wrappers for go-gsl operating on plain
+ * arrays described by their dimensions and strides
+ */
+
+#include <gsl/gsl_blas.h>
+#include <gsl/gsl_matrix.h>
+#include <gsl/gsl_vector.h>
+
+#include "blas_wrap.h"
+
+/* VECTOR and MATRIX declare gsl views of plain arrays passed in from Go,
+ * see matrix_wrap.c in the matrix package */
+#define VECTOR(v, x, n, stride) \
+  gsl_vector_view v = gsl_vector_view_array_with_stride(x, stride, n)
+#define CONST_VECTOR(v, x, n, stride) \
+  gsl_vector_const_view v = gsl_vector_const_view_array_with_stride(x, \
+    stride, n)
+#define MATRIX(m, x, n1, n2, tda) \
+  gsl_matrix_view m = gsl_matrix_view_array_with_tda(x, n1, n2, tda)
+#define CONST_MATRIX(m, x, n1, n2, tda) \
+  gsl_matrix_const_view m = gsl_matrix_const_view_array_with_tda(x, n1, \
+    n2, tda)
+
+
+/* blas_ddot computes the scalar product of x and y */
+int blas_ddot(const double *x, size_t x_stride, const double *y,
+  size_t y_stride, size_t n, double *result) {
+
+  CONST_VECTOR(vx, x, n, x_stride);
+  CONST_VECTOR(vy, y, n, y_stride);
+  return gsl_blas_ddot(&vx.vector, &vy.vector, result);
+}
+
+
+/* blas_daxpy computes y = alpha x + y */
+int blas_daxpy(double alpha, const double *x, size_t x_stride, double *y,
+  size_t y_stride, size_t n) {
+
+  CONST_VECTOR(vx, x, n, x_stride);
+  VECTOR(vy, y, n, y_stride);
+  return gsl_blas_daxpy(alpha, &vx.vector, &vy.vector);
+}
+
+
+/* blas_dnrm2 computes the Euclidean norm of x */
+double blas_dnrm2(const double *x, size_t x_stride, size_t n) {
+  CONST_VECTOR(vx, x, n, x_stride);
+  return gsl_blas_dnrm2(&vx.vector);
+}
+
+
+/* blas_dasum computes the sum of the absolute values of the elements
+ * of x */
+double blas_dasum(const double *x, size_t x_stride, size_t n) {
+  CONST_VECTOR(vx, x, n, x_stride);
+  return gsl_blas_dasum(&vx.vector);
+}
+
+
+/* blas_dgemv computes y = alpha op(A) x + beta y */
+int blas_dgemv(CBLAS_TRANSPOSE_t trans, double alpha, const double *a,
+  size_t a_n1, size_t a_n2, size_t a_tda, const double *x, size_t x_stride,
+  size_t x_n, double beta, double *y, size_t y_stride, size_t y_n) {
+
+  CONST_MATRIX(ma, a, a_n1, a_n2, a_tda);
+  CONST_VECTOR(vx, x, x_n, x_stride);
+  VECTOR(vy, y, y_n, y_stride);
+  return gsl_blas_dgemv(trans, alpha, &ma.matrix, &vx.vector, beta,
+    &vy.vector);
+}
+
+
+/* blas_dtrsv computes x = inv(op(A)) x for the triangular matrix A */
+int blas_dtrsv(CBLAS_UPLO_t uplo, CBLAS_TRANSPOSE_t trans, CBLAS_DIAG_t diag,
+  const double *a, size_t a_n, size_t a_tda, double *x, size_t x_stride) {
+
+  CONST_MATRIX(ma, a, a_n, a_n, a_tda);
+  VECTOR(vx, x, a_n, x_stride);
+  return gsl_blas_dtrsv(uplo, trans, diag, &ma.matrix, &vx.vector);
+}
+
+
+/* blas_dgemm computes C = alpha op(A) op(B) + beta C */
+int blas_dgemm(CBLAS_TRANSPOSE_t trans_a, CBLAS_TRANSPOSE_t trans_b,
+  double alpha, const double *a, size_t a_n1, size_t a_n2, size_t a_tda,
+  const double *b, size_t b_n1, size_t b_n2, size_t b_tda, double beta,
+  double *c, size_t c_n1, size_t c_n2, size_t c_tda) {
+
+  CONST_MATRIX(ma, a, a_n1, a_n2, a_tda);
+  CONST_MATRIX(mb, b, b_n1, b_n2, b_tda);
+  MATRIX(mc, c, c_n1, c_n2, c_tda);
+  return gsl_blas_dgemm(trans_a, trans_b, alpha, &ma.matrix, &mb.matrix,
+    beta, &mc.matrix);
+}
+
+
+/* blas_dsyrk computes C = alpha A A^T + beta C or C = alpha A^T A + beta C
+ * for the symmetric matrix C */
+int blas_dsyrk(CBLAS_UPLO_t uplo, CBLAS_TRANSPOSE_t trans, double alpha,
+  const double *a, size_t a_n1, size_t a_n2, size_t a_tda, double beta,
+  double *c, size_t c_n, size_t c_tda) {
+
+  CONST_MATRIX(ma, a, a_n1, a_n2, a_tda);
+  MATRIX(mc, c, c_n, c_n, c_tda);
+  return gsl_blas_dsyrk(uplo, trans, alpha, &ma.matrix, beta, &mc.matrix);
+}
+
+
+/* blas_dtrsm computes B = alpha inv(op(A)) B or B = alpha B inv(op(A)) for
+ * the triangular matrix A */
+int blas_dtrsm(CBLAS_SIDE_t side, CBLAS_UPLO_t uplo,
+  CBLAS_TRANSPOSE_t trans_a, CBLAS_DIAG_t diag, double alpha,
+  const double *a, size_t a_n, size_t a_tda, double *b, size_t b_n1,
+  size_t b_n2, size_t b_tda) {
+
+  CONST_MATRIX(ma, a, a_n, a_n, a_tda);
+  MATRIX(mb, b, b_n1, b_n2, b_tda);
+  return gsl_blas_dtrsm(side, uplo, trans_a, diag, alpha, &ma.matrix,
+    &mb.matrix);
+}
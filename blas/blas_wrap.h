@@ -0,0 +1,59 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * blas_wrap provides gsl blas wrappers for go-gsl operating on plain
+ * arrays described by their dimensions and strides
+ */
+
+
+#ifndef BLAS_WRAP_H
+#define BLAS_WRAP_H
+
+#include <stddef.h>
+
+#include <gsl/gsl_blas.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+int blas_ddot(const double *x, size_t x_stride, const double *y,
+  size_t y_stride, size_t n, double *result);
+
+int blas_daxpy(double alpha, const double *x, size_t x_stride, double *y,
+  size_t y_stride, size_t n);
+
+double blas_dnrm2(const double *x, size_t x_stride, size_t n);
+
+double blas_dasum(const double *x, size_t x_stride, size_t n);
+
+int blas_dgemv(CBLAS_TRANSPOSE_t trans, double alpha, const double *a,
+  size_t a_n1, size_t a_n2, size_t a_tda, const double *x, size_t x_stride,
+  size_t x_n, double beta, double *y, size_t y_stride, size_t y_n);
+
+int blas_dtrsv(CBLAS_UPLO_t uplo, CBLAS_TRANSPOSE_t trans, CBLAS_DIAG_t diag,
+  const double *a, size_t a_n, size_t a_tda, double *x, size_t x_stride);
+
+int blas_dgemm(CBLAS_TRANSPOSE_t trans_a, CBLAS_TRANSPOSE_t trans_b,
+  double alpha, const double *a, size_t a_n1, size_t a_n2, size_t a_tda,
+  const double *b, size_t b_n1, size_t b_n2, size_t b_tda, double beta,
+  double *c, size_t c_n1, size_t c_n2, size_t c_tda);
+
+int blas_dsyrk(CBLAS_UPLO_t uplo, CBLAS_TRANSPOSE_t trans, double alpha,
+  const double *a, size_t a_n1, size_t a_n2, size_t a_tda, double beta,
+  double *c, size_t c_n, size_t c_tda);
+
+int blas_dtrsm(CBLAS_SIDE_t side, CBLAS_UPLO_t uplo,
+  CBLAS_TRANSPOSE_t trans_a, CBLAS_DIAG_t diag, double alpha,
+  const double *a, size_t a_n, size_t a_tda, double *b, size_t b_n1,
+  size_t b_n2, size_t b_tda);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
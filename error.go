@@ -0,0 +1,124 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Package gsl provides functionality shared by all gsl packages. Importing
+// it (which all gsl packages do) replaces the default gsl error handler,
+// which aborts the process, by a handler recording the error on the
+// calling thread. Functions which can fail report these errors as *Error
+// values, all others return the value gsl returns in case of an error
+// (usually zero or NaN).
+package gsl
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_errno.h>
+// #include "error_handler.h"
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Errno is a gsl error code
+type Errno int
+
+// gsl error codes. See the gsl documentation for more detailed info on
+// each of these.
+const (
+	Success  Errno = C.GSL_SUCCESS
+	Failure  Errno = C.GSL_FAILURE
+	Continue Errno = C.GSL_CONTINUE
+	EDOM     Errno = C.GSL_EDOM
+	ERANGE   Errno = C.GSL_ERANGE
+	EFAULT   Errno = C.GSL_EFAULT
+	EINVAL   Errno = C.GSL_EINVAL
+	EFAILED  Errno = C.GSL_EFAILED
+	EFACTOR  Errno = C.GSL_EFACTOR
+	ESANITY  Errno = C.GSL_ESANITY
+	ENOMEM   Errno = C.GSL_ENOMEM
+	EBADFUNC Errno = C.GSL_EBADFUNC
+	ERUNAWAY Errno = C.GSL_ERUNAWAY
+	EMAXITER Errno = C.GSL_EMAXITER
+	EZERODIV Errno = C.GSL_EZERODIV
+	EBADTOL  Errno = C.GSL_EBADTOL
+	ETOL     Errno = C.GSL_ETOL
+	EUNDRFLW Errno = C.GSL_EUNDRFLW
+	EOVRFLW  Errno = C.GSL_EOVRFLW
+	ELOSS    Errno = C.GSL_ELOSS
+	EROUND   Errno = C.GSL_EROUND
+	EBADLEN  Errno = C.GSL_EBADLEN
+	ENOTSQR  Errno = C.GSL_ENOTSQR
+	ESING    Errno = C.GSL_ESING
+	EDIVERGE Errno = C.GSL_EDIVERGE
+	EUNSUP   Errno = C.GSL_EUNSUP
+	EUNIMPL  Errno = C.GSL_EUNIMPL
+	ECACHE   Errno = C.GSL_ECACHE
+	ETABLE   Errno = C.GSL_ETABLE
+	ENOPROG  Errno = C.GSL_ENOPROG
+	ENOPROGJ Errno = C.GSL_ENOPROGJ
+	ETOLF    Errno = C.GSL_ETOLF
+	ETOLX    Errno = C.GSL_ETOLX
+	ETOLG    Errno = C.GSL_ETOLG
+	EOF      Errno = C.GSL_EOF
+)
+
+// Error returns the gsl description of the error code
+func (e Errno) Error() string {
+	return C.GoString(C.gsl_strerror(C.int(e)))
+}
+
+// Error describes an error raised by gsl. Errors detected on the Go side
+// before calling gsl use the same type but leave File and Line empty.
+type Error struct {
+	Errno  Errno
+	Reason string
+	File   string
+	Line   int
+}
+
+// NewError returns an *Error with error code errno and reason formatted
+// according to format
+func NewError(errno Errno, format string, args ...interface{}) *Error {
+	return &Error{Errno: errno, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Error provides a printable string representation of the gsl error
+func (e *Error) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("%s (%s)", e.Reason, e.Errno)
+	}
+	return fmt.Sprintf("%s:%d: %s (%s)", e.File, e.Line, e.Reason, e.Errno)
+}
+
+// Unwrap returns the error code of e so errors.Is(err, gsl.EINVAL) works
+// as expected
+func (e *Error) Unwrap() error {
+	return e.Errno
+}
+
+func init() {
+	C.gsl_go_install_handler()
+}
+
+// Protect calls f and returns the first error gsl raised while running f
+// or nil if there was none. Since errors are recorded per thread f must
+// not hand its gsl calls off to other goroutines.
+func Protect(f func()) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	C.gsl_go_clear_error()
+	f()
+
+	var cErr C.gsl_go_error_t
+	if C.gsl_go_last_error(&cErr) == 0 {
+		return nil
+	}
+	return &Error{
+		Errno:  Errno(cErr.gsl_errno),
+		Reason: C.GoString(&cErr.reason[0]),
+		File:   C.GoString(&cErr.file[0]),
+		Line:   int(cErr.line),
+	}
+}
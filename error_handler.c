@@ -0,0 +1,57 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * this file provides a gsl error handler which records the first error
+ * raised on the calling thread instead of aborting the process
+ */
+
+#include <string.h>
+
+#include <gsl/gsl_errno.h>
+
+#include "error_handler.h"
+
+static __thread gsl_go_error_t last_error;
+static __thread int have_error = 0;
+
+
+/* record_error is installed as gsl error handler. Only the first error
+ * since the last call to gsl_go_clear_error is kept since subsequent
+ * errors are usually a consequence of it. */
+static void record_error(const char *reason, const char *file, int line,
+  int gsl_errno) {
+  if (have_error) {
+    return;
+  }
+
+  last_error.gsl_errno = gsl_errno;
+  strncpy(last_error.reason, reason ? reason : "", GSL_GO_ERROR_LENGTH - 1);
+  last_error.reason[GSL_GO_ERROR_LENGTH - 1] = '\0';
+  strncpy(last_error.file, file ? file : "", GSL_GO_ERROR_LENGTH - 1);
+  last_error.file[GSL_GO_ERROR_LENGTH - 1] = '\0';
+  last_error.line = line;
+  have_error = 1;
+}
+
+
+void gsl_go_install_handler(void) {
+  gsl_set_error_handler(&record_error);
+}
+
+
+void gsl_go_clear_error(void) {
+  have_error = 0;
+}
+
+
+/* gsl_go_last_error copies the recorded error into err and returns 1 if
+ * an error has been recorded on the calling thread and 0 otherwise. */
+int gsl_go_last_error(gsl_go_error_t *err) {
+  if (!have_error) {
+    return 0;
+  }
+  *err = last_error;
+  return 1;
+}
@@ -0,0 +1,25 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * this file declares the gsl error handler used by go-gsl
+ */
+
+#ifndef GSL_GO_ERROR_HANDLER_H
+#define GSL_GO_ERROR_HANDLER_H
+
+#define GSL_GO_ERROR_LENGTH 256
+
+typedef struct {
+  int gsl_errno;
+  char reason[GSL_GO_ERROR_LENGTH];
+  char file[GSL_GO_ERROR_LENGTH];
+  int line;
+} gsl_go_error_t;
+
+void gsl_go_install_handler(void);
+void gsl_go_clear_error(void);
+int gsl_go_last_error(gsl_go_error_t *err);
+
+#endif
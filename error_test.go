@@ -0,0 +1,40 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Package gsl provides functionality shared by all gsl packages
+package gsl
+
+import (
+  "errors"
+  "testing"
+)
+
+// test set 1
+func Test_error_1(t *testing.T) {
+
+  // test 1
+  if EBADLEN.Error() != "matrix, vector lengths are not conformant" {
+    t.Error("Test 1: Unexpected description of EBADLEN: ", EBADLEN.Error())
+  }
+
+  // test 2
+  err := NewError(EINVAL, "Invalid value %d.", 3)
+  if err.Error() != "Invalid value 3. (invalid argument supplied by user)" {
+    t.Error("Test 2: Unexpected error message: ", err.Error())
+  }
+  if !errors.Is(err, EINVAL) || errors.Is(err, EDOM) {
+    t.Error("Test 2: Error does not unwrap to its error code.")
+  }
+
+  // test 3
+  err = &Error{EDOM, "Input domain error.", "foo.c", 42}
+  if err.Error() != "foo.c:42: Input domain error. (input domain error)" {
+    t.Error("Test 3: Unexpected error message: ", err.Error())
+  }
+
+  // test 4
+  if err := Protect(func() {}); err != nil {
+    t.Error("Test 4: Unexpected error from Protect: ", err)
+  }
+}
@@ -0,0 +1,260 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// filter wraps the gsl digital filtering routines
+//
+// All filters use a symmetric window of length k centered on the current
+// sample, even k are rounded up to the next odd integer. Near the ends of
+// the input the window is completed according to the End mode. The
+// filtered output has the same length as the input.
+//
+// A *gsl.Error with code gsl.EBADLEN is returned for empty input and with
+// code gsl.EINVAL for invalid window lengths or filter parameters.
+// Requires GSL 2.5 or newer.
+package filter
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_errno.h>
+// #include <gsl/gsl_filter.h>
+// #include "filter_wrap.h"
+import "C"
+
+import (
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/stats"
+)
+
+// End determines how windows extending beyond the ends of the input are
+// handled
+type End int
+
+const (
+  // PadZero pads the input with zeros
+  PadZero End = C.GSL_FILTER_END_PADZERO
+
+  // PadValue pads the input with its first and last value, respectively
+  PadValue End = C.GSL_FILTER_END_PADVALUE
+
+  // Truncate only uses the available samples, i.e., windows near the
+  // ends are shorter
+  Truncate End = C.GSL_FILTER_END_TRUNCATE
+)
+
+// Scale selects the robust scale estimate used by the impulse detection
+// filter
+type Scale int
+
+const (
+  // ScaleMAD uses the median absolute deviation, see stats.FloatSlice.Mad
+  ScaleMAD Scale = C.GSL_FILTER_SCALE_MAD
+
+  // ScaleIQR uses the interquartile range scaled to estimate the standard
+  // deviation of Gaussian data
+  ScaleIQR Scale = C.GSL_FILTER_SCALE_IQR
+
+  // ScaleSn uses the Sn estimator, see stats.FloatSlice.SnFromSortedData
+  ScaleSn Scale = C.GSL_FILTER_SCALE_SN
+
+  // ScaleQn uses the Qn estimator, see stats.FloatSlice.QnFromSortedData
+  ScaleQn Scale = C.GSL_FILTER_SCALE_QN
+)
+
+// check verifies the input x and window length k
+func check(x stats.FloatSlice, k int) error {
+  if len(x) == 0 {
+    return gsl.NewError(gsl.EBADLEN, "Empty dataset.")
+  }
+  if k < 1 {
+    return gsl.NewError(gsl.EINVAL, "Invalid window length %d.", k)
+  }
+  return nil
+}
+
+// protect runs f via gsl.Protect and turns a non-zero status returned by
+// f into a *gsl.Error
+func protect(f func() C.int) error {
+  var status C.int
+  err := gsl.Protect(func() {
+    status = f()
+  })
+  if err == nil && status != 0 {
+    err = gsl.NewError(gsl.Errno(status), "Failed to apply filter.")
+  }
+  return err
+}
+
+// cptr returns a C pointer to the first element of d
+func cptr(d stats.FloatSlice) *C.double {
+  return (*C.double)(&d[0])
+}
+
+// Gaussian applies a Gaussian filter with window length k to x. The
+// width of the Gaussian is determined by alpha > 0, its standard deviation
+// is (k-1)/(2*alpha) samples, i.e., larger alpha yield narrower kernels.
+// For order 0 the smoothed signal is returned; order 1, 2, ... yield the
+// corresponding derivative of the smoothed signal by convolving with the
+// derivative of the Gaussian.
+func Gaussian(x stats.FloatSlice, k int, alpha float64, order int,
+  end End) (stats.FloatSlice, error) {
+  if err := check(x, k); err != nil {
+    return nil, err
+  }
+  if !(alpha > 0) || order < 0 {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid Gaussian width %g or "+
+      "derivative order %d.", alpha, order)
+  }
+
+  y := make(stats.FloatSlice, len(x))
+  err := protect(func() C.int {
+    w := C.gsl_filter_gaussian_alloc(C.size_t(k))
+    if w == nil {
+      return C.GSL_ENOMEM
+    }
+    defer C.gsl_filter_gaussian_free(w)
+    return C.filter_gaussian(C.gsl_filter_end_t(end), C.double(alpha),
+      C.size_t(order), cptr(x), cptr(y), C.size_t(len(x)), w)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// GaussianKernel returns the kernel of length k used by Gaussian for the
+// given alpha and derivative order. If normalize is true the kernel is
+// scaled to sum to one.
+func GaussianKernel(k int, alpha float64, order int,
+  normalize bool) (stats.FloatSlice, error) {
+  if k < 1 {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid kernel length %d.", k)
+  }
+  if !(alpha > 0) || order < 0 {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid Gaussian width %g or "+
+      "derivative order %d.", alpha, order)
+  }
+
+  norm := 0
+  if normalize {
+    norm = 1
+  }
+  kernel := make(stats.FloatSlice, k)
+  err := protect(func() C.int {
+    return C.filter_gaussian_kernel(C.double(alpha), C.size_t(order),
+      C.int(norm), cptr(kernel), C.size_t(k))
+  })
+  if err != nil {
+    return nil, err
+  }
+  return kernel, nil
+}
+
+// Median applies a standard median filter with window length k to x,
+// i.e., y[i] is the median of the window centered on x[i].
+func Median(x stats.FloatSlice, k int, end End) (stats.FloatSlice, error) {
+  if err := check(x, k); err != nil {
+    return nil, err
+  }
+
+  y := make(stats.FloatSlice, len(x))
+  err := protect(func() C.int {
+    w := C.gsl_filter_median_alloc(C.size_t(k))
+    if w == nil {
+      return C.GSL_ENOMEM
+    }
+    defer C.gsl_filter_median_free(w)
+    return C.filter_median(C.gsl_filter_end_t(end), cptr(x), cptr(y),
+      C.size_t(len(x)), w)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// RMedian applies a recursive median filter with window length k to x.
+// Unlike Median, the window centered on x[i] contains the already
+// filtered outputs y[i-k/2], ..., y[i-1] instead of the corresponding
+// inputs, which removes impulses more aggressively and yields a root
+// signal, i.e., filtering the output again does not change it.
+func RMedian(x stats.FloatSlice, k int, end End) (stats.FloatSlice, error) {
+  if err := check(x, k); err != nil {
+    return nil, err
+  }
+
+  y := make(stats.FloatSlice, len(x))
+  err := protect(func() C.int {
+    w := C.gsl_filter_rmedian_alloc(C.size_t(k))
+    if w == nil {
+      return C.GSL_ENOMEM
+    }
+    defer C.gsl_filter_rmedian_free(w)
+    return C.filter_rmedian(C.gsl_filter_end_t(end), cptr(x), cptr(y),
+      C.size_t(len(x)), w)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// Impulse holds the results of the impulse detection filter
+type Impulse struct {
+  // Y is the filtered input, outliers are replaced by the window median
+  Y stats.FloatSlice
+
+  // Median and Sigma are the window median and robust scale estimate
+  // at each sample
+  Median, Sigma stats.FloatSlice
+
+  // Outliers are the indices of the samples detected as outliers in
+  // ascending order
+  Outliers []int
+}
+
+// ImpulseDetect applies a Hampel-type impulse detection filter with window
+// length k to x. Sample x[i] is considered an outlier if
+// |x[i] - m[i]| > t*s[i], where m[i] is the median and s[i] the robust
+// scale estimate selected by scale of the window centered on x[i].
+// Outliers are replaced by the median m[i] in the filtered output. t has
+// to be non-negative.
+func ImpulseDetect(x stats.FloatSlice, k int, t float64, scale Scale,
+  end End) (*Impulse, error) {
+  if err := check(x, k); err != nil {
+    return nil, err
+  }
+  if !(t >= 0) {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid threshold %g.", t)
+  }
+
+  n := len(x)
+  res := &Impulse{
+    Y:      make(stats.FloatSlice, n),
+    Median: make(stats.FloatSlice, n),
+    Sigma:  make(stats.FloatSlice, n),
+  }
+  outlier := make([]C.int, n)
+  var noutlier C.size_t
+  err := protect(func() C.int {
+    w := C.gsl_filter_impulse_alloc(C.size_t(k))
+    if w == nil {
+      return C.GSL_ENOMEM
+    }
+    defer C.gsl_filter_impulse_free(w)
+    return C.filter_impulse(C.gsl_filter_end_t(end),
+      C.gsl_filter_scale_t(scale), C.double(t), cptr(x), cptr(res.Y),
+      cptr(res.Median), cptr(res.Sigma), &outlier[0], &noutlier,
+      C.size_t(n), w)
+  })
+  if err != nil {
+    return nil, err
+  }
+
+  res.Outliers = make([]int, 0, int(noutlier))
+  for i, o := range outlier {
+    if o != 0 {
+      res.Outliers = append(res.Outliers, i)
+    }
+  }
+  return res, nil
+}
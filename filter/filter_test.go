@@ -0,0 +1,197 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// filter wraps the gsl digital filtering routines
+package filter
+
+import (
+  "errors"
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/stats"
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_filter_1(t *testing.T) {
+
+  // test 1
+  k := 7
+  alpha := 2.5
+  kernel, err := GaussianKernel(k, alpha, 0, true)
+  if err != nil {
+    t.Fatal(err)
+  }
+  sigma := float64(k-1) / (2.0 * alpha)
+  expected := make(stats.FloatSlice, k)
+  sum := 0.0
+  for i := range expected {
+    x := float64(i - k/2)
+    expected[i] = math.Exp(-0.5 * x * x / (sigma * sigma))
+    sum += expected[i]
+  }
+  for i := range kernel {
+    if !util.FloatEqual(kernel[i], expected[i]/sum) {
+      t.Error("Test 1: Gaussian kernel differs at", i, kernel[i],
+        expected[i]/sum)
+    }
+  }
+
+  // test 2
+  deriv, err := GaussianKernel(k, alpha, 1, false)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if deriv[k/2] != 0.0 {
+    t.Error("Test 2: First derivative kernel not zero at center.")
+  }
+  for i := 0; i < k/2; i++ {
+    if !util.FloatEqual(deriv[i], -deriv[k-1-i]) {
+      t.Error("Test 2: First derivative kernel not antisymmetric at", i)
+    }
+  }
+
+  // test 3
+  constant := make(stats.FloatSlice, 20)
+  for i := range constant {
+    constant[i] = 3.0
+  }
+  smooth, err := Gaussian(constant, k, alpha, 0, PadValue)
+  if err != nil {
+    t.Fatal(err)
+  }
+  slope, err := Gaussian(constant, k, alpha, 1, PadValue)
+  if err != nil {
+    t.Fatal(err)
+  }
+  for i := range smooth {
+    if !util.FloatEqual(smooth[i], 3.0) || math.Abs(slope[i]) > 1e-12 {
+      t.Error("Test 3: Gaussian filter changed constant signal at", i,
+        smooth[i], slope[i])
+      break
+    }
+  }
+}
+
+// test set 2
+func Test_filter_2(t *testing.T) {
+
+  data := stats.FloatSlice{1.0, 2.0, 3.0, 50.0, 5.0, 6.0, 7.0, -40.0, 9.0,
+    10.0}
+
+  // test 1
+  median, err := Median(data, 3, PadValue)
+  if err != nil {
+    t.Fatal(err)
+  }
+  expected := stats.FloatSlice{1.0, 2.0, 3.0, 5.0, 6.0, 6.0, 6.0, 7.0, 9.0,
+    10.0}
+  for i := range median {
+    if median[i] != expected[i] {
+      t.Error("Test 1: Median filter differs at", i, median[i], expected[i])
+    }
+  }
+
+  // test 2
+  rmedian, err := RMedian(data, 3, PadValue)
+  if err != nil {
+    t.Fatal(err)
+  }
+  again, err := RMedian(rmedian, 3, PadValue)
+  if err != nil {
+    t.Fatal(err)
+  }
+  for i := range rmedian {
+    if rmedian[i] == 50.0 || rmedian[i] == -40.0 {
+      t.Error("Test 2: Recursive median filter kept impulse at", i)
+    }
+    if again[i] != rmedian[i] {
+      t.Error("Test 2: Recursive median filter did not yield root signal",
+        "at", i, again[i], rmedian[i])
+    }
+  }
+
+  // test 3
+  monotone := stats.FloatSlice{1.0, 2.0, 2.0, 4.0, 8.0, 9.0}
+  rmedian, err = RMedian(monotone, 5, PadValue)
+  if err != nil {
+    t.Fatal(err)
+  }
+  for i := range monotone {
+    if rmedian[i] != monotone[i] {
+      t.Error("Test 3: Recursive median filter changed monotone signal at",
+        i)
+    }
+  }
+}
+
+// test set 3
+func Test_filter_3(t *testing.T) {
+
+  data := stats.FloatSlice{1.0, 1.2, 0.9, 1.1, 1.0, 100.0, 1.1, 0.95, 1.05,
+    1.0, 1.1}
+
+  // test 1
+  for _, scale := range []Scale{ScaleMAD, ScaleIQR, ScaleSn, ScaleQn} {
+    res, err := ImpulseDetect(data, 5, 3.0, scale, PadValue)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if len(res.Outliers) != 1 || res.Outliers[0] != 5 {
+      t.Error("Test 1: Unexpected outliers for scale", scale, res.Outliers)
+      continue
+    }
+    if res.Median[5] != 1.1 || res.Y[5] != 1.1 {
+      t.Error("Test 1: Outlier was not replaced by median for scale", scale,
+        res.Y[5], res.Median[5])
+    }
+    for i := range data {
+      if i != 5 && res.Y[i] != data[i] {
+        t.Error("Test 1: Impulse filter changed inlier", i, "for scale",
+          scale)
+      }
+    }
+  }
+
+  // test 2
+  res, err := ImpulseDetect(data, 5, 0.0, ScaleMAD, PadValue)
+  if err != nil {
+    t.Fatal(err)
+  }
+  for i := range res.Y {
+    if res.Y[i] != res.Median[i] {
+      t.Error("Test 2: Zero threshold did not replace sample", i)
+    }
+  }
+}
+
+// test set 4
+func Test_filter_4(t *testing.T) {
+
+  data := stats.FloatSlice{1.0, 2.0, 3.0}
+
+  // test 1
+  if _, err := Median(nil, 3, PadZero); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 1: Median accepted empty data.")
+  }
+  if _, err := RMedian(data, 0, PadZero); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 1: RMedian accepted invalid window length.")
+  }
+
+  // test 2
+  if _, err := Gaussian(data, 3, -1.0, 0, PadZero); !errors.Is(err,
+    gsl.EINVAL) {
+    t.Error("Test 2: Gaussian accepted negative alpha.")
+  }
+  if _, err := GaussianKernel(3, 1.0, -1, true); !errors.Is(err,
+    gsl.EINVAL) {
+    t.Error("Test 2: GaussianKernel accepted negative order.")
+  }
+  if _, err := ImpulseDetect(data, 3, -1.0, ScaleQn, PadZero); !errors.Is(
+    err, gsl.EINVAL) {
+    t.Error("Test 2: ImpulseDetect accepted negative threshold.")
+  }
+}
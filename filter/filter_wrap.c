@@ -0,0 +1,73 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * filter_wrap provides wrappers around the gsl digital filters which
+ * operate on plain arrays of length n instead of gsl vectors
+ */
+
+#include <gsl/gsl_filter.h>
+#include <gsl/gsl_vector.h>
+
+#include "filter_wrap.h"
+
+
+int filter_gaussian(gsl_filter_end_t end, double alpha, size_t order,
+  const double *x, double *y, size_t n, gsl_filter_gaussian_workspace *w) {
+
+  gsl_vector_const_view x_view = gsl_vector_const_view_array(x, n);
+  gsl_vector_view y_view = gsl_vector_view_array(y, n);
+
+  return gsl_filter_gaussian(end, alpha, order, &x_view.vector,
+    &y_view.vector, w);
+}
+
+
+int filter_gaussian_kernel(double alpha, size_t order, int normalize,
+  double *kernel, size_t k) {
+
+  gsl_vector_view kernel_view = gsl_vector_view_array(kernel, k);
+
+  return gsl_filter_gaussian_kernel(alpha, order, normalize,
+    &kernel_view.vector);
+}
+
+
+int filter_median(gsl_filter_end_t end, const double *x, double *y,
+  size_t n, gsl_filter_median_workspace *w) {
+
+  gsl_vector_const_view x_view = gsl_vector_const_view_array(x, n);
+  gsl_vector_view y_view = gsl_vector_view_array(y, n);
+
+  return gsl_filter_median(end, &x_view.vector, &y_view.vector, w);
+}
+
+
+int filter_rmedian(gsl_filter_end_t end, const double *x, double *y,
+  size_t n, gsl_filter_rmedian_workspace *w) {
+
+  gsl_vector_const_view x_view = gsl_vector_const_view_array(x, n);
+  gsl_vector_view y_view = gsl_vector_view_array(y, n);
+
+  return gsl_filter_rmedian(end, &x_view.vector, &y_view.vector, w);
+}
+
+
+/* filter_impulse runs the impulse detection filter. ioutlier[i] is set to
+ * 1 if x[i] was detected as an outlier and to 0 otherwise. */
+int filter_impulse(gsl_filter_end_t end, gsl_filter_scale_t scale,
+  double t, const double *x, double *y, double *xmedian, double *xsigma,
+  int *ioutlier, size_t *noutlier, size_t n,
+  gsl_filter_impulse_workspace *w) {
+
+  gsl_vector_const_view x_view = gsl_vector_const_view_array(x, n);
+  gsl_vector_view y_view = gsl_vector_view_array(y, n);
+  gsl_vector_view median_view = gsl_vector_view_array(xmedian, n);
+  gsl_vector_view sigma_view = gsl_vector_view_array(xsigma, n);
+  gsl_vector_int_view outlier_view = gsl_vector_int_view_array(ioutlier, n);
+
+  return gsl_filter_impulse(end, scale, t, &x_view.vector, &y_view.vector,
+    &median_view.vector, &sigma_view.vector, noutlier,
+    &outlier_view.vector, w);
+}
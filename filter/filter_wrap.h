@@ -0,0 +1,45 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * filter_wrap provides wrappers around the gsl digital filters which
+ * operate on plain arrays of length n instead of gsl vectors
+ */
+
+
+#ifndef FILTER_WRAP_H
+#define FILTER_WRAP_H
+
+#include <stddef.h>
+
+#include <gsl/gsl_filter.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+int filter_gaussian(gsl_filter_end_t end, double alpha, size_t order,
+  const double *x, double *y, size_t n, gsl_filter_gaussian_workspace *w);
+
+int filter_gaussian_kernel(double alpha, size_t order, int normalize,
+  double *kernel, size_t k);
+
+int filter_median(gsl_filter_end_t end, const double *x, double *y,
+  size_t n, gsl_filter_median_workspace *w);
+
+int filter_rmedian(gsl_filter_end_t end, const double *x, double *y,
+  size_t n, gsl_filter_rmedian_workspace *w);
+
+int filter_impulse(gsl_filter_end_t end, gsl_filter_scale_t scale,
+  double t, const double *x, double *y, double *xmedian, double *xsigma,
+  int *ioutlier, size_t *noutlier, size_t n,
+  gsl_filter_impulse_workspace *w);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,466 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// histogram wraps the gsl one and two dimensional histograms
+//
+// A histogram with n bins is defined by n+1 increasing ranges; bin i
+// counts the values x with range[i] <= x < range[i+1]. Values outside
+// [range[0], range[n]) are ignored by Increment and Accumulate, which
+// report them with a *gsl.Error with code gsl.EDOM.
+//
+// The gsl histograms are released by a finalizer once a Histogram or
+// Histogram2D becomes unreachable; Free releases them right away.
+package histogram
+
+// #cgo pkg-config: gsl
+// #include <stdlib.h>
+// #include <gsl/gsl_histogram.h>
+// #include "histogram_wrap.h"
+import "C"
+
+import (
+  "bufio"
+  "errors"
+  "fmt"
+  "io"
+  "runtime"
+  "strings"
+  "unsafe"
+
+  "github.com/haskelladdict/gsl"
+)
+
+// ErrFreed is returned when using a histogram after it has been freed.
+// Functions without an error result panic with ErrFreed instead.
+var ErrFreed = errors.New("Histogram has already been freed.")
+
+// Histogram is a one dimensional histogram
+type Histogram struct {
+  h *C.gsl_histogram
+}
+
+// newHistogram wraps the gsl histogram h and registers its finalizer
+func newHistogram(h *C.gsl_histogram) *Histogram {
+  hist := &Histogram{h}
+  runtime.SetFinalizer(hist, (*Histogram).Free)
+  return hist
+}
+
+// NewUniform returns a histogram with n bins of equal width covering
+// [min, max). All bins are initialized to zero.
+func NewUniform(n int, min, max float64) (*Histogram, error) {
+  if n < 1 {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid number of bins %d.", n)
+  }
+  if !(min < max) {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid range [%g, %g).", min, max)
+  }
+  h := C.gsl_histogram_calloc_uniform(C.size_t(n), C.double(min),
+    C.double(max))
+  if h == nil {
+    return nil, gsl.NewError(gsl.ENOMEM, "Failed to allocate histogram.")
+  }
+  return newHistogram(h), nil
+}
+
+// NewRanges returns a histogram with len(ranges)-1 bins with bin i
+// covering [ranges[i], ranges[i+1]). ranges has to be strictly increasing.
+// All bins are initialized to zero.
+func NewRanges(ranges []float64) (*Histogram, error) {
+  if err := checkRanges(ranges); err != nil {
+    return nil, err
+  }
+  h := C.gsl_histogram_calloc(C.size_t(len(ranges) - 1))
+  if h == nil {
+    return nil, gsl.NewError(gsl.ENOMEM, "Failed to allocate histogram.")
+  }
+  C.gsl_histogram_set_ranges(h, (*C.double)(&ranges[0]),
+    C.size_t(len(ranges)))
+  return newHistogram(h), nil
+}
+
+// checkRanges verifies that ranges define at least one bin and are
+// strictly increasing
+func checkRanges(ranges []float64) error {
+  if len(ranges) < 2 {
+    return gsl.NewError(gsl.EINVAL, "At least two ranges are required.")
+  }
+  for i := 1; i < len(ranges); i++ {
+    if !(ranges[i-1] < ranges[i]) {
+      return gsl.NewError(gsl.EINVAL, "Ranges are not strictly increasing "+
+        "at %d.", i)
+    }
+  }
+  return nil
+}
+
+// ptr returns the gsl histogram and panics with ErrFreed if it has been
+// freed. Callers need to keep h alive until the C call using the returned
+// pointer has finished.
+func (h *Histogram) ptr() *C.gsl_histogram {
+  if h.h == nil {
+    panic(ErrFreed)
+  }
+  return h.h
+}
+
+// Free releases the gsl histogram. It is safe to call Free more than once.
+func (h *Histogram) Free() {
+  if h.h != nil {
+    C.gsl_histogram_free(h.h)
+    h.h = nil
+  }
+  runtime.SetFinalizer(h, nil)
+}
+
+// Clone returns an independent copy of h
+func (h *Histogram) Clone() *Histogram {
+  defer runtime.KeepAlive(h)
+  c := C.gsl_histogram_clone(h.ptr())
+  if c == nil {
+    panic(gsl.NewError(gsl.ENOMEM, "Failed to clone histogram."))
+  }
+  return newHistogram(c)
+}
+
+// Bins returns the number of bins
+func (h *Histogram) Bins() int {
+  defer runtime.KeepAlive(h)
+  return int(C.gsl_histogram_bins(h.ptr()))
+}
+
+// checkIndex panics if i is not a valid bin index
+func (h *Histogram) checkIndex(i int) {
+  if n := h.Bins(); i < 0 || i >= n {
+    panic(fmt.Sprintf("Bin index %d out of range [0, %d).", i, n))
+  }
+}
+
+// Get returns the content of bin i. Get panics if i is out of range.
+func (h *Histogram) Get(i int) float64 {
+  defer runtime.KeepAlive(h)
+  h.checkIndex(i)
+  return float64(C.gsl_histogram_get(h.ptr(), C.size_t(i)))
+}
+
+// Range returns the lower and upper limit of bin i. Range panics if i is
+// out of range.
+func (h *Histogram) Range(i int) (float64, float64) {
+  defer runtime.KeepAlive(h)
+  h.checkIndex(i)
+  var lower, upper C.double
+  C.gsl_histogram_get_range(h.ptr(), C.size_t(i), &lower, &upper)
+  return float64(lower), float64(upper)
+}
+
+// Max returns the upper limit of the histogram range
+func (h *Histogram) Max() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram_max(h.ptr()))
+}
+
+// Min returns the lower limit of the histogram range
+func (h *Histogram) Min() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram_min(h.ptr()))
+}
+
+// Reset sets all bins to zero
+func (h *Histogram) Reset() {
+  defer runtime.KeepAlive(h)
+  C.gsl_histogram_reset(h.ptr())
+}
+
+// Increment adds one to the bin containing x. A *gsl.Error with code
+// gsl.EDOM is returned if x is outside the histogram range, the histogram
+// is not modified in this case.
+func (h *Histogram) Increment(x float64) error {
+  return h.Accumulate(x, 1.0)
+}
+
+// Accumulate adds weight to the bin containing x. A *gsl.Error with code
+// gsl.EDOM is returned if x is outside the histogram range, the histogram
+// is not modified in this case.
+func (h *Histogram) Accumulate(x, weight float64) error {
+  defer runtime.KeepAlive(h)
+  if C.gsl_histogram_accumulate(h.ptr(), C.double(x), C.double(weight)) != 0 {
+    return gsl.NewError(gsl.EDOM, "Value %g outside of histogram range.", x)
+  }
+  return nil
+}
+
+// IncrementSlice increments the bins containing each of the values in
+// data in a single call into gsl and returns the number of values which
+// were ignored since they are outside the histogram range.
+func (h *Histogram) IncrementSlice(data []float64) int {
+  defer runtime.KeepAlive(h)
+  p := h.ptr()
+  if len(data) == 0 {
+    return 0
+  }
+  return int(C.histogram_increment_n(p, (*C.double)(&data[0]),
+    C.size_t(len(data))))
+}
+
+// Find returns the index of the bin containing x. A *gsl.Error with code
+// gsl.EDOM is returned if x is outside the histogram range.
+func (h *Histogram) Find(x float64) (int, error) {
+  defer runtime.KeepAlive(h)
+  p := h.ptr()
+  if !(x >= h.Min() && x < h.Max()) {
+    return 0, gsl.NewError(gsl.EDOM, "Value %g outside of histogram range.",
+      x)
+  }
+  var i C.size_t
+  err := gsl.Protect(func() {
+    C.gsl_histogram_find(p, C.double(x), &i)
+  })
+  return int(i), err
+}
+
+// MaxVal returns the maximum value contained in the bins
+func (h *Histogram) MaxVal() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram_max_val(h.ptr()))
+}
+
+// MaxBin returns the index of the bin containing the maximum value. In
+// case of ties the smallest index is returned.
+func (h *Histogram) MaxBin() int {
+  defer runtime.KeepAlive(h)
+  return int(C.gsl_histogram_max_bin(h.ptr()))
+}
+
+// MinVal returns the minimum value contained in the bins
+func (h *Histogram) MinVal() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram_min_val(h.ptr()))
+}
+
+// MinBin returns the index of the bin containing the minimum value. In
+// case of ties the smallest index is returned.
+func (h *Histogram) MinBin() int {
+  defer runtime.KeepAlive(h)
+  return int(C.gsl_histogram_min_bin(h.ptr()))
+}
+
+// Mean returns the mean of the histogrammed variable, where each bin is
+// represented by its center and weighted by its content. Negative bin
+// contents are ignored.
+func (h *Histogram) Mean() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram_mean(h.ptr()))
+}
+
+// Sigma returns the standard deviation of the histogrammed variable
+// computed like Mean
+func (h *Histogram) Sigma() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram_sigma(h.ptr()))
+}
+
+// Sum returns the sum of all bin contents. Negative contents are included.
+func (h *Histogram) Sum() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram_sum(h.ptr()))
+}
+
+// EqualBins returns true if h and other have identical bin ranges
+func (h *Histogram) EqualBins(other *Histogram) bool {
+  defer runtime.KeepAlive(h)
+  defer runtime.KeepAlive(other)
+  return C.gsl_histogram_equal_bins_p(h.ptr(), other.ptr()) != 0
+}
+
+// binOp checks that h and other have identical bins and applies op
+func (h *Histogram) binOp(other *Histogram,
+  op func(h1, h2 *C.gsl_histogram) C.int) error {
+  defer runtime.KeepAlive(h)
+  defer runtime.KeepAlive(other)
+  if h.h == nil || other.h == nil {
+    return ErrFreed
+  }
+  if !h.EqualBins(other) {
+    return gsl.NewError(gsl.EINVAL, "Histograms have different binning.")
+  }
+  if status := op(h.h, other.h); status != 0 {
+    return gsl.NewError(gsl.Errno(status), "Histogram operation failed.")
+  }
+  return nil
+}
+
+// Add adds the contents of the bins of other to the corresponding bins of
+// h. A *gsl.Error with code gsl.EINVAL is returned if the histograms have
+// different bin ranges.
+func (h *Histogram) Add(other *Histogram) error {
+  return h.binOp(other, func(h1, h2 *C.gsl_histogram) C.int {
+    return C.gsl_histogram_add(h1, h2)
+  })
+}
+
+// Sub subtracts the contents of the bins of other from the corresponding
+// bins of h. A *gsl.Error with code gsl.EINVAL is returned if the
+// histograms have different bin ranges.
+func (h *Histogram) Sub(other *Histogram) error {
+  return h.binOp(other, func(h1, h2 *C.gsl_histogram) C.int {
+    return C.gsl_histogram_sub(h1, h2)
+  })
+}
+
+// Mul multiplies the contents of the bins of h by the contents of the
+// corresponding bins of other. A *gsl.Error with code gsl.EINVAL is
+// returned if the histograms have different bin ranges.
+func (h *Histogram) Mul(other *Histogram) error {
+  return h.binOp(other, func(h1, h2 *C.gsl_histogram) C.int {
+    return C.gsl_histogram_mul(h1, h2)
+  })
+}
+
+// Div divides the contents of the bins of h by the contents of the
+// corresponding bins of other. A *gsl.Error with code gsl.EINVAL is
+// returned if the histograms have different bin ranges.
+func (h *Histogram) Div(other *Histogram) error {
+  return h.binOp(other, func(h1, h2 *C.gsl_histogram) C.int {
+    return C.gsl_histogram_div(h1, h2)
+  })
+}
+
+// Scale multiplies the contents of all bins by scale
+func (h *Histogram) Scale(scale float64) {
+  defer runtime.KeepAlive(h)
+  C.gsl_histogram_scale(h.ptr(), C.double(scale))
+}
+
+// Shift adds offset to the contents of all bins
+func (h *Histogram) Shift(offset float64) {
+  defer runtime.KeepAlive(h)
+  C.gsl_histogram_shift(h.ptr(), C.double(offset))
+}
+
+// bins returns the bin contents of h as a slice backed by gsl memory.
+// Callers need to keep h alive while using the slice.
+func (h *Histogram) bins() []float64 {
+  p := h.ptr()
+  return unsafe.Slice((*float64)(unsafe.Pointer(p.bin)), int(p.n))
+}
+
+// Fprintf writes h to w via gsl_histogram_fprintf, i.e., one line per bin
+// containing its lower and upper limit formatted with rangeFormat and its
+// content formatted with binFormat, separated by spaces. The formats are
+// C printf formats which have to contain exactly one conversion of a
+// double, such as "%g" or "%12.6e", otherwise a *gsl.Error with code
+// gsl.EINVAL is returned.
+func (h *Histogram) Fprintf(w io.Writer, rangeFormat,
+  binFormat string) error {
+  defer runtime.KeepAlive(h)
+  p := h.ptr()
+  return fprintf(w, rangeFormat, binFormat,
+    func(cRange, cBin *C.char) *C.char {
+      return C.histogram_sprintf(p, cRange, cBin)
+    })
+}
+
+// fprintf checks rangeFormat and binFormat and writes the output of
+// sprintf, which formats a histogram via gsl, to w
+func fprintf(w io.Writer, rangeFormat, binFormat string,
+  sprintf func(cRange, cBin *C.char) *C.char) error {
+  if err := checkFormat(rangeFormat); err != nil {
+    return err
+  }
+  if err := checkFormat(binFormat); err != nil {
+    return err
+  }
+  cRange, cBin := C.CString(rangeFormat), C.CString(binFormat)
+  defer C.free(unsafe.Pointer(cRange))
+  defer C.free(unsafe.Pointer(cBin))
+
+  var out *C.char
+  if err := gsl.Protect(func() {
+    out = sprintf(cRange, cBin)
+  }); err != nil {
+    return err
+  }
+  if out == nil {
+    return gsl.NewError(gsl.EFAILED, "Failed to format histogram.")
+  }
+  defer C.free(unsafe.Pointer(out))
+  _, err := io.WriteString(w, C.GoString(out))
+  return err
+}
+
+// checkFormat returns a *gsl.Error with code gsl.EINVAL unless format is
+// a C printf format with exactly one conversion of a double, the only
+// argument gsl passes along with it. Literal text and %% are allowed.
+func checkFormat(format string) error {
+  conversions := 0
+  for i := 0; i < len(format); i++ {
+    if format[i] != '%' {
+      continue
+    }
+    i++
+    if i < len(format) && format[i] == '%' {
+      continue
+    }
+    for i < len(format) && strings.IndexByte("+- #0", format[i]) >= 0 {
+      i++
+    }
+    for i < len(format) && '0' <= format[i] && format[i] <= '9' {
+      i++
+    }
+    if i < len(format) && format[i] == '.' {
+      i++
+      for i < len(format) && '0' <= format[i] && format[i] <= '9' {
+        i++
+      }
+    }
+    if i < len(format) && format[i] == 'l' {
+      i++
+    }
+    if i == len(format) || strings.IndexByte("eEfFgG", format[i]) < 0 {
+      return gsl.NewError(gsl.EINVAL, "Invalid conversion in format %q.",
+        format)
+    }
+    conversions++
+  }
+  if conversions != 1 {
+    return gsl.NewError(gsl.EINVAL,
+      "Format %q has to contain exactly one conversion.", format)
+  }
+  return nil
+}
+
+// Fscanf reads the bin ranges and contents of h from r in the format
+// written by Fprintf or gsl_histogram_fprintf. The number of bins of h
+// determines the number of lines read. If r does not implement
+// io.RuneScanner it may be read beyond the last line.
+func (h *Histogram) Fscanf(r io.Reader) error {
+  defer runtime.KeepAlive(h)
+  bins := h.bins()
+  ranges := make([]float64, len(bins)+1)
+  contents := make([]float64, len(bins))
+  in := runeScanner(r)
+  for i := range bins {
+    if _, err := fmt.Fscan(in, &ranges[i], &ranges[i+1],
+      &contents[i]); err != nil {
+      return fmt.Errorf("Failed to read bin %d of histogram: %v", i, err)
+    }
+  }
+  if err := checkRanges(ranges); err != nil {
+    return err
+  }
+  C.gsl_histogram_set_ranges(h.ptr(), (*C.double)(&ranges[0]),
+    C.size_t(len(ranges)))
+  copy(bins, contents)
+  return nil
+}
+
+// runeScanner returns r if it implements io.RuneScanner and a buffered
+// reader wrapping r otherwise. This keeps fmt.Fscan from dropping the
+// character following each value.
+func runeScanner(r io.Reader) io.Reader {
+  if _, ok := r.(io.RuneScanner); ok {
+    return r
+  }
+  return bufio.NewReader(r)
+}
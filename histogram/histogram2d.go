@@ -0,0 +1,438 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// histogram2d wraps the gsl two dimensional histograms
+package histogram
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_histogram2d.h>
+// #include "histogram_wrap.h"
+import "C"
+
+import (
+  "fmt"
+  "io"
+  "runtime"
+  "unsafe"
+
+  "github.com/haskelladdict/gsl"
+)
+
+// Histogram2D is a two dimensional histogram. Bin (i, j) counts the
+// points (x, y) with xrange[i] <= x < xrange[i+1] and
+// yrange[j] <= y < yrange[j+1].
+type Histogram2D struct {
+  h *C.gsl_histogram2d
+}
+
+// newHistogram2D wraps the gsl histogram h and registers its finalizer
+func newHistogram2D(h *C.gsl_histogram2d) *Histogram2D {
+  hist := &Histogram2D{h}
+  runtime.SetFinalizer(hist, (*Histogram2D).Free)
+  return hist
+}
+
+// NewUniform2D returns a histogram with nx by ny bins of equal size
+// covering [xmin, xmax) x [ymin, ymax). All bins are initialized to zero.
+func NewUniform2D(nx, ny int, xmin, xmax, ymin,
+  ymax float64) (*Histogram2D, error) {
+  if nx < 1 || ny < 1 {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid number of bins %d x %d.",
+      nx, ny)
+  }
+  if !(xmin < xmax) || !(ymin < ymax) {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid range [%g, %g) x "+
+      "[%g, %g).", xmin, xmax, ymin, ymax)
+  }
+  h := C.gsl_histogram2d_calloc_uniform(C.size_t(nx), C.size_t(ny),
+    C.double(xmin), C.double(xmax), C.double(ymin), C.double(ymax))
+  if h == nil {
+    return nil, gsl.NewError(gsl.ENOMEM, "Failed to allocate histogram.")
+  }
+  return newHistogram2D(h), nil
+}
+
+// NewRanges2D returns a histogram with len(xranges)-1 by len(yranges)-1
+// bins defined by the strictly increasing ranges xranges and yranges. All
+// bins are initialized to zero.
+func NewRanges2D(xranges, yranges []float64) (*Histogram2D, error) {
+  if err := checkRanges(xranges); err != nil {
+    return nil, err
+  }
+  if err := checkRanges(yranges); err != nil {
+    return nil, err
+  }
+  h := C.gsl_histogram2d_calloc(C.size_t(len(xranges)-1),
+    C.size_t(len(yranges)-1))
+  if h == nil {
+    return nil, gsl.NewError(gsl.ENOMEM, "Failed to allocate histogram.")
+  }
+  C.gsl_histogram2d_set_ranges(h, (*C.double)(&xranges[0]),
+    C.size_t(len(xranges)), (*C.double)(&yranges[0]),
+    C.size_t(len(yranges)))
+  return newHistogram2D(h), nil
+}
+
+// ptr returns the gsl histogram and panics with ErrFreed if it has been
+// freed. Callers need to keep h alive until the C call using the returned
+// pointer has finished.
+func (h *Histogram2D) ptr() *C.gsl_histogram2d {
+  if h.h == nil {
+    panic(ErrFreed)
+  }
+  return h.h
+}
+
+// Free releases the gsl histogram. It is safe to call Free more than once.
+func (h *Histogram2D) Free() {
+  if h.h != nil {
+    C.gsl_histogram2d_free(h.h)
+    h.h = nil
+  }
+  runtime.SetFinalizer(h, nil)
+}
+
+// Clone returns an independent copy of h
+func (h *Histogram2D) Clone() *Histogram2D {
+  defer runtime.KeepAlive(h)
+  c := C.gsl_histogram2d_clone(h.ptr())
+  if c == nil {
+    panic(gsl.NewError(gsl.ENOMEM, "Failed to clone histogram."))
+  }
+  return newHistogram2D(c)
+}
+
+// Nx returns the number of bins in x direction
+func (h *Histogram2D) Nx() int {
+  defer runtime.KeepAlive(h)
+  return int(C.gsl_histogram2d_nx(h.ptr()))
+}
+
+// Ny returns the number of bins in y direction
+func (h *Histogram2D) Ny() int {
+  defer runtime.KeepAlive(h)
+  return int(C.gsl_histogram2d_ny(h.ptr()))
+}
+
+// checkIndex panics if (i, j) is not a valid bin index
+func (h *Histogram2D) checkIndex(i, j int) {
+  nx, ny := h.Nx(), h.Ny()
+  if i < 0 || i >= nx || j < 0 || j >= ny {
+    panic(fmt.Sprintf("Bin index (%d, %d) out of range [0, %d) x [0, %d).",
+      i, j, nx, ny))
+  }
+}
+
+// Get returns the content of bin (i, j). Get panics if (i, j) is out of
+// range.
+func (h *Histogram2D) Get(i, j int) float64 {
+  defer runtime.KeepAlive(h)
+  h.checkIndex(i, j)
+  return float64(C.gsl_histogram2d_get(h.ptr(), C.size_t(i), C.size_t(j)))
+}
+
+// XRange returns the lower and upper limit of the bins with x index i.
+// XRange panics if i is out of range.
+func (h *Histogram2D) XRange(i int) (float64, float64) {
+  defer runtime.KeepAlive(h)
+  h.checkIndex(i, 0)
+  var lower, upper C.double
+  C.gsl_histogram2d_get_xrange(h.ptr(), C.size_t(i), &lower, &upper)
+  return float64(lower), float64(upper)
+}
+
+// YRange returns the lower and upper limit of the bins with y index j.
+// YRange panics if j is out of range.
+func (h *Histogram2D) YRange(j int) (float64, float64) {
+  defer runtime.KeepAlive(h)
+  h.checkIndex(0, j)
+  var lower, upper C.double
+  C.gsl_histogram2d_get_yrange(h.ptr(), C.size_t(j), &lower, &upper)
+  return float64(lower), float64(upper)
+}
+
+// XMax returns the upper limit of the histogram range in x direction
+func (h *Histogram2D) XMax() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_xmax(h.ptr()))
+}
+
+// XMin returns the lower limit of the histogram range in x direction
+func (h *Histogram2D) XMin() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_xmin(h.ptr()))
+}
+
+// YMax returns the upper limit of the histogram range in y direction
+func (h *Histogram2D) YMax() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_ymax(h.ptr()))
+}
+
+// YMin returns the lower limit of the histogram range in y direction
+func (h *Histogram2D) YMin() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_ymin(h.ptr()))
+}
+
+// Reset sets all bins to zero
+func (h *Histogram2D) Reset() {
+  defer runtime.KeepAlive(h)
+  C.gsl_histogram2d_reset(h.ptr())
+}
+
+// Increment adds one to the bin containing (x, y). A *gsl.Error with code
+// gsl.EDOM is returned if (x, y) is outside the histogram range, the
+// histogram is not modified in this case.
+func (h *Histogram2D) Increment(x, y float64) error {
+  return h.Accumulate(x, y, 1.0)
+}
+
+// Accumulate adds weight to the bin containing (x, y). A *gsl.Error with
+// code gsl.EDOM is returned if (x, y) is outside the histogram range, the
+// histogram is not modified in this case.
+func (h *Histogram2D) Accumulate(x, y, weight float64) error {
+  defer runtime.KeepAlive(h)
+  if C.gsl_histogram2d_accumulate(h.ptr(), C.double(x), C.double(y),
+    C.double(weight)) != 0 {
+    return gsl.NewError(gsl.EDOM, "Point (%g, %g) outside of histogram "+
+      "range.", x, y)
+  }
+  return nil
+}
+
+// IncrementSlice increments the bins containing each of the points
+// (x[i], y[i]) in a single call into gsl and returns the number of points
+// which were ignored since they are outside the histogram range. A
+// *gsl.Error with code gsl.EBADLEN is returned if x and y differ in
+// length.
+func (h *Histogram2D) IncrementSlice(x, y []float64) (int, error) {
+  defer runtime.KeepAlive(h)
+  p := h.ptr()
+  if len(x) != len(y) {
+    return 0, gsl.NewError(gsl.EBADLEN, "Lengths %d and %d of coordinates "+
+      "differ.", len(x), len(y))
+  }
+  if len(x) == 0 {
+    return 0, nil
+  }
+  return int(C.histogram2d_increment_n(p, (*C.double)(&x[0]),
+    (*C.double)(&y[0]), C.size_t(len(x)))), nil
+}
+
+// Find returns the index of the bin containing (x, y). A *gsl.Error with
+// code gsl.EDOM is returned if (x, y) is outside the histogram range.
+func (h *Histogram2D) Find(x, y float64) (int, int, error) {
+  defer runtime.KeepAlive(h)
+  p := h.ptr()
+  if !(x >= h.XMin() && x < h.XMax() && y >= h.YMin() && y < h.YMax()) {
+    return 0, 0, gsl.NewError(gsl.EDOM, "Point (%g, %g) outside of "+
+      "histogram range.", x, y)
+  }
+  var i, j C.size_t
+  err := gsl.Protect(func() {
+    C.gsl_histogram2d_find(p, C.double(x), C.double(y), &i, &j)
+  })
+  return int(i), int(j), err
+}
+
+// MaxVal returns the maximum value contained in the bins
+func (h *Histogram2D) MaxVal() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_max_val(h.ptr()))
+}
+
+// MaxBin returns the index of the bin containing the maximum value. In
+// case of ties the first one in row major order is returned.
+func (h *Histogram2D) MaxBin() (int, int) {
+  defer runtime.KeepAlive(h)
+  var i, j C.size_t
+  C.gsl_histogram2d_max_bin(h.ptr(), &i, &j)
+  return int(i), int(j)
+}
+
+// MinVal returns the minimum value contained in the bins
+func (h *Histogram2D) MinVal() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_min_val(h.ptr()))
+}
+
+// MinBin returns the index of the bin containing the minimum value. In
+// case of ties the first one in row major order is returned.
+func (h *Histogram2D) MinBin() (int, int) {
+  defer runtime.KeepAlive(h)
+  var i, j C.size_t
+  C.gsl_histogram2d_min_bin(h.ptr(), &i, &j)
+  return int(i), int(j)
+}
+
+// XMean returns the mean of the histogrammed x variable, where each bin is
+// represented by its center and weighted by its content. Negative bin
+// contents are ignored.
+func (h *Histogram2D) XMean() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_xmean(h.ptr()))
+}
+
+// YMean returns the mean of the histogrammed y variable computed like
+// XMean
+func (h *Histogram2D) YMean() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_ymean(h.ptr()))
+}
+
+// XSigma returns the standard deviation of the histogrammed x variable
+// computed like XMean
+func (h *Histogram2D) XSigma() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_xsigma(h.ptr()))
+}
+
+// YSigma returns the standard deviation of the histogrammed y variable
+// computed like XMean
+func (h *Histogram2D) YSigma() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_ysigma(h.ptr()))
+}
+
+// Cov returns the covariance of the histogrammed x and y variables
+// computed like XMean
+func (h *Histogram2D) Cov() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_cov(h.ptr()))
+}
+
+// Sum returns the sum of all bin contents. Negative contents are included.
+func (h *Histogram2D) Sum() float64 {
+  defer runtime.KeepAlive(h)
+  return float64(C.gsl_histogram2d_sum(h.ptr()))
+}
+
+// EqualBins returns true if h and other have identical bin ranges
+func (h *Histogram2D) EqualBins(other *Histogram2D) bool {
+  defer runtime.KeepAlive(h)
+  defer runtime.KeepAlive(other)
+  return C.gsl_histogram2d_equal_bins_p(h.ptr(), other.ptr()) != 0
+}
+
+// binOp checks that h and other have identical bins and applies op
+func (h *Histogram2D) binOp(other *Histogram2D,
+  op func(h1, h2 *C.gsl_histogram2d) C.int) error {
+  defer runtime.KeepAlive(h)
+  defer runtime.KeepAlive(other)
+  if h.h == nil || other.h == nil {
+    return ErrFreed
+  }
+  if !h.EqualBins(other) {
+    return gsl.NewError(gsl.EINVAL, "Histograms have different binning.")
+  }
+  if status := op(h.h, other.h); status != 0 {
+    return gsl.NewError(gsl.Errno(status), "Histogram operation failed.")
+  }
+  return nil
+}
+
+// Add adds the contents of the bins of other to the corresponding bins of
+// h. A *gsl.Error with code gsl.EINVAL is returned if the histograms have
+// different bin ranges.
+func (h *Histogram2D) Add(other *Histogram2D) error {
+  return h.binOp(other, func(h1, h2 *C.gsl_histogram2d) C.int {
+    return C.gsl_histogram2d_add(h1, h2)
+  })
+}
+
+// Sub subtracts the contents of the bins of other from the corresponding
+// bins of h. A *gsl.Error with code gsl.EINVAL is returned if the
+// histograms have different bin ranges.
+func (h *Histogram2D) Sub(other *Histogram2D) error {
+  return h.binOp(other, func(h1, h2 *C.gsl_histogram2d) C.int {
+    return C.gsl_histogram2d_sub(h1, h2)
+  })
+}
+
+// Mul multiplies the contents of the bins of h by the contents of the
+// corresponding bins of other. A *gsl.Error with code gsl.EINVAL is
+// returned if the histograms have different bin ranges.
+func (h *Histogram2D) Mul(other *Histogram2D) error {
+  return h.binOp(other, func(h1, h2 *C.gsl_histogram2d) C.int {
+    return C.gsl_histogram2d_mul(h1, h2)
+  })
+}
+
+// Div divides the contents of the bins of h by the contents of the
+// corresponding bins of other. A *gsl.Error with code gsl.EINVAL is
+// returned if the histograms have different bin ranges.
+func (h *Histogram2D) Div(other *Histogram2D) error {
+  return h.binOp(other, func(h1, h2 *C.gsl_histogram2d) C.int {
+    return C.gsl_histogram2d_div(h1, h2)
+  })
+}
+
+// Scale multiplies the contents of all bins by scale
+func (h *Histogram2D) Scale(scale float64) {
+  defer runtime.KeepAlive(h)
+  C.gsl_histogram2d_scale(h.ptr(), C.double(scale))
+}
+
+// Shift adds offset to the contents of all bins
+func (h *Histogram2D) Shift(offset float64) {
+  defer runtime.KeepAlive(h)
+  C.gsl_histogram2d_shift(h.ptr(), C.double(offset))
+}
+
+// bins returns the bin contents of h in row major order as a slice backed
+// by gsl memory. Callers need to keep h alive while using the slice.
+func (h *Histogram2D) bins() []float64 {
+  p := h.ptr()
+  return unsafe.Slice((*float64)(unsafe.Pointer(p.bin)), int(p.nx*p.ny))
+}
+
+// Fprintf writes h to w via gsl_histogram2d_fprintf, i.e., one line per
+// bin containing the lower and upper x and y limits formatted with
+// rangeFormat and its content formatted with binFormat, separated by
+// spaces. The bins are written in row major order with a blank line after
+// each row. See Histogram.Fprintf for the formats.
+func (h *Histogram2D) Fprintf(w io.Writer, rangeFormat,
+  binFormat string) error {
+  defer runtime.KeepAlive(h)
+  p := h.ptr()
+  return fprintf(w, rangeFormat, binFormat,
+    func(cRange, cBin *C.char) *C.char {
+      return C.histogram2d_sprintf(p, cRange, cBin)
+    })
+}
+
+// Fscanf reads the bin ranges and contents of h from r in the format
+// written by Fprintf or gsl_histogram2d_fprintf. The number of bins of h
+// determines the number of lines read. If r does not implement
+// io.RuneScanner it may be read beyond the last line.
+func (h *Histogram2D) Fscanf(r io.Reader) error {
+  defer runtime.KeepAlive(h)
+  bins := h.bins()
+  nx, ny := h.Nx(), h.Ny()
+  xranges := make([]float64, nx+1)
+  yranges := make([]float64, ny+1)
+  contents := make([]float64, len(bins))
+  in := runeScanner(r)
+  for i := 0; i < nx; i++ {
+    for j := 0; j < ny; j++ {
+      if _, err := fmt.Fscan(in, &xranges[i], &xranges[i+1], &yranges[j],
+        &yranges[j+1], &contents[i*ny+j]); err != nil {
+        return fmt.Errorf("Failed to read bin (%d, %d) of histogram: %v",
+          i, j, err)
+      }
+    }
+  }
+  if err := checkRanges(xranges); err != nil {
+    return err
+  }
+  if err := checkRanges(yranges); err != nil {
+    return err
+  }
+  C.gsl_histogram2d_set_ranges(h.ptr(), (*C.double)(&xranges[0]),
+    C.size_t(len(xranges)), (*C.double)(&yranges[0]),
+    C.size_t(len(yranges)))
+  copy(bins, contents)
+  return nil
+}
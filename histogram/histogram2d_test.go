@@ -0,0 +1,211 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// histogram2d wraps the gsl two dimensional histograms
+package histogram
+
+import (
+  "bytes"
+  "errors"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_histogram2d_1(t *testing.T) {
+
+  hist, err := NewUniform2D(2, 3, 0.0, 2.0, 0.0, 3.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist.Free()
+
+  // test 1
+  points := [][2]float64{{0.5, 0.5}, {0.5, 2.5}, {1.5, 1.5}, {1.5, 1.5}}
+  for _, p := range points {
+    if err := hist.Increment(p[0], p[1]); err != nil {
+      t.Error("Test 1: Failed to increment histogram.", err)
+    }
+  }
+  if err := hist.Increment(2.0, 0.5); !errors.Is(err, gsl.EDOM) {
+    t.Error("Test 1: Incremented histogram with point out of range.")
+  }
+  if hist.Nx() != 2 || hist.Ny() != 3 || hist.Get(0, 0) != 1.0 ||
+    hist.Get(0, 2) != 1.0 || hist.Get(1, 1) != 2.0 || hist.Get(1, 0) != 0.0 {
+    t.Error("Test 1: Wrong histogram contents.")
+  }
+
+  // test 2
+  if lower, upper := hist.XRange(1); lower != 1.0 || upper != 2.0 {
+    t.Error("Test 2: Wrong x range of bin 1", lower, upper)
+  }
+  if lower, upper := hist.YRange(2); lower != 2.0 || upper != 3.0 {
+    t.Error("Test 2: Wrong y range of bin 2", lower, upper)
+  }
+  if hist.XMin() != 0.0 || hist.XMax() != 2.0 || hist.YMin() != 0.0 ||
+    hist.YMax() != 3.0 {
+    t.Error("Test 2: Wrong histogram range.")
+  }
+
+  // test 3
+  if i, j := hist.MaxBin(); i != 1 || j != 1 || hist.MaxVal() != 2.0 {
+    t.Error("Test 3: Wrong maximum bin", i, j)
+  }
+  if i, j := hist.MinBin(); i != 0 || j != 1 || hist.MinVal() != 0.0 {
+    t.Error("Test 3: Wrong minimum bin", i, j)
+  }
+  if i, j, err := hist.Find(1.2, 2.7); err != nil || i != 1 || j != 2 {
+    t.Error("Test 3: Failed to find bin.", i, j, err)
+  }
+  if _, _, err := hist.Find(1.2, 3.7); !errors.Is(err, gsl.EDOM) {
+    t.Error("Test 3: Found bin for point out of range.")
+  }
+
+  // test 4
+  xmean := (0.5 + 0.5 + 1.5 + 1.5) / 4.0
+  ymean := (0.5 + 2.5 + 1.5 + 1.5) / 4.0
+  var xvar, yvar, cov float64
+  for _, p := range points {
+    xvar += (p[0] - xmean) * (p[0] - xmean) / 4.0
+    yvar += (p[1] - ymean) * (p[1] - ymean) / 4.0
+    cov += (p[0] - xmean) * (p[1] - ymean) / 4.0
+  }
+  if hist.Sum() != 4.0 || !util.FloatEqual(hist.XMean(), xmean) ||
+    !util.FloatEqual(hist.YMean(), ymean) ||
+    !util.FloatEqual(hist.XSigma()*hist.XSigma(), xvar) ||
+    !util.FloatEqual(hist.YSigma()*hist.YSigma(), yvar) ||
+    !util.FloatEqual(hist.Cov(), cov) {
+    t.Error("Test 4: Wrong histogram statistics", hist.XMean(),
+      hist.YMean(), hist.XSigma(), hist.YSigma(), hist.Cov())
+  }
+
+  // test 5
+  if err := hist.Accumulate(0.5, 1.5, -0.5); err != nil ||
+    hist.Get(0, 1) != -0.5 {
+    t.Error("Test 5: Failed to accumulate weight.", err)
+  }
+  hist.Reset()
+  if hist.Sum() != 0.0 {
+    t.Error("Test 5: Failed to reset histogram.")
+  }
+}
+
+// test set 2
+func Test_histogram2d_2(t *testing.T) {
+
+  // test 1
+  hist, err := NewRanges2D([]float64{0.0, 1.0, 10.0},
+    []float64{-1.0, 0.0, 1.0})
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist.Free()
+  x := []float64{0.5, 5.0, 5.0, 20.0}
+  y := []float64{-0.5, 0.5, 0.7, 0.0}
+  outside, err := hist.IncrementSlice(x, y)
+  if err != nil || outside != 1 || hist.Get(0, 0) != 1.0 ||
+    hist.Get(1, 1) != 2.0 {
+    t.Error("Test 1: Failed to increment histogram from slices.", outside,
+      err)
+  }
+  if _, err := hist.IncrementSlice(x, y[:2]); !errors.Is(err,
+    gsl.EBADLEN) {
+    t.Error("Test 1: Accepted coordinates of different length.")
+  }
+
+  // test 2
+  clone := hist.Clone()
+  defer clone.Free()
+  if err := hist.Add(clone); err != nil || hist.Get(1, 1) != 4.0 {
+    t.Error("Test 2: Failed to add histograms.", err)
+  }
+  if err := hist.Sub(clone); err != nil || hist.Get(1, 1) != 2.0 {
+    t.Error("Test 2: Failed to subtract histograms.", err)
+  }
+  if err := hist.Mul(clone); err != nil || hist.Get(1, 1) != 4.0 {
+    t.Error("Test 2: Failed to multiply histograms.", err)
+  }
+  if err := hist.Div(clone); err != nil || hist.Get(1, 1) != 2.0 {
+    t.Error("Test 2: Failed to divide histograms.", err)
+  }
+  hist.Scale(0.5)
+  hist.Shift(1.0)
+  if hist.Get(1, 1) != 2.0 || hist.Get(1, 0) != 1.0 {
+    t.Error("Test 2: Failed to scale and shift histogram.")
+  }
+
+  // test 3
+  other, err := NewUniform2D(2, 2, 0.0, 10.0, -1.0, 1.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer other.Free()
+  if err := hist.Add(other); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 3: Added histograms with different binning.")
+  }
+
+  // test 4
+  var buf bytes.Buffer
+  if err := hist.Fprintf(&buf, "%g", "%g"); err != nil {
+    t.Fatal(err)
+  }
+  expected := "0 1 -1 0 1.5\n0 1 0 1 1\n\n1 10 -1 0 1\n1 10 0 1 2\n\n"
+  if buf.String() != expected {
+    t.Errorf("Test 4: Unexpected text format %q", buf.String())
+  }
+  if err := other.Fscanf(&buf); err != nil {
+    t.Fatal(err)
+  }
+  if !other.EqualBins(hist) || other.Get(0, 0) != 1.5 ||
+    other.Get(1, 1) != 2.0 {
+    t.Error("Test 4: Failed to read histogram.")
+  }
+}
+
+// test set 3
+func Test_histogram2d_3(t *testing.T) {
+
+  // test 1: Fprintf uses the C printf formats
+  hist, err := NewRanges2D([]float64{-1e-5, 0.1234567, 1234567.0},
+    []float64{-3.0, 1.0/3.0, 1e21})
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist.Free()
+  hist.Accumulate(0.0, 0.0, 1.0/3.0)
+  hist.Accumulate(1.0, 0.0, -2.5e-7)
+  hist.Accumulate(100.0, 1e20, 123456789.0)
+
+  // output of gsl_histogram2d_fprintf
+  expected := []struct {
+    rangeFormat, binFormat, text string
+  }{
+    {"%e", "%.3f",
+      "-1.000000e-05 1.234567e-01 -3.000000e+00 3.333333e-01 0.333\n" +
+      "-1.000000e-05 1.234567e-01 3.333333e-01 1.000000e+21 0.000\n" +
+      "\n" +
+      "1.234567e-01 1.234567e+06 -3.000000e+00 3.333333e-01 -0.000\n" +
+      "1.234567e-01 1.234567e+06 3.333333e-01 1.000000e+21 123456789.000\n" +
+      "\n"},
+    {"%#g", "%lg",
+      "-1.00000e-05 0.123457 -3.00000 0.333333 0.333333\n" +
+      "-1.00000e-05 0.123457 0.333333 1.00000e+21 0\n" +
+      "\n" +
+      "0.123457 1.23457e+06 -3.00000 0.333333 -2.5e-07\n" +
+      "0.123457 1.23457e+06 0.333333 1.00000e+21 1.23457e+08\n" +
+      "\n"},
+  }
+  for _, e := range expected {
+    var buf bytes.Buffer
+    if err := hist.Fprintf(&buf, e.rangeFormat, e.binFormat); err != nil {
+      t.Fatal(err)
+    }
+    if buf.String() != e.text {
+      t.Errorf("Formats %q and %q: expected %q, got %q", e.rangeFormat,
+        e.binFormat, e.text, buf.String())
+    }
+  }
+}
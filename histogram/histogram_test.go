@@ -0,0 +1,291 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// histogram wraps the gsl one and two dimensional histograms
+package histogram
+
+import (
+  "bytes"
+  "errors"
+  "math"
+  "strings"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_histogram_1(t *testing.T) {
+
+  hist, err := NewUniform(4, 0.0, 4.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist.Free()
+
+  // test 1
+  for _, x := range []float64{0.5, 1.5, 1.5, 2.5, 2.5, 2.5} {
+    if err := hist.Increment(x); err != nil {
+      t.Error("Test 1: Failed to increment histogram.", err)
+    }
+  }
+  for _, x := range []float64{4.0, -1.0} {
+    if err := hist.Increment(x); !errors.Is(err, gsl.EDOM) {
+      t.Error("Test 1: Incremented histogram with value out of range", x)
+    }
+  }
+  expected := []float64{1.0, 2.0, 3.0, 0.0}
+  if hist.Bins() != len(expected) {
+    t.Fatal("Test 1: Wrong number of bins", hist.Bins())
+  }
+  for i := range expected {
+    if hist.Get(i) != expected[i] {
+      t.Error("Test 1: Wrong content of bin", i, hist.Get(i), expected[i])
+    }
+  }
+
+  // test 2
+  if hist.Min() != 0.0 || hist.Max() != 4.0 {
+    t.Error("Test 2: Wrong histogram range", hist.Min(), hist.Max())
+  }
+  if lower, upper := hist.Range(2); lower != 2.0 || upper != 3.0 {
+    t.Error("Test 2: Wrong range of bin 2", lower, upper)
+  }
+  if hist.MaxBin() != 2 || hist.MaxVal() != 3.0 || hist.MinBin() != 3 ||
+    hist.MinVal() != 0.0 {
+    t.Error("Test 2: Wrong extreme bins.")
+  }
+
+  // test 3
+  mean := (0.5*1.0 + 1.5*2.0 + 2.5*3.0) / 6.0
+  sigma := math.Sqrt((1.0*(0.5-mean)*(0.5-mean) + 2.0*(1.5-mean)*(1.5-mean) +
+    3.0*(2.5-mean)*(2.5-mean)) / 6.0)
+  if hist.Sum() != 6.0 || !util.FloatEqual(hist.Mean(), mean) ||
+    !util.FloatEqual(hist.Sigma(), sigma) {
+    t.Error("Test 3: Wrong histogram statistics", hist.Sum(), hist.Mean(),
+      hist.Sigma())
+  }
+
+  // test 4
+  if i, err := hist.Find(1.2); err != nil || i != 1 {
+    t.Error("Test 4: Failed to find bin.", i, err)
+  }
+  if _, err := hist.Find(5.0); !errors.Is(err, gsl.EDOM) {
+    t.Error("Test 4: Found bin for value out of range.")
+  }
+
+  // test 5
+  if err := hist.Accumulate(0.1, 2.5); err != nil || hist.Get(0) != 3.5 {
+    t.Error("Test 5: Failed to accumulate weight.", hist.Get(0), err)
+  }
+  hist.Reset()
+  if hist.Sum() != 0.0 {
+    t.Error("Test 5: Failed to reset histogram.")
+  }
+}
+
+// test set 2
+func Test_histogram_2(t *testing.T) {
+
+  // test 1
+  ranges := []float64{0.0, 1.0, 10.0, 100.0}
+  hist, err := NewRanges(ranges)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist.Free()
+  if lower, upper := hist.Range(1); lower != 1.0 || upper != 10.0 {
+    t.Error("Test 1: Wrong range of bin 1", lower, upper)
+  }
+
+  outside := hist.IncrementSlice([]float64{0.5, 5.0, 50.0, 55.0, 500.0,
+    -1.0})
+  if outside != 2 || hist.Get(0) != 1.0 || hist.Get(1) != 1.0 ||
+    hist.Get(2) != 2.0 {
+    t.Error("Test 1: Failed to increment histogram from slice.", outside)
+  }
+
+  // test 2
+  clone := hist.Clone()
+  defer clone.Free()
+  if !clone.EqualBins(hist) || clone.Get(2) != 2.0 {
+    t.Error("Test 2: Failed to clone histogram.")
+  }
+  clone.Increment(0.5)
+  if hist.Get(0) != 1.0 {
+    t.Error("Test 2: Clone is not independent.")
+  }
+
+  // test 3
+  if err := hist.Add(clone); err != nil || hist.Get(0) != 3.0 ||
+    hist.Get(2) != 4.0 {
+    t.Error("Test 3: Failed to add histograms.", err)
+  }
+  if err := hist.Sub(clone); err != nil || hist.Get(0) != 1.0 ||
+    hist.Get(2) != 2.0 {
+    t.Error("Test 3: Failed to subtract histograms.", err)
+  }
+  if err := hist.Mul(clone); err != nil || hist.Get(0) != 2.0 ||
+    hist.Get(2) != 4.0 {
+    t.Error("Test 3: Failed to multiply histograms.", err)
+  }
+  if err := hist.Div(clone); err != nil || hist.Get(0) != 1.0 ||
+    hist.Get(2) != 2.0 {
+    t.Error("Test 3: Failed to divide histograms.", err)
+  }
+  hist.Scale(3.0)
+  hist.Shift(-1.0)
+  if hist.Get(0) != 2.0 || hist.Get(1) != 2.0 || hist.Get(2) != 5.0 {
+    t.Error("Test 3: Failed to scale and shift histogram.")
+  }
+
+  // test 4
+  uniform, err := NewUniform(3, 0.0, 100.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer uniform.Free()
+  if err := hist.Add(uniform); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 4: Added histograms with different binning.")
+  }
+  if _, err := NewRanges([]float64{0.0, 2.0, 1.0}); !errors.Is(err,
+    gsl.EINVAL) {
+    t.Error("Test 4: Accepted ranges which are not increasing.")
+  }
+  if _, err := NewUniform(0, 0.0, 1.0); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 4: Accepted histogram without bins.")
+  }
+
+  // test 5
+  uniform.Free()
+  if err := hist.Add(uniform); err != ErrFreed {
+    t.Error("Test 5: Added freed histogram.")
+  }
+}
+
+// test set 3
+func Test_histogram_3(t *testing.T) {
+
+  hist, err := NewRanges([]float64{0.0, 0.5, 2.0})
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist.Free()
+  hist.Accumulate(0.25, 1.5)
+  hist.Accumulate(1.0, 3.0)
+
+  // test 1
+  var buf bytes.Buffer
+  if err := hist.Fprintf(&buf, "%g", "%g"); err != nil {
+    t.Fatal(err)
+  }
+  if buf.String() != "0 0.5 1.5\n0.5 2 3\n" {
+    t.Errorf("Test 1: Unexpected text format %q", buf.String())
+  }
+
+  // test 2
+  other, err := NewUniform(2, 10.0, 20.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer other.Free()
+  if err := other.Fscanf(&buf); err != nil {
+    t.Fatal(err)
+  }
+  if !other.EqualBins(hist) || other.Get(0) != 1.5 || other.Get(1) != 3.0 {
+    t.Error("Test 2: Failed to read histogram.")
+  }
+
+  // test 3: output of gsl_histogram_fprintf with "%e" formats
+  text := "0.000000e+00 5.000000e-01 2.500000e+00\n" +
+    "5.000000e-01 2.000000e+00 -1.000000e+00\n"
+  if err := other.Fscanf(strings.NewReader(text)); err != nil {
+    t.Fatal(err)
+  }
+  if other.Get(0) != 2.5 || other.Get(1) != -1.0 || other.Max() != 2.0 {
+    t.Error("Test 3: Failed to read gsl histogram text format.")
+  }
+
+  // test 4
+  if err := other.Fscanf(strings.NewReader("0 1 2\n")); err == nil {
+    t.Error("Test 4: Read histogram from incomplete input.")
+  }
+}
+
+// test set 4
+func Test_histogram_4(t *testing.T) {
+
+  // test 1: Fprintf uses the C printf formats
+  hist, err := NewRanges([]float64{-1e-5, 0.1234567, 3.0, 1234567.0, 1e21})
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist.Free()
+  hist.Accumulate(0.0, 1.0/3.0)
+  hist.Accumulate(1.0, -2.5e-7)
+  hist.Accumulate(100.0, 123456789.0)
+  hist.Accumulate(1e20, 100000.0)
+
+  // output of gsl_histogram_fprintf
+  expected := []struct {
+    rangeFormat, binFormat, text string
+  }{
+    {"%12.6e", "%.3f",
+      "-1.000000e-05 1.234567e-01 0.333\n" +
+      "1.234567e-01 3.000000e+00 -0.000\n" +
+      "3.000000e+00 1.234567e+06 123456789.000\n" +
+      "1.234567e+06 1.000000e+21 100000.000\n"},
+    {"%#g", "%+g",
+      "-1.00000e-05 0.123457 +0.333333\n" +
+      "0.123457 3.00000 -2.5e-07\n" +
+      "3.00000 1.23457e+06 +1.23457e+08\n" +
+      "1.23457e+06 1.00000e+21 +100000\n"},
+    {"%lg", "%-9.2e",
+      "-1e-05 0.123457 3.33e-01 \n" +
+      "0.123457 3 -2.50e-07\n" +
+      "3 1.23457e+06 1.23e+08 \n" +
+      "1.23457e+06 1e+21 1.00e+05 \n"},
+    {"% g", "%10.4g",
+      "-1e-05  0.123457     0.3333\n" +
+      " 0.123457  3   -2.5e-07\n" +
+      " 3  1.23457e+06  1.235e+08\n" +
+      " 1.23457e+06  1e+21      1e+05\n"},
+    {"%G", "%e",
+      "-1E-05 0.123457 3.333333e-01\n" +
+      "0.123457 3 -2.500000e-07\n" +
+      "3 1.23457E+06 1.234568e+08\n" +
+      "1.23457E+06 1E+21 1.000000e+05\n"},
+  }
+  for _, e := range expected {
+    var buf bytes.Buffer
+    if err := hist.Fprintf(&buf, e.rangeFormat, e.binFormat); err != nil {
+      t.Fatal(err)
+    }
+    if buf.String() != e.text {
+      t.Errorf("Formats %q and %q: expected %q, got %q", e.rangeFormat,
+        e.binFormat, e.text, buf.String())
+    }
+  }
+
+  // test 2: formats gsl can't pass a double to are rejected
+  for _, format := range []string{"", "%d", "%s", "%g %g", "%Lg", "%", "x"} {
+    var buf bytes.Buffer
+    err := hist.Fprintf(&buf, format, "%g")
+    if !errors.Is(err, gsl.EINVAL) {
+      t.Errorf("Test 2: Expected EINVAL for format %q, got %v", format, err)
+    }
+    err = hist.Fprintf(&buf, "%g", format)
+    if !errors.Is(err, gsl.EINVAL) {
+      t.Errorf("Test 2: Expected EINVAL for format %q, got %v", format, err)
+    }
+    if buf.Len() != 0 {
+      t.Errorf("Test 2: Unexpected output for format %q", format)
+    }
+  }
+  var buf bytes.Buffer
+  if err := hist.Fprintf(&buf, "%%%g", "%g%%"); err != nil {
+    t.Error("Test 2: Failed to accept literal %.", err)
+  }
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * histogram_wrap provides additional gsl histogram wrappers for go-gsl
+ */
+
+#include <stdio.h>
+#include <stdlib.h>
+
+#include <gsl/gsl_errno.h>
+#include <gsl/gsl_histogram.h>
+#include <gsl/gsl_histogram2d.h>
+
+#include "histogram_wrap.h"
+
+
+/* histogram_increment_n increments the bins containing each of the n
+ * values x and returns the number of values outside the histogram range */
+size_t histogram_increment_n(gsl_histogram *h, const double *x, size_t n) {
+
+  size_t outside = 0;
+  for (size_t i = 0; i < n; i++) {
+    if (gsl_histogram_increment(h, x[i]) != 0) {
+      outside++;
+    }
+  }
+  return outside;
+}
+
+
+/* histogram2d_increment_n increments the bins containing each of the n
+ * points (x[i], y[i]) and returns the number of points outside the
+ * histogram range */
+size_t histogram2d_increment_n(gsl_histogram2d *h, const double *x,
+  const double *y, size_t n) {
+
+  size_t outside = 0;
+  for (size_t i = 0; i < n; i++) {
+    if (gsl_histogram2d_increment(h, x[i], y[i]) != 0) {
+      outside++;
+    }
+  }
+  return outside;
+}
+
+
+/* read_stream returns everything written to the file f so far in a newly
+ * allocated, null terminated buffer or NULL on failure */
+static char *read_stream(FILE *f) {
+
+  long size = ftell(f);
+  if (size < 0 || fseek(f, 0, SEEK_SET) != 0) {
+    return NULL;
+  }
+  char *buf = malloc(size + 1);
+  if (buf == NULL) {
+    return NULL;
+  }
+  if (fread(buf, 1, size, f) != (size_t)size) {
+    free(buf);
+    return NULL;
+  }
+  buf[size] = '\0';
+  return buf;
+}
+
+
+/* histogram_sprintf returns the output of gsl_histogram_fprintf for h in a
+ * newly allocated string, which has to be released with free, or NULL on
+ * failure */
+char *histogram_sprintf(const gsl_histogram *h, const char *range_format,
+  const char *bin_format) {
+
+  FILE *f = tmpfile();
+  if (f == NULL) {
+    return NULL;
+  }
+  char *buf = NULL;
+  if (gsl_histogram_fprintf(f, h, range_format, bin_format) == GSL_SUCCESS) {
+    buf = read_stream(f);
+  }
+  fclose(f);
+  return buf;
+}
+
+
+/* histogram2d_sprintf returns the output of gsl_histogram2d_fprintf for h
+ * in a newly allocated string, which has to be released with free, or
+ * NULL on failure */
+char *histogram2d_sprintf(const gsl_histogram2d *h, const char *range_format,
+  const char *bin_format) {
+
+  FILE *f = tmpfile();
+  if (f == NULL) {
+    return NULL;
+  }
+  char *buf = NULL;
+  if (gsl_histogram2d_fprintf(f, h, range_format,
+      bin_format) == GSL_SUCCESS) {
+    buf = read_stream(f);
+  }
+  fclose(f);
+  return buf;
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * histogram_wrap provides additional gsl histogram wrappers for go-gsl
+ */
+
+
+#ifndef HISTOGRAM_WRAP_H
+#define HISTOGRAM_WRAP_H
+
+#include <stddef.h>
+
+#include <gsl/gsl_histogram.h>
+#include <gsl/gsl_histogram2d.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+size_t histogram_increment_n(gsl_histogram *h, const double *x, size_t n);
+
+size_t histogram2d_increment_n(gsl_histogram2d *h, const double *x,
+  const double *y, size_t n);
+
+char *histogram_sprintf(const gsl_histogram *h, const char *range_format,
+  const char *bin_format);
+
+char *histogram2d_sprintf(const gsl_histogram2d *h, const char *range_format,
+  const char *bin_format);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,215 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// pdf wraps the gsl histogram probability distributions which allow
+// sampling from binned data
+package histogram
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_histogram.h>
+// #include <gsl/gsl_histogram2d.h>
+import "C"
+
+import (
+  "math"
+  "runtime"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/random"
+)
+
+// HistogramPdf is the probability distribution described by a one
+// dimensional histogram. The probability of each bin is proportional to
+// its content and samples are distributed uniformly within each bin.
+type HistogramPdf struct {
+  p *C.gsl_histogram_pdf
+}
+
+// NewHistogramPdf returns the probability distribution of h. The pdf does
+// not refer to h afterwards, i.e., h may be modified or freed. A
+// *gsl.Error with code gsl.EDOM is returned if h contains negative bins or
+// is empty.
+func NewHistogramPdf(h *Histogram) (*HistogramPdf, error) {
+  defer runtime.KeepAlive(h)
+  hp := h.ptr()
+  if h.MinVal() < 0 || h.Sum() == 0 {
+    return nil, gsl.NewError(gsl.EDOM, "Histogram bins must be "+
+      "non-negative and not all zero.")
+  }
+
+  p := C.gsl_histogram_pdf_alloc(C.gsl_histogram_bins(hp))
+  if p == nil {
+    return nil, gsl.NewError(gsl.ENOMEM, "Failed to allocate pdf.")
+  }
+  pdf := &HistogramPdf{p}
+  runtime.SetFinalizer(pdf, (*HistogramPdf).Free)
+  if err := gsl.Protect(func() {
+    C.gsl_histogram_pdf_init(p, hp)
+  }); err != nil {
+    pdf.Free()
+    return nil, err
+  }
+  return pdf, nil
+}
+
+// ptr returns the gsl pdf and panics with ErrFreed if it has been freed.
+// Callers need to keep p alive until the C call using the returned pointer
+// has finished.
+func (p *HistogramPdf) ptr() *C.gsl_histogram_pdf {
+  if p.p == nil {
+    panic(ErrFreed)
+  }
+  return p.p
+}
+
+// Free releases the gsl pdf. It is safe to call Free more than once.
+func (p *HistogramPdf) Free() {
+  if p.p != nil {
+    C.gsl_histogram_pdf_free(p.p)
+    p.p = nil
+  }
+  runtime.SetFinalizer(p, nil)
+}
+
+// Sample returns the sample of the distribution corresponding to the
+// uniform random number r in [0, 1), i.e., the inverse of the cumulative
+// distribution function at r. NaN is returned if r is outside of [0, 1)
+// or NaN.
+func (p *HistogramPdf) Sample(r float64) float64 {
+  defer runtime.KeepAlive(p)
+  pdf := p.ptr()
+  if !isUniform(r) {
+    return math.NaN()
+  }
+  return float64(C.gsl_histogram_pdf_sample(pdf, C.double(r)))
+}
+
+// SampleE is like Sample but returns a *gsl.Error with code gsl.EDOM if r
+// is outside of [0, 1) or NaN and ErrFreed instead of panicking if p has
+// been freed.
+func (p *HistogramPdf) SampleE(r float64) (float64, error) {
+  defer runtime.KeepAlive(p)
+  if p.p == nil {
+    return 0, ErrFreed
+  }
+  if !isUniform(r) {
+    return math.NaN(), gsl.NewError(gsl.EDOM,
+      "Random number %g outside of [0, 1).", r)
+  }
+  var x C.double
+  err := gsl.Protect(func() {
+    x = C.gsl_histogram_pdf_sample(p.p, C.double(r))
+  })
+  return float64(x), err
+}
+
+// isUniform checks that r is a valid uniform random number in [0, 1).
+// The comparisons fail for NaN.
+func isUniform(r float64) bool {
+  return r >= 0 && r < 1
+}
+
+// SampleWith returns a random sample of the distribution using the
+// generator rng
+func (p *HistogramPdf) SampleWith(rng random.RngState) float64 {
+  return p.Sample(rng.Uniform())
+}
+
+// Histogram2DPdf is the probability distribution described by a two
+// dimensional histogram. The probability of each bin is proportional to
+// its content and samples are distributed uniformly within each bin.
+type Histogram2DPdf struct {
+  p *C.gsl_histogram2d_pdf
+}
+
+// NewHistogram2DPdf returns the probability distribution of h. The pdf
+// does not refer to h afterwards, i.e., h may be modified or freed. A
+// *gsl.Error with code gsl.EDOM is returned if h contains negative bins or
+// is empty.
+func NewHistogram2DPdf(h *Histogram2D) (*Histogram2DPdf, error) {
+  defer runtime.KeepAlive(h)
+  hp := h.ptr()
+  if h.MinVal() < 0 || h.Sum() == 0 {
+    return nil, gsl.NewError(gsl.EDOM, "Histogram bins must be "+
+      "non-negative and not all zero.")
+  }
+
+  p := C.gsl_histogram2d_pdf_alloc(C.gsl_histogram2d_nx(hp),
+    C.gsl_histogram2d_ny(hp))
+  if p == nil {
+    return nil, gsl.NewError(gsl.ENOMEM, "Failed to allocate pdf.")
+  }
+  pdf := &Histogram2DPdf{p}
+  runtime.SetFinalizer(pdf, (*Histogram2DPdf).Free)
+  if err := gsl.Protect(func() {
+    C.gsl_histogram2d_pdf_init(p, hp)
+  }); err != nil {
+    pdf.Free()
+    return nil, err
+  }
+  return pdf, nil
+}
+
+// ptr returns the gsl pdf and panics with ErrFreed if it has been freed.
+// Callers need to keep p alive until the C call using the returned pointer
+// has finished.
+func (p *Histogram2DPdf) ptr() *C.gsl_histogram2d_pdf {
+  if p.p == nil {
+    panic(ErrFreed)
+  }
+  return p.p
+}
+
+// Free releases the gsl pdf. It is safe to call Free more than once.
+func (p *Histogram2DPdf) Free() {
+  if p.p != nil {
+    C.gsl_histogram2d_pdf_free(p.p)
+    p.p = nil
+  }
+  runtime.SetFinalizer(p, nil)
+}
+
+// Sample returns the sample (x, y) of the distribution corresponding to
+// the two uniform random numbers r1 and r2 in [0, 1). r1 selects the bin
+// and the position within it in x direction, r2 the position within the
+// bin in y direction. NaN is returned for x and y if r1 or r2 is outside
+// of [0, 1) or NaN.
+func (p *Histogram2DPdf) Sample(r1, r2 float64) (float64, float64) {
+  defer runtime.KeepAlive(p)
+  pdf := p.ptr()
+  if !isUniform(r1) || !isUniform(r2) {
+    return math.NaN(), math.NaN()
+  }
+  var x, y C.double
+  C.gsl_histogram2d_pdf_sample(pdf, C.double(r1), C.double(r2), &x, &y)
+  return float64(x), float64(y)
+}
+
+// SampleE is like Sample but returns a *gsl.Error with code gsl.EDOM if r1
+// or r2 is outside of [0, 1) or NaN and ErrFreed instead of panicking if p
+// has been freed.
+func (p *Histogram2DPdf) SampleE(r1, r2 float64) (float64, float64,
+  error) {
+  defer runtime.KeepAlive(p)
+  if p.p == nil {
+    return 0, 0, ErrFreed
+  }
+  if !isUniform(r1) || !isUniform(r2) {
+    return math.NaN(), math.NaN(), gsl.NewError(gsl.EDOM,
+      "Random numbers (%g, %g) outside of [0, 1).", r1, r2)
+  }
+  var x, y C.double
+  err := gsl.Protect(func() {
+    C.gsl_histogram2d_pdf_sample(p.p, C.double(r1), C.double(r2), &x, &y)
+  })
+  return float64(x), float64(y), err
+}
+
+// SampleWith returns a random sample (x, y) of the distribution using the
+// generator rng
+func (p *Histogram2DPdf) SampleWith(rng random.RngState) (float64, float64) {
+  r1 := rng.Uniform()
+  r2 := rng.Uniform()
+  return p.Sample(r1, r2)
+}
@@ -0,0 +1,210 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// pdf wraps the gsl histogram probability distributions which allow
+// sampling from binned data
+package histogram
+
+import (
+  "errors"
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/random"
+)
+
+// test set 1
+func Test_pdf_1(t *testing.T) {
+
+  hist, err := NewRanges([]float64{0.0, 1.0, 2.0, 4.0})
+  if err != nil {
+    t.Fatal(err)
+  }
+  hist.Accumulate(0.5, 1.0)
+  hist.Accumulate(3.0, 3.0)
+  pdf, err := NewHistogramPdf(hist)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer pdf.Free()
+  hist.Free()
+
+  // test 1
+  for _, c := range []struct{ r, x float64 }{
+    {0.0, 0.0}, {0.125, 0.5}, {0.25, 2.0}, {0.625, 3.0},
+  } {
+    if x := pdf.Sample(c.r); math.Abs(x-c.x) > 1e-12 {
+      t.Error("Test 1: Wrong sample for", c.r, x, c.x)
+    }
+  }
+
+  // test 2
+  numSamples := 10000
+  rng1 := random.Rng_alloc(random.Mt19937)
+  rng2 := random.Rng_alloc(random.Mt19937)
+  defer rng1.Free()
+  defer rng2.Free()
+  sum := 0.0
+  for i := 0; i < numSamples; i++ {
+    x := pdf.SampleWith(rng1)
+    if x != pdf.Sample(rng2.Uniform()) {
+      t.Fatal("Test 2: SampleWith differs from Sample at", i)
+    }
+    if x < 0.0 || x >= 4.0 || (x >= 1.0 && x < 2.0) {
+      t.Fatal("Test 2: Sample outside of non-empty bins", x)
+    }
+    sum += x
+  }
+
+  // the exact mean is 0.25*0.5 + 0.75*3.0
+  if mean := sum / float64(numSamples); math.Abs(mean-2.375) > 0.05 {
+    t.Error("Test 2: Wrong sample mean", mean)
+  }
+}
+
+// test set 2
+func Test_pdf_2(t *testing.T) {
+
+  hist, err := NewUniform2D(2, 2, 0.0, 2.0, 0.0, 2.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist.Free()
+  hist.Accumulate(0.5, 1.5, 1.0)
+  hist.Accumulate(1.5, 1.5, 3.0)
+  pdf, err := NewHistogram2DPdf(hist)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer pdf.Free()
+
+  // test 1
+  if x, y := pdf.Sample(0.125, 0.5); math.Abs(x-0.5) > 1e-12 ||
+    math.Abs(y-1.5) > 1e-12 {
+    t.Error("Test 1: Wrong sample", x, y)
+  }
+
+  // test 2
+  rng := random.Rng_alloc(random.Mt19937)
+  defer rng.Free()
+  numSamples := 10000
+  left := 0
+  for i := 0; i < numSamples; i++ {
+    x, y := pdf.SampleWith(rng)
+    if x < 0.0 || x >= 2.0 || y < 1.0 || y >= 2.0 {
+      t.Fatal("Test 2: Sample outside of non-empty bins", x, y)
+    }
+    if x < 1.0 {
+      left++
+    }
+  }
+  if frac := float64(left) / float64(numSamples); math.Abs(frac-0.25) >
+    0.02 {
+    t.Error("Test 2: Wrong fraction of samples in bin (0, 1)", frac)
+  }
+}
+
+// test set 3
+func Test_pdf_3(t *testing.T) {
+
+  hist, err := NewUniform(2, 0.0, 1.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist.Free()
+
+  // test 1
+  if _, err := NewHistogramPdf(hist); !errors.Is(err, gsl.EDOM) {
+    t.Error("Test 1: Created pdf from empty histogram.")
+  }
+
+  // test 2
+  hist.Accumulate(0.2, 1.0)
+  hist.Accumulate(0.7, -0.5)
+  if _, err := NewHistogramPdf(hist); !errors.Is(err, gsl.EDOM) {
+    t.Error("Test 2: Created pdf from histogram with negative bin.")
+  }
+
+  hist2d, err := NewUniform2D(1, 2, 0.0, 1.0, 0.0, 1.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist2d.Free()
+  hist2d.Accumulate(0.5, 0.2, -1.0)
+  if _, err := NewHistogram2DPdf(hist2d); !errors.Is(err, gsl.EDOM) {
+    t.Error("Test 2: Created 2D pdf from histogram with negative bin.")
+  }
+}
+
+// test set 4
+func Test_pdf_4(t *testing.T) {
+
+  hist, err := NewUniform2D(2, 2, 0.0, 2.0, 0.0, 2.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist.Free()
+  hist.Accumulate(0.5, 1.5, 1.0)
+  hist.Accumulate(1.5, 1.5, 3.0)
+  pdf2d, err := NewHistogram2DPdf(hist)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  hist1d, err := NewUniform(2, 0.0, 2.0)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer hist1d.Free()
+  hist1d.Accumulate(0.5, 1.0)
+  hist1d.Accumulate(1.5, 3.0)
+  pdf, err := NewHistogramPdf(hist1d)
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  // test 1: invalid uniform numbers are rejected
+  for _, r := range []float64{-0.1, 1.0, 1.5, math.NaN(), math.Inf(1)} {
+    if x := pdf.Sample(r); !math.IsNaN(x) {
+      t.Error("Test 1: Expected NaN for r =", r, "got", x)
+    }
+    if _, err := pdf.SampleE(r); !errors.Is(err, gsl.EDOM) {
+      t.Error("Test 1: Expected EDOM for r =", r, "got", err)
+    }
+    if x, y := pdf2d.Sample(r, 0.5); !math.IsNaN(x) || !math.IsNaN(y) {
+      t.Error("Test 1: Expected NaN for r1 =", r, "got", x, y)
+    }
+    if x, y := pdf2d.Sample(0.5, r); !math.IsNaN(x) || !math.IsNaN(y) {
+      t.Error("Test 1: Expected NaN for r2 =", r, "got", x, y)
+    }
+    if _, _, err := pdf2d.SampleE(r, 0.5); !errors.Is(err, gsl.EDOM) {
+      t.Error("Test 1: Expected EDOM for r1 =", r, "got", err)
+    }
+    if _, _, err := pdf2d.SampleE(0.5, r); !errors.Is(err, gsl.EDOM) {
+      t.Error("Test 1: Expected EDOM for r2 =", r, "got", err)
+    }
+  }
+
+  // test 2: E variants agree with Sample for valid numbers
+  for _, r := range []float64{0.0, 0.125, 0.5, 0.999} {
+    if x, err := pdf.SampleE(r); err != nil || x != pdf.Sample(r) {
+      t.Error("Test 2: SampleE differs from Sample for r =", r, err)
+    }
+    x, y, err := pdf2d.SampleE(r, 0.5)
+    if ex, ey := pdf2d.Sample(r, 0.5); err != nil || x != ex || y != ey {
+      t.Error("Test 2: 2D SampleE differs from Sample for r1 =", r, err)
+    }
+  }
+
+  // test 3: freed pdfs report ErrFreed
+  pdf.Free()
+  pdf2d.Free()
+  if _, err := pdf.SampleE(0.5); err != ErrFreed {
+    t.Error("Test 3: Expected ErrFreed, got", err)
+  }
+  if _, _, err := pdf2d.SampleE(0.5, 0.5); err != ErrFreed {
+    t.Error("Test 3: Expected ErrFreed, got", err)
+  }
+}
@@ -0,0 +1,343 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// matrix provides vectors and matrices over Go allocated memory backed by
+// gsl_vector and gsl_matrix
+//
+// Vector and Matrix wrap Go slices without copying; the gsl views needed
+// for each operation are created on the C side, so no Go pointers are
+// stored in C memory and the cgo pointer rules hold without pinning.
+// Subvectors, rows, columns, diagonals and submatrices are views sharing
+// memory with the original object.
+//
+// Invalid indices are programming errors and cause a panic just like
+// indexing a Go slice out of range does. Operations on objects of
+// incompatible dimensions return a *gsl.Error with code gsl.EBADLEN.
+package matrix
+
+// #cgo pkg-config: gsl
+// #include "matrix_wrap.h"
+import "C"
+
+import (
+  "fmt"
+  "unsafe"
+
+  "github.com/haskelladdict/gsl"
+)
+
+// Matrix is a rows x cols matrix stored in row major order in a Go slice.
+// Consecutive rows start tda (the trailing dimension) elements apart.
+type Matrix struct {
+  data []float64
+  rows int
+  cols int
+  tda  int
+}
+
+// NewMatrix returns a rows x cols matrix of zero elements
+func NewMatrix(rows, cols int) (*Matrix, error) {
+  if rows < 1 || cols < 1 {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid matrix dimensions %d x "+
+      "%d.", rows, cols)
+  }
+  return &Matrix{make([]float64, rows*cols), rows, cols, cols}, nil
+}
+
+// NewMatrixFrom returns a rows x cols matrix wrapping data, which has to
+// hold the elements in row major order, without copying. A *gsl.Error
+// with code gsl.EBADLEN is returned unless data has rows*cols elements.
+func NewMatrixFrom(data []float64, rows, cols int) (*Matrix, error) {
+  if rows < 1 || cols < 1 {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid matrix dimensions %d x "+
+      "%d.", rows, cols)
+  }
+  if len(data) != rows*cols {
+    return nil, gsl.NewError(gsl.EBADLEN, "Data of length %d does not "+
+      "match matrix dimensions %d x %d.", len(data), rows, cols)
+  }
+  return newMatrix(data, rows, cols, cols), nil
+}
+
+// NewMatrixWithTda is like NewMatrixFrom but row i starts at element
+// i*tda of data, which allows wrapping a part of a larger matrix. A
+// *gsl.Error with code gsl.EINVAL is returned if tda < cols and with code
+// gsl.EBADLEN if data is too short.
+func NewMatrixWithTda(data []float64, rows, cols, tda int) (*Matrix,
+  error) {
+  if rows < 1 || cols < 1 || tda < cols {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid matrix dimensions %d x "+
+      "%d with tda %d.", rows, cols, tda)
+  }
+  if len(data) < (rows-1)*tda+cols {
+    return nil, gsl.NewError(gsl.EBADLEN, "Data of length %d too short for "+
+      "matrix dimensions %d x %d with tda %d.", len(data), rows, cols, tda)
+  }
+  return newMatrix(data, rows, cols, tda), nil
+}
+
+// newMatrix returns a matrix starting at data[0]. data is truncated to
+// the last element of the matrix.
+func newMatrix(data []float64, rows, cols, tda int) *Matrix {
+  return &Matrix{data[:(rows-1)*tda+cols], rows, cols, tda}
+}
+
+// ptr returns a C pointer to the first element of m
+func (m *Matrix) ptr() *C.double {
+  return (*C.double)(unsafe.Pointer(&m.data[0]))
+}
+
+// Dims returns the number of rows and columns of m
+func (m *Matrix) Dims() (int, int) {
+  return m.rows, m.cols
+}
+
+// Tda returns the distance between the starts of consecutive rows in the
+// underlying data
+func (m *Matrix) Tda() int {
+  return m.tda
+}
+
+// Data returns the memory underlying m. Element (i, j) of m is stored at
+// index i*m.Tda() + j.
+func (m *Matrix) Data() []float64 {
+  return m.data
+}
+
+// checkIndex panics if (i, j) is not a valid element index
+func (m *Matrix) checkIndex(i, j int) {
+  if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+    panic(fmt.Sprintf("Matrix index (%d, %d) out of range for %d x %d "+
+      "matrix.", i, j, m.rows, m.cols))
+  }
+}
+
+// At returns element (i, j). At panics if (i, j) is out of range.
+func (m *Matrix) At(i, j int) float64 {
+  m.checkIndex(i, j)
+  return m.data[i*m.tda+j]
+}
+
+// Set sets element (i, j) to x. Set panics if (i, j) is out of range.
+func (m *Matrix) Set(i, j int, x float64) {
+  m.checkIndex(i, j)
+  m.data[i*m.tda+j] = x
+}
+
+// SetAll sets all elements to x
+func (m *Matrix) SetAll(x float64) {
+  C.matrix_set_all(m.ptr(), C.size_t(m.rows), C.size_t(m.cols),
+    C.size_t(m.tda), C.double(x))
+}
+
+// SetZero sets all elements to zero
+func (m *Matrix) SetZero() {
+  m.SetAll(0)
+}
+
+// SetIdentity sets the diagonal elements to one and all others to zero
+func (m *Matrix) SetIdentity() {
+  C.matrix_set_identity(m.ptr(), C.size_t(m.rows), C.size_t(m.cols),
+    C.size_t(m.tda))
+}
+
+// Row returns a view of row i. Row panics if i is out of range.
+func (m *Matrix) Row(i int) *Vector {
+  m.checkIndex(i, 0)
+  return newVector(m.data[i*m.tda:], m.cols, 1)
+}
+
+// Col returns a view of column j. Col panics if j is out of range.
+func (m *Matrix) Col(j int) *Vector {
+  m.checkIndex(0, j)
+  return newVector(m.data[j:], m.rows, m.tda)
+}
+
+// Diagonal returns a view of the diagonal of m
+func (m *Matrix) Diagonal() *Vector {
+  n := m.rows
+  if m.cols < n {
+    n = m.cols
+  }
+  return newVector(m.data, n, m.tda+1)
+}
+
+// Submatrix returns a view of the rows x cols submatrix of m whose upper
+// left element is (i, j). Submatrix panics if the view is not contained
+// in m.
+func (m *Matrix) Submatrix(i, j, rows, cols int) *Matrix {
+  if rows < 1 || cols < 1 {
+    panic(fmt.Sprintf("Invalid submatrix dimensions %d x %d.", rows, cols))
+  }
+  m.checkIndex(i, j)
+  m.checkIndex(i+rows-1, j+cols-1)
+  return newMatrix(m.data[i*m.tda+j:], rows, cols, m.tda)
+}
+
+// checkDims returns a *gsl.Error with code gsl.EBADLEN unless m and b
+// have the same dimensions
+func (m *Matrix) checkDims(b *Matrix) error {
+  if m.rows != b.rows || m.cols != b.cols {
+    return gsl.NewError(gsl.EBADLEN, "Matrix dimensions %d x %d and %d x "+
+      "%d differ.", m.rows, m.cols, b.rows, b.cols)
+  }
+  return nil
+}
+
+// Copy copies the elements of src into m. A *gsl.Error with code
+// gsl.EBADLEN is returned if the dimensions differ.
+func (m *Matrix) Copy(src *Matrix) error {
+  if err := m.checkDims(src); err != nil {
+    return err
+  }
+  return gsl.Protect(func() {
+    C.matrix_memcpy(m.ptr(), C.size_t(m.tda), src.ptr(), C.size_t(src.tda),
+      C.size_t(m.rows), C.size_t(m.cols))
+  })
+}
+
+// Swap exchanges the elements of m and b. A *gsl.Error with code
+// gsl.EBADLEN is returned if the dimensions differ.
+func (m *Matrix) Swap(b *Matrix) error {
+  if err := m.checkDims(b); err != nil {
+    return err
+  }
+  return gsl.Protect(func() {
+    C.matrix_swap(m.ptr(), C.size_t(m.tda), b.ptr(), C.size_t(b.tda),
+      C.size_t(m.rows), C.size_t(m.cols))
+  })
+}
+
+// SwapRows exchanges rows i and j. SwapRows panics if i or j is out of
+// range.
+func (m *Matrix) SwapRows(i, j int) {
+  m.checkIndex(i, 0)
+  m.checkIndex(j, 0)
+  C.matrix_swap_rows(m.ptr(), C.size_t(m.rows), C.size_t(m.cols),
+    C.size_t(m.tda), C.size_t(i), C.size_t(j))
+}
+
+// SwapColumns exchanges columns i and j. SwapColumns panics if i or j is
+// out of range.
+func (m *Matrix) SwapColumns(i, j int) {
+  m.checkIndex(0, i)
+  m.checkIndex(0, j)
+  C.matrix_swap_columns(m.ptr(), C.size_t(m.rows), C.size_t(m.cols),
+    C.size_t(m.tda), C.size_t(i), C.size_t(j))
+}
+
+// Transpose transposes m in place. A *gsl.Error with code gsl.ENOTSQR is
+// returned if m is not square.
+func (m *Matrix) Transpose() error {
+  if m.rows != m.cols {
+    return gsl.NewError(gsl.ENOTSQR, "Matrix of dimensions %d x %d is not "+
+      "square.", m.rows, m.cols)
+  }
+  return gsl.Protect(func() {
+    C.matrix_transpose(m.ptr(), C.size_t(m.rows), C.size_t(m.tda))
+  })
+}
+
+// TransposeCopy stores the transpose of src in m. A *gsl.Error with code
+// gsl.EBADLEN is returned unless the dimensions of m are those of src
+// swapped.
+func (m *Matrix) TransposeCopy(src *Matrix) error {
+  if m.rows != src.cols || m.cols != src.rows {
+    return gsl.NewError(gsl.EBADLEN, "Matrix dimensions %d x %d do not "+
+      "match transpose of %d x %d.", m.rows, m.cols, src.rows, src.cols)
+  }
+  return gsl.Protect(func() {
+    C.matrix_transpose_memcpy(m.ptr(), C.size_t(m.tda), src.ptr(),
+      C.size_t(src.tda), C.size_t(src.rows), C.size_t(src.cols))
+  })
+}
+
+// elementOp applies the element wise operation op to m and b
+func (m *Matrix) elementOp(op C.int, b *Matrix) error {
+  if err := m.checkDims(b); err != nil {
+    return err
+  }
+  return gsl.Protect(func() {
+    C.matrix_op(op, m.ptr(), C.size_t(m.tda), b.ptr(), C.size_t(b.tda),
+      C.size_t(m.rows), C.size_t(m.cols))
+  })
+}
+
+// Add adds the elements of b to the elements of m. A *gsl.Error with code
+// gsl.EBADLEN is returned if the dimensions differ.
+func (m *Matrix) Add(b *Matrix) error {
+  return m.elementOp(C.ELEMENT_ADD, b)
+}
+
+// Sub subtracts the elements of b from the elements of m. A *gsl.Error
+// with code gsl.EBADLEN is returned if the dimensions differ.
+func (m *Matrix) Sub(b *Matrix) error {
+  return m.elementOp(C.ELEMENT_SUB, b)
+}
+
+// MulElements multiplies the elements of m by the elements of b. A
+// *gsl.Error with code gsl.EBADLEN is returned if the dimensions differ.
+func (m *Matrix) MulElements(b *Matrix) error {
+  return m.elementOp(C.ELEMENT_MUL, b)
+}
+
+// DivElements divides the elements of m by the elements of b. A
+// *gsl.Error with code gsl.EBADLEN is returned if the dimensions differ.
+func (m *Matrix) DivElements(b *Matrix) error {
+  return m.elementOp(C.ELEMENT_DIV, b)
+}
+
+// Scale multiplies all elements by x
+func (m *Matrix) Scale(x float64) {
+  C.matrix_scale(m.ptr(), C.size_t(m.rows), C.size_t(m.cols),
+    C.size_t(m.tda), C.double(x))
+}
+
+// AddConstant adds x to all elements
+func (m *Matrix) AddConstant(x float64) {
+  C.matrix_add_constant(m.ptr(), C.size_t(m.rows), C.size_t(m.cols),
+    C.size_t(m.tda), C.double(x))
+}
+
+// MinMax returns the smallest and largest element
+func (m *Matrix) MinMax() (float64, float64) {
+  var min, max C.double
+  C.matrix_minmax(m.ptr(), C.size_t(m.rows), C.size_t(m.cols),
+    C.size_t(m.tda), &min, &max)
+  return float64(min), float64(max)
+}
+
+// Min returns the smallest element
+func (m *Matrix) Min() float64 {
+  min, _ := m.MinMax()
+  return min
+}
+
+// Max returns the largest element
+func (m *Matrix) Max() float64 {
+  _, max := m.MinMax()
+  return max
+}
+
+// MinMaxIndex returns the row and column indices of the smallest and of
+// the largest element. In case of ties the first element in row major
+// order is returned.
+func (m *Matrix) MinMaxIndex() (int, int, int, int) {
+  var imin, jmin, imax, jmax C.size_t
+  C.matrix_minmax_index(m.ptr(), C.size_t(m.rows), C.size_t(m.cols),
+    C.size_t(m.tda), &imin, &jmin, &imax, &jmax)
+  return int(imin), int(jmin), int(imax), int(jmax)
+}
+
+// MinIndex returns the row and column index of the smallest element
+func (m *Matrix) MinIndex() (int, int) {
+  imin, jmin, _, _ := m.MinMaxIndex()
+  return imin, jmin
+}
+
+// MaxIndex returns the row and column index of the largest element
+func (m *Matrix) MaxIndex() (int, int) {
+  _, _, imax, jmax := m.MinMaxIndex()
+  return imax, jmax
+}
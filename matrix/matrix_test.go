@@ -0,0 +1,182 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// matrix provides vectors and matrices over Go allocated memory backed by
+// gsl_vector and gsl_matrix
+//
+// Vector and Matrix wrap Go slices without copying; the gsl views needed
+// for each operation are created on the C side, so no Go pointers are
+// stored in C memory and the cgo pointer rules hold without pinning.
+// Subvectors, rows, columns, diagonals and submatrices are views sharing
+// memory with the original object.
+//
+// Invalid indices are programming errors and cause a panic just like
+// indexing a Go slice out of range does. Operations on objects of
+// incompatible dimensions return a *gsl.Error with code gsl.EBADLEN.
+package matrix
+
+import (
+  "errors"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_matrix_1(t *testing.T) {
+
+  // test 1
+  data := []float64{
+    1, 2, 3, 4,
+    5, 6, 7, 8,
+    9, 10, 11, 12,
+  }
+  m, err := NewMatrixFrom(data, 3, 4)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if rows, cols := m.Dims(); rows != 3 || cols != 4 || m.Tda() != 4 {
+    t.Error("Test 1: Wrong dimensions", rows, cols, m.Tda())
+  }
+  if m.At(1, 2) != 7 {
+    t.Error("Test 1: Wrong element", m.At(1, 2))
+  }
+  m.Set(2, 3, -12)
+  if data[11] != -12 {
+    t.Error("Test 1: Matrix does not share memory with data.")
+  }
+
+  // test 2
+  if !equalSlices(m.Row(1).Slice(), []float64{5, 6, 7, 8}) {
+    t.Error("Test 2: Wrong row", m.Row(1).Slice())
+  }
+  if !equalSlices(m.Col(2).Slice(), []float64{3, 7, 11}) {
+    t.Error("Test 2: Wrong column", m.Col(2).Slice())
+  }
+  if !equalSlices(m.Diagonal().Slice(), []float64{1, 6, 11}) {
+    t.Error("Test 2: Wrong diagonal", m.Diagonal().Slice())
+  }
+
+  // test 3
+  sub := m.Submatrix(1, 1, 2, 2)
+  if sub.Tda() != 4 || sub.At(1, 1) != 11 {
+    t.Error("Test 3: Wrong submatrix", sub.Data())
+  }
+  sub.SetZero()
+  if data[5] != 0 || data[10] != 0 || data[7] != 8 {
+    t.Error("Test 3: Submatrix does not share memory with matrix", data)
+  }
+  sub.Col(0).SetAll(1)
+  if data[5] != 1 || data[9] != 1 || data[6] != 0 {
+    t.Error("Test 3: Column of submatrix is wrong", data)
+  }
+
+  // test 4
+  d, stride := m.Col(3).FloatSlice()
+  mean, err := d.Mean(stride)
+  if err != nil || !util.FloatEqual(mean, 0.0) {
+    t.Error("Test 4: Wrong mean of column", mean, err)
+  }
+
+  // test 5
+  if _, err := NewMatrixFrom(data, 2, 4); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 5: Expected EBADLEN for wrong data length, got", err)
+  }
+  if _, err := NewMatrixWithTda(data, 2, 4, 3); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 5: Expected EINVAL for tda < cols, got", err)
+  }
+  if _, err := NewMatrix(0, 3); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 5: Expected EINVAL for empty matrix, got", err)
+  }
+  func() {
+    defer func() {
+      if recover() == nil {
+        t.Error("Test 5: Expected panic for submatrix out of range.")
+      }
+    }()
+    m.Submatrix(2, 2, 2, 2)
+  }()
+}
+
+// test set 2
+func Test_matrix_2(t *testing.T) {
+
+  // test 1
+  data := []float64{
+    1, 2, 3, 0,
+    4, 5, 6, 0,
+  }
+  a, err := NewMatrixWithTda(data, 2, 3, 4)
+  if err != nil {
+    t.Fatal(err)
+  }
+  at, _ := NewMatrix(3, 2)
+  if err := at.TransposeCopy(a); err != nil {
+    t.Error("Test 1: Unexpected error", err)
+  }
+  if !equalSlices(at.Data(), []float64{1, 4, 2, 5, 3, 6}) {
+    t.Error("Test 1: Wrong transpose", at.Data())
+  }
+  if err := a.TransposeCopy(a); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 1: Expected EBADLEN for wrong dimensions, got", err)
+  }
+  if err := a.Transpose(); !errors.Is(err, gsl.ENOTSQR) {
+    t.Error("Test 1: Expected ENOTSQR for non square matrix, got", err)
+  }
+
+  // test 2
+  s, _ := NewMatrixFrom([]float64{1, 2, 3, 4}, 2, 2)
+  s.Transpose()
+  if !equalSlices(s.Data(), []float64{1, 3, 2, 4}) {
+    t.Error("Test 2: Wrong in place transpose", s.Data())
+  }
+  s.SwapRows(0, 1)
+  s.SwapColumns(0, 1)
+  if !equalSlices(s.Data(), []float64{4, 2, 3, 1}) {
+    t.Error("Test 2: Wrong row or column swap", s.Data())
+  }
+
+  // test 3
+  b, _ := NewMatrix(2, 3)
+  if err := b.Copy(a); err != nil {
+    t.Error("Test 3: Unexpected error", err)
+  }
+  b.Add(a)
+  b.MulElements(a)
+  b.DivElements(a)
+  b.Sub(a)
+  b.Scale(3)
+  b.AddConstant(-1)
+  if !equalSlices(b.Data(), []float64{2, 5, 8, 11, 14, 17}) {
+    t.Error("Test 3: Wrong element wise operations", b.Data())
+  }
+  if err := b.Swap(a); err != nil || a.At(1, 2) != 17 || b.At(1, 2) != 6 {
+    t.Error("Test 3: Swap failed", err)
+  }
+  if data[3] != 0 {
+    t.Error("Test 3: Padding between rows was modified.")
+  }
+
+  // test 4
+  if min, max := a.MinMax(); min != 2 || max != 17 {
+    t.Error("Test 4: Wrong minimum or maximum", min, max)
+  }
+  imin, jmin, imax, jmax := a.MinMaxIndex()
+  if imin != 0 || jmin != 0 || imax != 1 || jmax != 2 {
+    t.Error("Test 4: Wrong minimum or maximum index", imin, jmin, imax, jmax)
+  }
+
+  // test 5
+  id, _ := NewMatrix(2, 3)
+  id.SetIdentity()
+  if !equalSlices(id.Data(), []float64{1, 0, 0, 0, 1, 0}) {
+    t.Error("Test 5: Wrong identity", id.Data())
+  }
+  for _, err := range []error{id.Add(s), id.Copy(s), id.Swap(s)} {
+    if !errors.Is(err, gsl.EBADLEN) {
+      t.Error("Test 5: Expected EBADLEN for different dimensions, got", err)
+    }
+  }
+}
@@ -0,0 +1,262 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * matrix_wrap provides gsl vector and matrix wrappers for go-gsl operating
+ * on plain arrays described by their dimensions and strides
+ */
+
+#include <gsl/gsl_errno.h>
+#include <gsl/gsl_matrix.h>
+#include <gsl/gsl_vector.h>
+
+#include "matrix_wrap.h"
+
+/* VECTOR and MATRIX declare gsl views named v and m of the plain array x.
+ * Go code can not construct gsl_vector and gsl_matrix structs pointing to
+ * Go memory itself without violating the cgo pointer rules, so the views
+ * are set up on the C side for every call. */
+#define VECTOR(v, x, n, stride) \
+  gsl_vector_view v = gsl_vector_view_array_with_stride(x, stride, n)
+#define CONST_VECTOR(v, x, n, stride) \
+  gsl_vector_const_view v = gsl_vector_const_view_array_with_stride(x, \
+    stride, n)
+#define MATRIX(m, x, n1, n2, tda) \
+  gsl_matrix_view m = gsl_matrix_view_array_with_tda(x, n1, n2, tda)
+#define CONST_MATRIX(m, x, n1, n2, tda) \
+  gsl_matrix_const_view m = gsl_matrix_const_view_array_with_tda(x, n1, \
+    n2, tda)
+
+
+/* vector_set_all sets all elements of x to val */
+void vector_set_all(double *x, size_t n, size_t stride, double val) {
+  VECTOR(v, x, n, stride);
+  gsl_vector_set_all(&v.vector, val);
+}
+
+
+/* vector_set_basis sets element i of x to one and all others to zero */
+int vector_set_basis(double *x, size_t n, size_t stride, size_t i) {
+  VECTOR(v, x, n, stride);
+  return gsl_vector_set_basis(&v.vector, i);
+}
+
+
+/* vector_reverse reverses the order of the elements of x */
+int vector_reverse(double *x, size_t n, size_t stride) {
+  VECTOR(v, x, n, stride);
+  return gsl_vector_reverse(&v.vector);
+}
+
+
+/* vector_memcpy copies the n elements of src into dst */
+int vector_memcpy(double *dst, size_t dst_stride, const double *src,
+  size_t src_stride, size_t n) {
+
+  VECTOR(d, dst, n, dst_stride);
+  CONST_VECTOR(s, src, n, src_stride);
+  return gsl_vector_memcpy(&d.vector, &s.vector);
+}
+
+
+/* vector_swap exchanges the n elements of a and b */
+int vector_swap(double *a, size_t a_stride, double *b, size_t b_stride,
+  size_t n) {
+
+  VECTOR(va, a, n, a_stride);
+  VECTOR(vb, b, n, b_stride);
+  return gsl_vector_swap(&va.vector, &vb.vector);
+}
+
+
+/* vector_swap_elements exchanges elements i and j of x */
+int vector_swap_elements(double *x, size_t n, size_t stride, size_t i,
+  size_t j) {
+
+  VECTOR(v, x, n, stride);
+  return gsl_vector_swap_elements(&v.vector, i, j);
+}
+
+
+/* vector_op applies the element wise operation op to a and b and stores
+ * the result in a */
+int vector_op(int op, double *a, size_t a_stride, const double *b,
+  size_t b_stride, size_t n) {
+
+  VECTOR(va, a, n, a_stride);
+  CONST_VECTOR(vb, b, n, b_stride);
+  switch (op) {
+    case ELEMENT_ADD:
+      return gsl_vector_add(&va.vector, &vb.vector);
+    case ELEMENT_SUB:
+      return gsl_vector_sub(&va.vector, &vb.vector);
+    case ELEMENT_MUL:
+      return gsl_vector_mul(&va.vector, &vb.vector);
+    case ELEMENT_DIV:
+      return gsl_vector_div(&va.vector, &vb.vector);
+  }
+  return GSL_EINVAL;
+}
+
+
+/* vector_scale multiplies all elements of x by c */
+int vector_scale(double *x, size_t n, size_t stride, double c) {
+  VECTOR(v, x, n, stride);
+  return gsl_vector_scale(&v.vector, c);
+}
+
+
+/* vector_add_constant adds c to all elements of x */
+int vector_add_constant(double *x, size_t n, size_t stride, double c) {
+  VECTOR(v, x, n, stride);
+  return gsl_vector_add_constant(&v.vector, c);
+}
+
+
+/* vector_minmax determines the minimum and maximum element of x */
+void vector_minmax(const double *x, size_t n, size_t stride, double *min,
+  double *max) {
+
+  CONST_VECTOR(v, x, n, stride);
+  gsl_vector_minmax(&v.vector, min, max);
+}
+
+
+/* vector_minmax_index determines the indices of the minimum and maximum
+ * element of x */
+void vector_minmax_index(const double *x, size_t n, size_t stride,
+  size_t *imin, size_t *imax) {
+
+  CONST_VECTOR(v, x, n, stride);
+  gsl_vector_minmax_index(&v.vector, imin, imax);
+}
+
+
+/* matrix_set_all sets all elements of x to val */
+void matrix_set_all(double *x, size_t n1, size_t n2, size_t tda,
+  double val) {
+
+  MATRIX(m, x, n1, n2, tda);
+  gsl_matrix_set_all(&m.matrix, val);
+}
+
+
+/* matrix_set_identity sets the diagonal elements of x to one and all
+ * others to zero */
+void matrix_set_identity(double *x, size_t n1, size_t n2, size_t tda) {
+  MATRIX(m, x, n1, n2, tda);
+  gsl_matrix_set_identity(&m.matrix);
+}
+
+
+/* matrix_memcpy copies the n1 x n2 elements of src into dst */
+int matrix_memcpy(double *dst, size_t dst_tda, const double *src,
+  size_t src_tda, size_t n1, size_t n2) {
+
+  MATRIX(d, dst, n1, n2, dst_tda);
+  CONST_MATRIX(s, src, n1, n2, src_tda);
+  return gsl_matrix_memcpy(&d.matrix, &s.matrix);
+}
+
+
+/* matrix_swap exchanges the n1 x n2 elements of a and b */
+int matrix_swap(double *a, size_t a_tda, double *b, size_t b_tda,
+  size_t n1, size_t n2) {
+
+  MATRIX(ma, a, n1, n2, a_tda);
+  MATRIX(mb, b, n1, n2, b_tda);
+  return gsl_matrix_swap(&ma.matrix, &mb.matrix);
+}
+
+
+/* matrix_swap_rows exchanges rows i and j of x */
+int matrix_swap_rows(double *x, size_t n1, size_t n2, size_t tda, size_t i,
+  size_t j) {
+
+  MATRIX(m, x, n1, n2, tda);
+  return gsl_matrix_swap_rows(&m.matrix, i, j);
+}
+
+
+/* matrix_swap_columns exchanges columns i and j of x */
+int matrix_swap_columns(double *x, size_t n1, size_t n2, size_t tda,
+  size_t i, size_t j) {
+
+  MATRIX(m, x, n1, n2, tda);
+  return gsl_matrix_swap_columns(&m.matrix, i, j);
+}
+
+
+/* matrix_transpose transposes the square n x n matrix x in place */
+int matrix_transpose(double *x, size_t n, size_t tda) {
+  MATRIX(m, x, n, n, tda);
+  return gsl_matrix_transpose(&m.matrix);
+}
+
+
+/* matrix_transpose_memcpy stores the transpose of the n1 x n2 matrix src
+ * in the n2 x n1 matrix dst */
+int matrix_transpose_memcpy(double *dst, size_t dst_tda, const double *src,
+  size_t src_tda, size_t n1, size_t n2) {
+
+  MATRIX(d, dst, n2, n1, dst_tda);
+  CONST_MATRIX(s, src, n1, n2, src_tda);
+  return gsl_matrix_transpose_memcpy(&d.matrix, &s.matrix);
+}
+
+
+/* matrix_op applies the element wise operation op to a and b and stores
+ * the result in a */
+int matrix_op(int op, double *a, size_t a_tda, const double *b,
+  size_t b_tda, size_t n1, size_t n2) {
+
+  MATRIX(ma, a, n1, n2, a_tda);
+  CONST_MATRIX(mb, b, n1, n2, b_tda);
+  switch (op) {
+    case ELEMENT_ADD:
+      return gsl_matrix_add(&ma.matrix, &mb.matrix);
+    case ELEMENT_SUB:
+      return gsl_matrix_sub(&ma.matrix, &mb.matrix);
+    case ELEMENT_MUL:
+      return gsl_matrix_mul_elements(&ma.matrix, &mb.matrix);
+    case ELEMENT_DIV:
+      return gsl_matrix_div_elements(&ma.matrix, &mb.matrix);
+  }
+  return GSL_EINVAL;
+}
+
+
+/* matrix_scale multiplies all elements of x by c */
+int matrix_scale(double *x, size_t n1, size_t n2, size_t tda, double c) {
+  MATRIX(m, x, n1, n2, tda);
+  return gsl_matrix_scale(&m.matrix, c);
+}
+
+
+/* matrix_add_constant adds c to all elements of x */
+int matrix_add_constant(double *x, size_t n1, size_t n2, size_t tda,
+  double c) {
+
+  MATRIX(m, x, n1, n2, tda);
+  return gsl_matrix_add_constant(&m.matrix, c);
+}
+
+
+/* matrix_minmax determines the minimum and maximum element of x */
+void matrix_minmax(const double *x, size_t n1, size_t n2, size_t tda,
+  double *min, double *max) {
+
+  CONST_MATRIX(m, x, n1, n2, tda);
+  gsl_matrix_minmax(&m.matrix, min, max);
+}
+
+
+/* matrix_minmax_index determines the row and column indices of the
+ * minimum and maximum element of x */
+void matrix_minmax_index(const double *x, size_t n1, size_t n2, size_t tda,
+  size_t *imin, size_t *jmin, size_t *imax, size_t *jmax) {
+
+  CONST_MATRIX(m, x, n1, n2, tda);
+  gsl_matrix_minmax_index(&m.matrix, imin, jmin, imax, jmax);
+}
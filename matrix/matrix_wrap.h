@@ -0,0 +1,94 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * matrix_wrap provides gsl vector and matrix wrappers for go-gsl operating
+ * on plain arrays described by their dimensions and strides
+ */
+
+
+#ifndef MATRIX_WRAP_H
+#define MATRIX_WRAP_H
+
+#include <stddef.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+/* element wise operations supported by vector_op and matrix_op */
+enum { ELEMENT_ADD, ELEMENT_SUB, ELEMENT_MUL, ELEMENT_DIV };
+
+
+void vector_set_all(double *x, size_t n, size_t stride, double val);
+
+int vector_set_basis(double *x, size_t n, size_t stride, size_t i);
+
+int vector_reverse(double *x, size_t n, size_t stride);
+
+int vector_memcpy(double *dst, size_t dst_stride, const double *src,
+  size_t src_stride, size_t n);
+
+int vector_swap(double *a, size_t a_stride, double *b, size_t b_stride,
+  size_t n);
+
+int vector_swap_elements(double *x, size_t n, size_t stride, size_t i,
+  size_t j);
+
+int vector_op(int op, double *a, size_t a_stride, const double *b,
+  size_t b_stride, size_t n);
+
+int vector_scale(double *x, size_t n, size_t stride, double c);
+
+int vector_add_constant(double *x, size_t n, size_t stride, double c);
+
+void vector_minmax(const double *x, size_t n, size_t stride, double *min,
+  double *max);
+
+void vector_minmax_index(const double *x, size_t n, size_t stride,
+  size_t *imin, size_t *imax);
+
+void matrix_set_all(double *x, size_t n1, size_t n2, size_t tda,
+  double val);
+
+void matrix_set_identity(double *x, size_t n1, size_t n2, size_t tda);
+
+int matrix_memcpy(double *dst, size_t dst_tda, const double *src,
+  size_t src_tda, size_t n1, size_t n2);
+
+int matrix_swap(double *a, size_t a_tda, double *b, size_t b_tda,
+  size_t n1, size_t n2);
+
+int matrix_swap_rows(double *x, size_t n1, size_t n2, size_t tda, size_t i,
+  size_t j);
+
+int matrix_swap_columns(double *x, size_t n1, size_t n2, size_t tda,
+  size_t i, size_t j);
+
+int matrix_transpose(double *x, size_t n, size_t tda);
+
+int matrix_transpose_memcpy(double *dst, size_t dst_tda, const double *src,
+  size_t src_tda, size_t n1, size_t n2);
+
+int matrix_op(int op, double *a, size_t a_tda, const double *b,
+  size_t b_tda, size_t n1, size_t n2);
+
+int matrix_scale(double *x, size_t n1, size_t n2, size_t tda, double c);
+
+int matrix_add_constant(double *x, size_t n1, size_t n2, size_t tda,
+  double c);
+
+void matrix_minmax(const double *x, size_t n1, size_t n2, size_t tda,
+  double *min, double *max);
+
+void matrix_minmax_index(const double *x, size_t n1, size_t n2, size_t tda,
+  size_t *imin, size_t *jmin, size_t *imax, size_t *jmax);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
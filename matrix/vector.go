@@ -0,0 +1,287 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// vector provides vectors over Go allocated memory backed by gsl_vector
+package matrix
+
+// #cgo pkg-config: gsl
+// #include "matrix_wrap.h"
+import "C"
+
+import (
+  "fmt"
+  "unsafe"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/stats"
+)
+
+// Vector is a vector of n elements stored stride elements apart in a Go
+// slice. Vectors returned by the view methods of Vector and Matrix share
+// their memory with the viewed object.
+type Vector struct {
+  data   []float64
+  n      int
+  stride int
+}
+
+// NewVector returns a vector of n zero elements
+func NewVector(n int) (*Vector, error) {
+  if n < 1 {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid vector length %d.", n)
+  }
+  return &Vector{make([]float64, n), n, 1}, nil
+}
+
+// NewVectorFrom returns a vector wrapping every stride-th element of data
+// without copying. Since stats.FloatSlice is a []float64 it can be passed
+// directly, e.g., to run gsl vector operations on data used with stats.
+// A *gsl.Error with code gsl.EINVAL is returned if stride is smaller than
+// one and with code gsl.EBADLEN if data is empty.
+func NewVectorFrom(data []float64, stride int) (*Vector, error) {
+  if stride < 1 {
+    return nil, gsl.NewError(gsl.EINVAL, "Invalid stride %d.", stride)
+  }
+  if len(data) == 0 {
+    return nil, gsl.NewError(gsl.EBADLEN, "Empty data.")
+  }
+  n := (len(data) + stride - 1) / stride
+  return newVector(data, n, stride), nil
+}
+
+// newVector returns a vector with n elements of stride stride starting at
+// data[0]. data is truncated to the last element of the vector.
+func newVector(data []float64, n, stride int) *Vector {
+  return &Vector{data[:(n-1)*stride+1], n, stride}
+}
+
+// ptr returns a C pointer to the first element of v. Go memory without
+// any Go pointers may be passed to C for the duration of a call, so no
+// pinning is required.
+func (v *Vector) ptr() *C.double {
+  return (*C.double)(unsafe.Pointer(&v.data[0]))
+}
+
+// Len returns the number of elements of v
+func (v *Vector) Len() int {
+  return v.n
+}
+
+// Stride returns the distance between consecutive elements of v in the
+// underlying data
+func (v *Vector) Stride() int {
+  return v.stride
+}
+
+// Data returns the memory underlying v. Element i of v is stored at index
+// i*v.Stride().
+func (v *Vector) Data() []float64 {
+  return v.data
+}
+
+// FloatSlice returns the memory underlying v and its stride so that the
+// strided functions of the stats package can be called on any vector or
+// view, e.g.,
+//
+//   d, stride := m.Col(2).FloatSlice()
+//   mean, err := d.Mean(stride)
+func (v *Vector) FloatSlice() (stats.FloatSlice, int) {
+  return stats.FloatSlice(v.data), v.stride
+}
+
+// Slice returns a copy of the elements of v
+func (v *Vector) Slice() []float64 {
+  s := make([]float64, v.n)
+  for i := range s {
+    s[i] = v.data[i*v.stride]
+  }
+  return s
+}
+
+// checkIndex panics if i is not a valid element index
+func (v *Vector) checkIndex(i int) {
+  if i < 0 || i >= v.n {
+    panic(fmt.Sprintf("Vector index %d out of range [0, %d).", i, v.n))
+  }
+}
+
+// At returns element i. At panics if i is out of range.
+func (v *Vector) At(i int) float64 {
+  v.checkIndex(i)
+  return v.data[i*v.stride]
+}
+
+// Set sets element i to x. Set panics if i is out of range.
+func (v *Vector) Set(i int, x float64) {
+  v.checkIndex(i)
+  v.data[i*v.stride] = x
+}
+
+// SetAll sets all elements to x
+func (v *Vector) SetAll(x float64) {
+  C.vector_set_all(v.ptr(), C.size_t(v.n), C.size_t(v.stride), C.double(x))
+}
+
+// SetZero sets all elements to zero
+func (v *Vector) SetZero() {
+  v.SetAll(0)
+}
+
+// SetBasis sets element i to one and all others to zero. SetBasis panics
+// if i is out of range.
+func (v *Vector) SetBasis(i int) {
+  v.checkIndex(i)
+  C.vector_set_basis(v.ptr(), C.size_t(v.n), C.size_t(v.stride), C.size_t(i))
+}
+
+// Subvector returns a view of the n elements of v starting at element
+// offset. Subvector panics if the view is not contained in v.
+func (v *Vector) Subvector(offset, n int) *Vector {
+  return v.SubvectorWithStride(offset, 1, n)
+}
+
+// SubvectorWithStride returns a view of n elements of v starting at
+// element offset and taking every stride-th element from there on.
+// SubvectorWithStride panics if the view is not contained in v.
+func (v *Vector) SubvectorWithStride(offset, stride, n int) *Vector {
+  if offset < 0 || stride < 1 || n < 1 || offset+(n-1)*stride >= v.n {
+    panic(fmt.Sprintf("Subvector (%d, %d, %d) out of range for vector of "+
+      "length %d.", offset, stride, n, v.n))
+  }
+  return newVector(v.data[offset*v.stride:], n, stride*v.stride)
+}
+
+// checkLen returns a *gsl.Error with code gsl.EBADLEN unless v and w
+// have the same length
+func (v *Vector) checkLen(w *Vector) error {
+  if v.n != w.n {
+    return gsl.NewError(gsl.EBADLEN, "Vector lengths %d and %d differ.",
+      v.n, w.n)
+  }
+  return nil
+}
+
+// Copy copies the elements of src into v. A *gsl.Error with code
+// gsl.EBADLEN is returned if the lengths differ.
+func (v *Vector) Copy(src *Vector) error {
+  if err := v.checkLen(src); err != nil {
+    return err
+  }
+  return gsl.Protect(func() {
+    C.vector_memcpy(v.ptr(), C.size_t(v.stride), src.ptr(),
+      C.size_t(src.stride), C.size_t(v.n))
+  })
+}
+
+// Swap exchanges the elements of v and w. A *gsl.Error with code
+// gsl.EBADLEN is returned if the lengths differ.
+func (v *Vector) Swap(w *Vector) error {
+  if err := v.checkLen(w); err != nil {
+    return err
+  }
+  return gsl.Protect(func() {
+    C.vector_swap(v.ptr(), C.size_t(v.stride), w.ptr(), C.size_t(w.stride),
+      C.size_t(v.n))
+  })
+}
+
+// SwapElements exchanges elements i and j. SwapElements panics if i or j
+// is out of range.
+func (v *Vector) SwapElements(i, j int) {
+  v.checkIndex(i)
+  v.checkIndex(j)
+  C.vector_swap_elements(v.ptr(), C.size_t(v.n), C.size_t(v.stride),
+    C.size_t(i), C.size_t(j))
+}
+
+// Reverse reverses the order of the elements
+func (v *Vector) Reverse() {
+  C.vector_reverse(v.ptr(), C.size_t(v.n), C.size_t(v.stride))
+}
+
+// elementOp applies the element wise operation op to v and w
+func (v *Vector) elementOp(op C.int, w *Vector) error {
+  if err := v.checkLen(w); err != nil {
+    return err
+  }
+  return gsl.Protect(func() {
+    C.vector_op(op, v.ptr(), C.size_t(v.stride), w.ptr(), C.size_t(w.stride),
+      C.size_t(v.n))
+  })
+}
+
+// Add adds the elements of w to the elements of v. A *gsl.Error with code
+// gsl.EBADLEN is returned if the lengths differ.
+func (v *Vector) Add(w *Vector) error {
+  return v.elementOp(C.ELEMENT_ADD, w)
+}
+
+// Sub subtracts the elements of w from the elements of v. A *gsl.Error
+// with code gsl.EBADLEN is returned if the lengths differ.
+func (v *Vector) Sub(w *Vector) error {
+  return v.elementOp(C.ELEMENT_SUB, w)
+}
+
+// Mul multiplies the elements of v by the elements of w. A *gsl.Error
+// with code gsl.EBADLEN is returned if the lengths differ.
+func (v *Vector) Mul(w *Vector) error {
+  return v.elementOp(C.ELEMENT_MUL, w)
+}
+
+// Div divides the elements of v by the elements of w. A *gsl.Error with
+// code gsl.EBADLEN is returned if the lengths differ.
+func (v *Vector) Div(w *Vector) error {
+  return v.elementOp(C.ELEMENT_DIV, w)
+}
+
+// Scale multiplies all elements by x
+func (v *Vector) Scale(x float64) {
+  C.vector_scale(v.ptr(), C.size_t(v.n), C.size_t(v.stride), C.double(x))
+}
+
+// AddConstant adds x to all elements
+func (v *Vector) AddConstant(x float64) {
+  C.vector_add_constant(v.ptr(), C.size_t(v.n), C.size_t(v.stride),
+    C.double(x))
+}
+
+// MinMax returns the smallest and largest element
+func (v *Vector) MinMax() (float64, float64) {
+  var min, max C.double
+  C.vector_minmax(v.ptr(), C.size_t(v.n), C.size_t(v.stride), &min, &max)
+  return float64(min), float64(max)
+}
+
+// Min returns the smallest element
+func (v *Vector) Min() float64 {
+  min, _ := v.MinMax()
+  return min
+}
+
+// Max returns the largest element
+func (v *Vector) Max() float64 {
+  _, max := v.MinMax()
+  return max
+}
+
+// MinMaxIndex returns the indices of the smallest and largest element. In
+// case of ties the smallest index is returned.
+func (v *Vector) MinMaxIndex() (int, int) {
+  var imin, imax C.size_t
+  C.vector_minmax_index(v.ptr(), C.size_t(v.n), C.size_t(v.stride), &imin,
+    &imax)
+  return int(imin), int(imax)
+}
+
+// MinIndex returns the index of the smallest element
+func (v *Vector) MinIndex() int {
+  imin, _ := v.MinMaxIndex()
+  return imin
+}
+
+// MaxIndex returns the index of the largest element
+func (v *Vector) MaxIndex() int {
+  _, imax := v.MinMaxIndex()
+  return imax
+}
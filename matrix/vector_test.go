@@ -0,0 +1,176 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// vector provides vectors over Go allocated memory backed by gsl_vector
+package matrix
+
+import (
+  "errors"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/stats"
+  "github.com/haskelladdict/gsl/util"
+)
+
+// equalSlices checks that a and b contain the same elements
+func equalSlices(a, b []float64) bool {
+  if len(a) != len(b) {
+    return false
+  }
+  for i := range a {
+    if a[i] != b[i] {
+      return false
+    }
+  }
+  return true
+}
+
+// test set 1
+func Test_vector_1(t *testing.T) {
+
+  // test 1
+  data := []float64{1, 2, 3, 4, 5, 6, 7}
+  v, err := NewVectorFrom(data, 2)
+  if err != nil {
+    t.Fatal(err)
+  }
+  if v.Len() != 4 || v.Stride() != 2 {
+    t.Error("Test 1: Wrong length or stride", v.Len(), v.Stride())
+  }
+  if !equalSlices(v.Slice(), []float64{1, 3, 5, 7}) {
+    t.Error("Test 1: Wrong elements", v.Slice())
+  }
+
+  // test 2
+  v.Set(1, 10)
+  if data[2] != 10 || v.At(1) != 10 {
+    t.Error("Test 2: Vector does not share memory with data.")
+  }
+
+  // test 3
+  sub := v.Subvector(1, 2)
+  sub.SetAll(-1)
+  if !equalSlices(data, []float64{1, 2, -1, 4, -1, 6, 7}) {
+    t.Error("Test 3: Wrong data after setting subvector", data)
+  }
+  sub = v.SubvectorWithStride(0, 3, 2)
+  if !equalSlices(sub.Slice(), []float64{1, 7}) || sub.Stride() != 6 {
+    t.Error("Test 3: Wrong strided subvector", sub.Slice())
+  }
+
+  // test 4
+  if _, err := NewVectorFrom(data, 0); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 4: Expected EINVAL for invalid stride, got", err)
+  }
+  if _, err := NewVectorFrom(nil, 1); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 4: Expected EBADLEN for empty data, got", err)
+  }
+  if _, err := NewVector(0); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 4: Expected EINVAL for empty vector, got", err)
+  }
+
+  // test 5
+  func() {
+    defer func() {
+      if recover() == nil {
+        t.Error("Test 5: Expected panic for index out of range.")
+      }
+    }()
+    v.At(4)
+  }()
+  func() {
+    defer func() {
+      if recover() == nil {
+        t.Error("Test 5: Expected panic for subvector out of range.")
+      }
+    }()
+    v.Subvector(2, 3)
+  }()
+}
+
+// test set 2
+func Test_vector_2(t *testing.T) {
+
+  // test 1
+  v, _ := NewVectorFrom([]float64{3, -1, 4, 1, 5, 9, 2, 6}, 1)
+  min, max := v.MinMax()
+  if min != -1 || max != 9 || v.Min() != -1 || v.Max() != 9 {
+    t.Error("Test 1: Wrong minimum or maximum", min, max)
+  }
+  imin, imax := v.MinMaxIndex()
+  if imin != 1 || imax != 5 || v.MinIndex() != 1 || v.MaxIndex() != 5 {
+    t.Error("Test 1: Wrong minimum or maximum index", imin, imax)
+  }
+
+  // test 2
+  w, _ := NewVector(8)
+  if err := w.Copy(v); err != nil || !equalSlices(w.Slice(), v.Slice()) {
+    t.Error("Test 2: Copy failed", err, w.Slice())
+  }
+  w.Reverse()
+  if !equalSlices(w.Slice(), []float64{6, 2, 9, 5, 1, 4, -1, 3}) {
+    t.Error("Test 2: Reverse failed", w.Slice())
+  }
+  w.SwapElements(0, 7)
+  if w.At(0) != 3 || w.At(7) != 6 {
+    t.Error("Test 2: SwapElements failed", w.Slice())
+  }
+  if err := w.Swap(v); err != nil || v.At(0) != 3 || v.At(1) != 2 {
+    t.Error("Test 2: Swap failed", err, v.Slice())
+  }
+
+  // test 3
+  a, _ := NewVectorFrom([]float64{1, 2, 3}, 1)
+  b, _ := NewVectorFrom([]float64{4, 0, 5, 0, 6}, 2)
+  a.Add(b)
+  if !equalSlices(a.Slice(), []float64{5, 7, 9}) {
+    t.Error("Test 3: Add failed", a.Slice())
+  }
+  a.Sub(b)
+  a.Mul(b)
+  if !equalSlices(a.Slice(), []float64{4, 10, 18}) {
+    t.Error("Test 3: Mul failed", a.Slice())
+  }
+  a.Div(b)
+  a.Scale(2)
+  a.AddConstant(1)
+  if !equalSlices(a.Slice(), []float64{3, 5, 7}) {
+    t.Error("Test 3: Div, Scale or AddConstant failed", a.Slice())
+  }
+  a.SetBasis(1)
+  if !equalSlices(a.Slice(), []float64{0, 1, 0}) {
+    t.Error("Test 3: SetBasis failed", a.Slice())
+  }
+
+  // test 4
+  for _, err := range []error{a.Add(v), a.Copy(v), a.Swap(v)} {
+    if !errors.Is(err, gsl.EBADLEN) {
+      t.Error("Test 4: Expected EBADLEN for different lengths, got", err)
+    }
+  }
+}
+
+// test set 3
+func Test_vector_3(t *testing.T) {
+
+  // test 1
+  data := stats.FloatSlice{1, 100, 2, 100, 3, 100, 4}
+  v, err := NewVectorFrom(data, 2)
+  if err != nil {
+    t.Fatal(err)
+  }
+  d, stride := v.FloatSlice()
+  mean, err := d.Mean(stride)
+  if err != nil || !util.FloatEqual(mean, 2.5) {
+    t.Error("Test 1: Wrong mean of strided vector", mean, err)
+  }
+
+  // test 2
+  d, stride = v.Subvector(1, 3).FloatSlice()
+  max, err := d.Max(stride)
+  if err != nil || max != 4 {
+    t.Error("Test 2: Wrong maximum of subvector", max, err)
+  }
+}
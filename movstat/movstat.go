@@ -0,0 +1,308 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// movstat wraps the gsl moving window statistics routines
+//
+// Each function computes a statistic over a window sliding along the
+// input x and returns an output y of the same length, where y[i] is the
+// statistic of the window around x[i]. The window extends w.H samples
+// before and w.J samples after x[i]; Symmetric(h) gives a window of length
+// 2h+1 centered on x[i] and Window{H: h} a trailing window covering
+// x[i-h], ..., x[i]. Near the ends of x the window is completed according
+// to the End mode. Custom statistics can be computed with Apply.
+//
+// A *gsl.Error with code gsl.EBADLEN is returned for empty input and with
+// code gsl.EINVAL for windows with negative extent. Requires GSL 2.5 or
+// newer.
+package movstat
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_errno.h>
+// #include <gsl/gsl_movstat.h>
+// #include "movstat_wrap.h"
+import "C"
+
+import (
+  "runtime/cgo"
+  "unsafe"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/stats"
+)
+
+// End determines how windows extending beyond the ends of the input are
+// handled
+type End int
+
+const (
+  // PadZero pads the input with zeros
+  PadZero End = C.GSL_MOVSTAT_END_PADZERO
+
+  // PadValue pads the input with its first and last value, respectively
+  PadValue End = C.GSL_MOVSTAT_END_PADVALUE
+
+  // Truncate only uses the available samples, i.e., windows near the
+  // ends are shorter
+  Truncate End = C.GSL_MOVSTAT_END_TRUNCATE
+)
+
+// Window describes a moving window covering H samples before and J
+// samples after the current one
+type Window struct {
+  H, J int
+}
+
+// Symmetric returns a window of length 2h+1 centered on the current sample
+func Symmetric(h int) Window {
+  return Window{h, h}
+}
+
+// Accumulator computes a custom statistic of the samples of a single
+// window. The window slice is only valid during the call and may be
+// reordered by the accumulator, e.g., for sorting.
+type Accumulator func(window []float64) float64
+
+// run allocates a gsl workspace for window w and calls f with it and the
+// length of x. Errors raised by gsl and non-zero status codes returned by
+// f are turned into a *gsl.Error.
+func run(x stats.FloatSlice, w Window, f func(ws *C.gsl_movstat_workspace,
+  n C.size_t) C.int) error {
+  if len(x) == 0 {
+    return gsl.NewError(gsl.EBADLEN, "Empty dataset.")
+  }
+  if w.H < 0 || w.J < 0 {
+    return gsl.NewError(gsl.EINVAL, "Invalid window [-%d, %d].", w.H, w.J)
+  }
+
+  var status C.int
+  err := gsl.Protect(func() {
+    ws := C.gsl_movstat_alloc2(C.size_t(w.H), C.size_t(w.J))
+    if ws == nil {
+      status = C.GSL_ENOMEM
+      return
+    }
+    defer C.gsl_movstat_free(ws)
+    status = f(ws, C.size_t(len(x)))
+  })
+  if err == nil && status != 0 {
+    err = gsl.NewError(gsl.Errno(status), "Failed to compute moving "+
+      "window statistic.")
+  }
+  return err
+}
+
+// cptr returns a C pointer to the first element of d
+func cptr(d stats.FloatSlice) *C.double {
+  return (*C.double)(&d[0])
+}
+
+// Mean returns the moving window mean of x
+func Mean(x stats.FloatSlice, w Window, end End) (stats.FloatSlice, error) {
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_mean(C.gsl_movstat_end_t(end), cptr(x), cptr(y), n, ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// Variance returns the moving window variance of x
+func Variance(x stats.FloatSlice, w Window, end End) (stats.FloatSlice,
+  error) {
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_variance(C.gsl_movstat_end_t(end), cptr(x), cptr(y), n,
+      ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// Sd returns the moving window standard deviation of x
+func Sd(x stats.FloatSlice, w Window, end End) (stats.FloatSlice, error) {
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_sd(C.gsl_movstat_end_t(end), cptr(x), cptr(y), n, ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// Median returns the moving window median of x
+func Median(x stats.FloatSlice, w Window, end End) (stats.FloatSlice,
+  error) {
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_median(C.gsl_movstat_end_t(end), cptr(x), cptr(y), n,
+      ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// Min returns the moving window minimum of x
+func Min(x stats.FloatSlice, w Window, end End) (stats.FloatSlice, error) {
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_min(C.gsl_movstat_end_t(end), cptr(x), cptr(y), n, ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// Max returns the moving window maximum of x
+func Max(x stats.FloatSlice, w Window, end End) (stats.FloatSlice, error) {
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_max(C.gsl_movstat_end_t(end), cptr(x), cptr(y), n, ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// MinMax returns the moving window minimum and maximum of x in a single
+// pass
+func MinMax(x stats.FloatSlice, w Window, end End) (stats.FloatSlice,
+  stats.FloatSlice, error) {
+  yMin := make(stats.FloatSlice, len(x))
+  yMax := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_minmax(C.gsl_movstat_end_t(end), cptr(x), cptr(yMin),
+      cptr(yMax), n, ws)
+  })
+  if err != nil {
+    return nil, nil, err
+  }
+  return yMin, yMax, nil
+}
+
+// Sum returns the moving window sum of x
+func Sum(x stats.FloatSlice, w Window, end End) (stats.FloatSlice, error) {
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_sum(C.gsl_movstat_end_t(end), cptr(x), cptr(y), n, ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// Mad0 returns the moving window median and unscaled median absolute
+// deviation of x, see stats.FloatSlice.Mad0
+func Mad0(x stats.FloatSlice, w Window, end End) (stats.FloatSlice,
+  stats.FloatSlice, error) {
+  median := make(stats.FloatSlice, len(x))
+  mad := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_mad0(C.gsl_movstat_end_t(end), cptr(x), cptr(median),
+      cptr(mad), n, ws)
+  })
+  if err != nil {
+    return nil, nil, err
+  }
+  return median, mad, nil
+}
+
+// Mad returns the moving window median and median absolute deviation of
+// x scaled to estimate the standard deviation of Gaussian data, see
+// stats.FloatSlice.Mad
+func Mad(x stats.FloatSlice, w Window, end End) (stats.FloatSlice,
+  stats.FloatSlice, error) {
+  median := make(stats.FloatSlice, len(x))
+  mad := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_mad(C.gsl_movstat_end_t(end), cptr(x), cptr(median),
+      cptr(mad), n, ws)
+  })
+  if err != nil {
+    return nil, nil, err
+  }
+  return median, mad, nil
+}
+
+// QQR returns the moving window q-quantile range Q(1-q) - Q(q) of x. q has
+// to be in [0, 0.5], e.g., q = 0.25 yields the interquartile range.
+func QQR(x stats.FloatSlice, q float64, w Window, end End) (stats.FloatSlice,
+  error) {
+  if !(q >= 0 && q <= 0.5) {
+    return nil, gsl.NewError(gsl.EINVAL, "Quantile %g not in [0, 0.5].", q)
+  }
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_qqr(C.gsl_movstat_end_t(end), cptr(x), C.double(q),
+      cptr(y), n, ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// Sn returns the moving window Sn scale estimate of x, see
+// stats.FloatSlice.SnFromSortedData
+func Sn(x stats.FloatSlice, w Window, end End) (stats.FloatSlice, error) {
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_Sn(C.gsl_movstat_end_t(end), cptr(x), cptr(y), n, ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// Qn returns the moving window Qn scale estimate of x, see
+// stats.FloatSlice.QnFromSortedData
+func Qn(x stats.FloatSlice, w Window, end End) (stats.FloatSlice, error) {
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_Qn(C.gsl_movstat_end_t(end), cptr(x), cptr(y), n, ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+// Apply returns the moving window statistic of x computed by the custom
+// accumulator f. f is called once per sample of x with the samples of the
+// corresponding window, including any padding.
+//
+// NOTE: Every window involves a call from C back into Go which is
+// considerably slower than the builtin statistics.
+func Apply(x stats.FloatSlice, w Window, end End,
+  f Accumulator) (stats.FloatSlice, error) {
+  h := cgo.NewHandle(f)
+  defer h.Delete()
+
+  y := make(stats.FloatSlice, len(x))
+  err := run(x, w, func(ws *C.gsl_movstat_workspace, n C.size_t) C.int {
+    return C.movstat_apply(C.gsl_movstat_end_t(end), C.uintptr_t(h), cptr(x),
+      cptr(y), n, ws)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return y, nil
+}
+
+//export goMovstatApply
+func goMovstatApply(n C.size_t, x *C.double, handle C.uintptr_t) C.double {
+  f := cgo.Handle(handle).Value().(Accumulator)
+  window := unsafe.Slice((*float64)(unsafe.Pointer(x)), int(n))
+  return C.double(f(window))
+}
@@ -0,0 +1,256 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// movstat wraps the gsl moving window statistics routines
+package movstat
+
+import (
+  "errors"
+  "math"
+  "sort"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/stats"
+  "github.com/haskelladdict/gsl/util"
+)
+
+// checkSlice compares got and expected elementwise
+func checkSlice(t *testing.T, msg string, got, expected stats.FloatSlice) {
+  if len(got) != len(expected) {
+    t.Error(msg, "length mismatch", len(got), len(expected))
+    return
+  }
+  for i := range got {
+    if !util.FloatEqual(got[i], expected[i]) {
+      t.Error(msg, "at", i, got[i], expected[i])
+      return
+    }
+  }
+}
+
+// test set 1
+func Test_movstat_1(t *testing.T) {
+
+  data := stats.FloatSlice{1.0, 2.0, 3.0, 4.0, 5.0}
+
+  // test 1
+  mean, err := Mean(data, Symmetric(1), Truncate)
+  if err != nil {
+    t.Fatal(err)
+  }
+  checkSlice(t, "Test 1: Truncated mean differs", mean,
+    stats.FloatSlice{1.5, 2.0, 3.0, 4.0, 4.5})
+
+  mean, err = Mean(data, Symmetric(1), PadZero)
+  if err != nil {
+    t.Fatal(err)
+  }
+  checkSlice(t, "Test 1: Zero padded mean differs", mean,
+    stats.FloatSlice{1.0, 2.0, 3.0, 4.0, 3.0})
+
+  mean, err = Mean(data, Symmetric(1), PadValue)
+  if err != nil {
+    t.Fatal(err)
+  }
+  checkSlice(t, "Test 1: Value padded mean differs", mean,
+    stats.FloatSlice{4.0 / 3.0, 2.0, 3.0, 4.0, 14.0 / 3.0})
+
+  // test 2
+  sum, err := Sum(data, Symmetric(1), PadZero)
+  if err != nil {
+    t.Fatal(err)
+  }
+  checkSlice(t, "Test 2: Sum differs", sum,
+    stats.FloatSlice{3.0, 6.0, 9.0, 12.0, 9.0})
+
+  // test 3
+  median, err := Median(stats.FloatSlice{5.0, 1.0, 4.0, 2.0, 3.0},
+    Symmetric(1), PadValue)
+  if err != nil {
+    t.Fatal(err)
+  }
+  checkSlice(t, "Test 3: Median differs", median,
+    stats.FloatSlice{5.0, 4.0, 2.0, 3.0, 3.0})
+
+  // test 4: trailing window
+  data = stats.FloatSlice{3.0, 1.0, 4.0, 1.0, 5.0, 9.0, 2.0}
+  min, err := Min(data, Window{H: 2}, Truncate)
+  if err != nil {
+    t.Fatal(err)
+  }
+  checkSlice(t, "Test 4: Min differs", min,
+    stats.FloatSlice{3.0, 1.0, 1.0, 1.0, 1.0, 1.0, 2.0})
+
+  max, err := Max(data, Window{H: 2}, Truncate)
+  if err != nil {
+    t.Fatal(err)
+  }
+  checkSlice(t, "Test 4: Max differs", max,
+    stats.FloatSlice{3.0, 3.0, 4.0, 4.0, 5.0, 9.0, 9.0})
+
+  min2, max2, err := MinMax(data, Window{H: 2}, Truncate)
+  if err != nil {
+    t.Fatal(err)
+  }
+  checkSlice(t, "Test 4: MinMax differs from Min", min2, min)
+  checkSlice(t, "Test 4: MinMax differs from Max", max2, max)
+
+  // test 5: leading window
+  max, err = Max(data, Window{J: 1}, PadValue)
+  if err != nil {
+    t.Fatal(err)
+  }
+  checkSlice(t, "Test 5: Leading max differs", max,
+    stats.FloatSlice{3.0, 4.0, 4.0, 5.0, 9.0, 9.0, 2.0})
+}
+
+// test set 2
+//
+// NOTE: The builtin statistics are compared to custom accumulators
+//       computing the same statistic via package stats.
+func Test_movstat_2(t *testing.T) {
+
+  data := stats.FloatSlice{16.0, 99.0, 26.0, 85.0, 76.0, 50.0, 46.0, 11.0, 79.0,
+    97.0, 24.0, 20.0, 100.0, 68.0, 22.0, 15.0, 5.0, 89.0, 45.0, 2.0, 57.0}
+
+  sorted := func(window []float64) stats.FloatSlice {
+    s := append(stats.FloatSlice(nil), window...)
+    sort.Float64s(s)
+    return s
+  }
+
+  builtin := map[string]func(x stats.FloatSlice, w Window,
+    end End) (stats.FloatSlice, error){
+    "Mean":     Mean,
+    "Variance": Variance,
+    "Sd":       Sd,
+    "Median":   Median,
+    "Sn":       Sn,
+    "Qn":       Qn,
+    "Mad0": func(x stats.FloatSlice, w Window, end End) (stats.FloatSlice,
+      error) {
+      _, mad, err := Mad0(x, w, end)
+      return mad, err
+    },
+    "Mad": func(x stats.FloatSlice, w Window, end End) (stats.FloatSlice,
+      error) {
+      _, mad, err := Mad(x, w, end)
+      return mad, err
+    },
+    "QQR": func(x stats.FloatSlice, w Window, end End) (stats.FloatSlice,
+      error) {
+      return QQR(x, 0.25, w, end)
+    },
+  }
+
+  custom := map[string]Accumulator{
+    "Mean": func(window []float64) float64 {
+      v, _ := stats.FloatSlice(window).Mean(1)
+      return v
+    },
+    "Variance": func(window []float64) float64 {
+      v, _ := stats.FloatSlice(window).Variance(1)
+      return v
+    },
+    "Sd": func(window []float64) float64 {
+      v, _ := stats.FloatSlice(window).Sd(1)
+      return v
+    },
+    "Median": func(window []float64) float64 {
+      v, _ := stats.FloatSlice(window).Median(1)
+      return v
+    },
+    "Sn": func(window []float64) float64 {
+      v, _ := sorted(window).SnFromSortedData(1)
+      return v
+    },
+    "Qn": func(window []float64) float64 {
+      v, _ := sorted(window).QnFromSortedData(1)
+      return v
+    },
+    "Mad0": func(window []float64) float64 {
+      v, _ := stats.FloatSlice(window).Mad0(1)
+      return v
+    },
+    "Mad": func(window []float64) float64 {
+      v, _ := stats.FloatSlice(window).Mad(1)
+      return v
+    },
+    "QQR": func(window []float64) float64 {
+      s := sorted(window)
+      lower, _ := s.QuantileFromSortedData(1, 0.25)
+      upper, _ := s.QuantileFromSortedData(1, 0.75)
+      return upper - lower
+    },
+  }
+
+  // test 1
+  for _, w := range []Window{Symmetric(2), Symmetric(4), {H: 5, J: 1},
+    {H: 0, J: 3}} {
+    for _, end := range []End{PadZero, PadValue, Truncate} {
+      for name, f := range builtin {
+        got, err := f(data, w, end)
+        if err != nil {
+          t.Fatal(err)
+        }
+        expected, err := Apply(data, w, end, custom[name])
+        if err != nil {
+          t.Fatal(err)
+        }
+        for i := range got {
+          if math.Abs(got[i]-expected[i]) > 1e-10*math.Abs(expected[i]) {
+            t.Error("Test 1: Moving", name, "with window", w, "and end",
+              end, "differs at", i, got[i], expected[i])
+            break
+          }
+        }
+      }
+    }
+  }
+
+  // test 2
+  var lengths []int
+  _, err := Apply(data[:4], Symmetric(2), Truncate,
+    func(window []float64) float64 {
+      lengths = append(lengths, len(window))
+      return 0
+    })
+  if err != nil {
+    t.Fatal(err)
+  }
+  expected := []int{3, 4, 4, 3}
+  for i := range expected {
+    if len(lengths) != len(expected) || lengths[i] != expected[i] {
+      t.Error("Test 2: Unexpected truncated window lengths", lengths)
+      break
+    }
+  }
+}
+
+// test set 3
+func Test_movstat_3(t *testing.T) {
+
+  data := stats.FloatSlice{1.0, 2.0, 3.0}
+
+  // test 1
+  if _, err := Mean(stats.FloatSlice{}, Symmetric(1), PadZero); !errors.Is(
+    err, gsl.EBADLEN) {
+    t.Error("Test 1: Mean accepted empty data.")
+  }
+  if _, err := Apply(nil, Symmetric(1), PadZero,
+    func([]float64) float64 { return 0 }); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 1: Apply accepted empty data.")
+  }
+
+  // test 2
+  if _, err := Median(data, Window{H: -1}, PadZero); !errors.Is(err,
+    gsl.EINVAL) {
+    t.Error("Test 2: Median accepted invalid window.")
+  }
+  if _, err := QQR(data, 0.75, Symmetric(1), PadZero); !errors.Is(err,
+    gsl.EINVAL) {
+    t.Error("Test 2: QQR accepted invalid quantile.")
+  }
+}
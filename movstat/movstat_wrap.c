@@ -0,0 +1,103 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * movstat_wrap provides wrappers around the gsl moving window statistics
+ * which operate on plain arrays of length n instead of gsl vectors
+ */
+
+#include <gsl/gsl_movstat.h>
+#include <gsl/gsl_vector.h>
+
+#include "movstat_wrap.h"
+#include "_cgo_export.h"
+
+
+/* MOVSTAT_WRAP defines the wrapper declared by MOVSTAT_PROTO */
+#define MOVSTAT_WRAP(name)                                            \
+  MOVSTAT_PROTO(name) {                                               \
+    gsl_vector_const_view x_view = gsl_vector_const_view_array(x, n); \
+    gsl_vector_view y_view = gsl_vector_view_array(y, n);             \
+    return gsl_movstat_##name(end, &x_view.vector, &y_view.vector, w); \
+  }
+
+MOVSTAT_WRAP(mean)
+MOVSTAT_WRAP(variance)
+MOVSTAT_WRAP(sd)
+MOVSTAT_WRAP(median)
+MOVSTAT_WRAP(min)
+MOVSTAT_WRAP(max)
+MOVSTAT_WRAP(sum)
+MOVSTAT_WRAP(Sn)
+MOVSTAT_WRAP(Qn)
+
+
+int movstat_minmax(gsl_movstat_end_t end, const double *x, double *y_min,
+  double *y_max, size_t n, gsl_movstat_workspace *w) {
+
+  gsl_vector_const_view x_view = gsl_vector_const_view_array(x, n);
+  gsl_vector_view min_view = gsl_vector_view_array(y_min, n);
+  gsl_vector_view max_view = gsl_vector_view_array(y_max, n);
+
+  return gsl_movstat_minmax(end, &x_view.vector, &min_view.vector,
+    &max_view.vector, w);
+}
+
+
+int movstat_mad0(gsl_movstat_end_t end, const double *x, double *xmedian,
+  double *xmad, size_t n, gsl_movstat_workspace *w) {
+
+  gsl_vector_const_view x_view = gsl_vector_const_view_array(x, n);
+  gsl_vector_view median_view = gsl_vector_view_array(xmedian, n);
+  gsl_vector_view mad_view = gsl_vector_view_array(xmad, n);
+
+  return gsl_movstat_mad0(end, &x_view.vector, &median_view.vector,
+    &mad_view.vector, w);
+}
+
+
+int movstat_mad(gsl_movstat_end_t end, const double *x, double *xmedian,
+  double *xmad, size_t n, gsl_movstat_workspace *w) {
+
+  gsl_vector_const_view x_view = gsl_vector_const_view_array(x, n);
+  gsl_vector_view median_view = gsl_vector_view_array(xmedian, n);
+  gsl_vector_view mad_view = gsl_vector_view_array(xmad, n);
+
+  return gsl_movstat_mad(end, &x_view.vector, &median_view.vector,
+    &mad_view.vector, w);
+}
+
+
+int movstat_qqr(gsl_movstat_end_t end, const double *x, double q,
+  double *xqqr, size_t n, gsl_movstat_workspace *w) {
+
+  gsl_vector_const_view x_view = gsl_vector_const_view_array(x, n);
+  gsl_vector_view qqr_view = gsl_vector_view_array(xqqr, n);
+
+  return gsl_movstat_qqr(end, &x_view.vector, q, &qqr_view.vector, w);
+}
+
+
+/* movstat_go_function evaluates the Go accumulator registered under the
+ * handle passed as params for the samples x of a single window */
+static double movstat_go_function(const size_t n, double x[],
+  void *params) {
+  return goMovstatApply(n, x, (uintptr_t)params);
+}
+
+
+/* movstat_apply applies the Go accumulator registered under handle to
+ * all windows of x */
+int movstat_apply(gsl_movstat_end_t end, uintptr_t handle, const double *x,
+  double *y, size_t n, gsl_movstat_workspace *w) {
+
+  gsl_movstat_function F;
+  F.function = &movstat_go_function;
+  F.params = (void *)handle;
+
+  gsl_vector_const_view x_view = gsl_vector_const_view_array(x, n);
+  gsl_vector_view y_view = gsl_vector_view_array(y, n);
+
+  return gsl_movstat_apply(end, &F, &x_view.vector, &y_view.vector, w);
+}
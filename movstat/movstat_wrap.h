@@ -0,0 +1,60 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * movstat_wrap provides wrappers around the gsl moving window statistics
+ * which operate on plain arrays of length n instead of gsl vectors
+ */
+
+
+#ifndef MOVSTAT_WRAP_H
+#define MOVSTAT_WRAP_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+#include <gsl/gsl_movstat.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+/* MOVSTAT_PROTO declares the wrapper for gsl_movstat_<name> which maps
+ * the input x to the output y */
+#define MOVSTAT_PROTO(name)                                           \
+  int movstat_##name(gsl_movstat_end_t end, const double *x, double *y, \
+    size_t n, gsl_movstat_workspace *w)
+
+MOVSTAT_PROTO(mean);
+MOVSTAT_PROTO(variance);
+MOVSTAT_PROTO(sd);
+MOVSTAT_PROTO(median);
+MOVSTAT_PROTO(min);
+MOVSTAT_PROTO(max);
+MOVSTAT_PROTO(sum);
+MOVSTAT_PROTO(Sn);
+MOVSTAT_PROTO(Qn);
+
+int movstat_minmax(gsl_movstat_end_t end, const double *x, double *y_min,
+  double *y_max, size_t n, gsl_movstat_workspace *w);
+
+int movstat_mad0(gsl_movstat_end_t end, const double *x, double *xmedian,
+  double *xmad, size_t n, gsl_movstat_workspace *w);
+
+int movstat_mad(gsl_movstat_end_t end, const double *x, double *xmedian,
+  double *xmad, size_t n, gsl_movstat_workspace *w);
+
+int movstat_qqr(gsl_movstat_end_t end, const double *x, double q,
+  double *xqqr, size_t n, gsl_movstat_workspace *w);
+
+int movstat_apply(gsl_movstat_end_t end, uintptr_t handle, const double *x,
+  double *y, size_t n, gsl_movstat_workspace *w);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
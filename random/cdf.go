@@ -4,6 +4,13 @@
 //
 // cdf wraps gsl cumulative random distribution functions
 //
+// Most of the cdfs are evaluated in closed form and gsl reports invalid
+// arguments by returning NaN or Inf. The discrete cdfs check the domain of
+// their parameters and the inverse cdfs of the gamma, chi-squared, Erlang,
+// F, t and beta distributions are computed iteratively; since these can
+// raise gsl errors they come with an additional E variant, e.g. BetaPinvE,
+// which returns the *gsl.Error.
+//
 // XXX: All of the slice functions could be made more efficient
 // by calling the underlying GSL routines directly rather than
 // calling the go wrappers.
@@ -11,8 +18,26 @@ package random
 
 // #cgo pkg-config: gsl
 // #include <gsl/gsl_cdf.h>
+// #include <gsl/gsl_rng.h>
+// #include "random_wrap.h"
 import "C"
 
+import (
+	"math"
+
+	"github.com/haskelladdict/gsl"
+)
+
+// cdfE calls the gsl cdf function f and returns its value together with
+// the *gsl.Error raised by gsl during the call, if any
+func cdfE(f func() C.double) (float64, error) {
+	var value C.double
+	err := gsl.Protect(func() {
+		value = f()
+	})
+	return float64(value), err
+}
+
 // GaussianP returns the cumulative distribution function P(x) for
 // the lower tail of a Gaussian.
 func GaussianP(x float64, sigma float64) float64 {
@@ -187,12 +212,28 @@ func GammaPinv(P, a, b float64) float64 {
 	return float64(C.gsl_cdf_gamma_Pinv(C.double(P), C.double(a), C.double(b)))
 }
 
+// GammaPinvE is like GammaPinv but also returns the *gsl.Error raised by gsl if
+// the parameters are invalid or the inversion fails to converge.
+func GammaPinvE(P, a, b float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_gamma_Pinv(C.double(P), C.double(a), C.double(b))
+	})
+}
+
 // GammaQinv returns the cumulative distribution function Qinv(x) for
 // the upper tail of a gamma distribution.
 func GammaQinv(Q, a, b float64) float64 {
 	return float64(C.gsl_cdf_gamma_Qinv(C.double(Q), C.double(a), C.double(b)))
 }
 
+// GammaQinvE is like GammaQinv but also returns the *gsl.Error raised by gsl if
+// the parameters are invalid or the inversion fails to converge.
+func GammaQinvE(Q, a, b float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_gamma_Qinv(C.double(Q), C.double(a), C.double(b))
+	})
+}
+
 // FlatP returns the cumulative distribution function P(x) for
 // the lower tail of a flat distribution.
 func FlatP(x, a, b float64) float64 {
@@ -240,3 +281,613 @@ func LognormalPinv(P, zeta, sigma float64) float64 {
 func LognormalQinv(Q, zeta, sigma float64) float64 {
 	return float64(C.gsl_cdf_lognormal_Qinv(C.double(Q), C.double(zeta), C.double(sigma)))
 }
+
+// ChisqP returns the cumulative distribution function P(x) for
+// the lower tail of a chi-squared distribution.
+func ChisqP(x, nu float64) float64 {
+	return float64(C.gsl_cdf_chisq_P(C.double(x), C.double(nu)))
+}
+
+// ChisqQ returns the cumulative distribution function Q(x) for
+// the upper tail of a chi-squared distribution.
+func ChisqQ(x, nu float64) float64 {
+	return float64(C.gsl_cdf_chisq_Q(C.double(x), C.double(nu)))
+}
+
+// ChisqPinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of a chi-squared distribution.
+func ChisqPinv(P, nu float64) float64 {
+	return float64(C.gsl_cdf_chisq_Pinv(C.double(P), C.double(nu)))
+}
+
+// ChisqPinvE is like ChisqPinv but also returns the *gsl.Error raised by gsl if
+// the parameters are invalid or the inversion fails to converge.
+func ChisqPinvE(P, nu float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_chisq_Pinv(C.double(P), C.double(nu))
+	})
+}
+
+// ChisqQinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of a chi-squared distribution.
+func ChisqQinv(Q, nu float64) float64 {
+	return float64(C.gsl_cdf_chisq_Qinv(C.double(Q), C.double(nu)))
+}
+
+// ChisqQinvE is like ChisqQinv but also returns the *gsl.Error raised by gsl if
+// the parameters are invalid or the inversion fails to converge.
+func ChisqQinvE(Q, nu float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_chisq_Qinv(C.double(Q), C.double(nu))
+	})
+}
+
+// FdistP returns the cumulative distribution function P(x) for
+// the lower tail of an F-distribution.
+func FdistP(x, nu1, nu2 float64) float64 {
+	return float64(C.gsl_cdf_fdist_P(C.double(x), C.double(nu1), C.double(nu2)))
+}
+
+// FdistQ returns the cumulative distribution function Q(x) for
+// the upper tail of an F-distribution.
+func FdistQ(x, nu1, nu2 float64) float64 {
+	return float64(C.gsl_cdf_fdist_Q(C.double(x), C.double(nu1), C.double(nu2)))
+}
+
+// FdistPinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of an F-distribution.
+func FdistPinv(P, nu1, nu2 float64) float64 {
+	return float64(C.gsl_cdf_fdist_Pinv(C.double(P), C.double(nu1), C.double(nu2)))
+}
+
+// FdistPinvE is like FdistPinv but also returns the *gsl.Error raised by gsl if
+// the parameters are invalid or the inversion fails to converge.
+func FdistPinvE(P, nu1, nu2 float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_fdist_Pinv(C.double(P), C.double(nu1), C.double(nu2))
+	})
+}
+
+// FdistQinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of an F-distribution.
+func FdistQinv(Q, nu1, nu2 float64) float64 {
+	return float64(C.gsl_cdf_fdist_Qinv(C.double(Q), C.double(nu1), C.double(nu2)))
+}
+
+// FdistQinvE is like FdistQinv but also returns the *gsl.Error raised by gsl if
+// the parameters are invalid or the inversion fails to converge.
+func FdistQinvE(Q, nu1, nu2 float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_fdist_Qinv(C.double(Q), C.double(nu1), C.double(nu2))
+	})
+}
+
+// TdistP returns the cumulative distribution function P(x) for
+// the lower tail of a t-distribution.
+func TdistP(x, nu float64) float64 {
+	return float64(C.gsl_cdf_tdist_P(C.double(x), C.double(nu)))
+}
+
+// TdistQ returns the cumulative distribution function Q(x) for
+// the upper tail of a t-distribution.
+func TdistQ(x, nu float64) float64 {
+	return float64(C.gsl_cdf_tdist_Q(C.double(x), C.double(nu)))
+}
+
+// TdistPinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of a t-distribution.
+func TdistPinv(P, nu float64) float64 {
+	return float64(C.gsl_cdf_tdist_Pinv(C.double(P), C.double(nu)))
+}
+
+// TdistPinvE is like TdistPinv but also returns the *gsl.Error raised by gsl if
+// the parameters are invalid or the inversion fails to converge.
+func TdistPinvE(P, nu float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_tdist_Pinv(C.double(P), C.double(nu))
+	})
+}
+
+// TdistQinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of a t-distribution.
+func TdistQinv(Q, nu float64) float64 {
+	return float64(C.gsl_cdf_tdist_Qinv(C.double(Q), C.double(nu)))
+}
+
+// TdistQinvE is like TdistQinv but also returns the *gsl.Error raised by gsl if
+// the parameters are invalid or the inversion fails to converge.
+func TdistQinvE(Q, nu float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_tdist_Qinv(C.double(Q), C.double(nu))
+	})
+}
+
+// BetaP returns the cumulative distribution function P(x) for
+// the lower tail of a beta distribution.
+func BetaP(x, a, b float64) float64 {
+	return float64(C.gsl_cdf_beta_P(C.double(x), C.double(a), C.double(b)))
+}
+
+// BetaQ returns the cumulative distribution function Q(x) for
+// the upper tail of a beta distribution.
+func BetaQ(x, a, b float64) float64 {
+	return float64(C.gsl_cdf_beta_Q(C.double(x), C.double(a), C.double(b)))
+}
+
+// BetaPinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of a beta distribution.
+func BetaPinv(P, a, b float64) float64 {
+	return float64(C.gsl_cdf_beta_Pinv(C.double(P), C.double(a), C.double(b)))
+}
+
+// BetaPinvE is like BetaPinv but also returns the *gsl.Error raised by gsl if
+// the parameters are invalid or the inversion fails to converge.
+func BetaPinvE(P, a, b float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_beta_Pinv(C.double(P), C.double(a), C.double(b))
+	})
+}
+
+// BetaQinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of a beta distribution.
+func BetaQinv(Q, a, b float64) float64 {
+	return float64(C.gsl_cdf_beta_Qinv(C.double(Q), C.double(a), C.double(b)))
+}
+
+// BetaQinvE is like BetaQinv but also returns the *gsl.Error raised by gsl if
+// the parameters are invalid or the inversion fails to converge.
+func BetaQinvE(Q, a, b float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_beta_Qinv(C.double(Q), C.double(a), C.double(b))
+	})
+}
+
+// LogisticP returns the cumulative distribution function P(x) for
+// the lower tail of a logistic distribution.
+func LogisticP(x, a float64) float64 {
+	return float64(C.gsl_cdf_logistic_P(C.double(x), C.double(a)))
+}
+
+// LogisticQ returns the cumulative distribution function Q(x) for
+// the upper tail of a logistic distribution.
+func LogisticQ(x, a float64) float64 {
+	return float64(C.gsl_cdf_logistic_Q(C.double(x), C.double(a)))
+}
+
+// LogisticPinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of a logistic distribution.
+func LogisticPinv(P, a float64) float64 {
+	return float64(C.gsl_cdf_logistic_Pinv(C.double(P), C.double(a)))
+}
+
+// LogisticQinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of a logistic distribution.
+func LogisticQinv(Q, a float64) float64 {
+	return float64(C.gsl_cdf_logistic_Qinv(C.double(Q), C.double(a)))
+}
+
+// ParetoP returns the cumulative distribution function P(x) for
+// the lower tail of a Pareto distribution.
+func ParetoP(x, a, b float64) float64 {
+	return float64(C.gsl_cdf_pareto_P(C.double(x), C.double(a), C.double(b)))
+}
+
+// ParetoQ returns the cumulative distribution function Q(x) for
+// the upper tail of a Pareto distribution.
+func ParetoQ(x, a, b float64) float64 {
+	return float64(C.gsl_cdf_pareto_Q(C.double(x), C.double(a), C.double(b)))
+}
+
+// ParetoPinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of a Pareto distribution.
+func ParetoPinv(P, a, b float64) float64 {
+	return float64(C.gsl_cdf_pareto_Pinv(C.double(P), C.double(a), C.double(b)))
+}
+
+// ParetoQinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of a Pareto distribution.
+func ParetoQinv(Q, a, b float64) float64 {
+	return float64(C.gsl_cdf_pareto_Qinv(C.double(Q), C.double(a), C.double(b)))
+}
+
+// WeibullP returns the cumulative distribution function P(x) for
+// the lower tail of a Weibull distribution.
+func WeibullP(x, a, b float64) float64 {
+	return float64(C.gsl_cdf_weibull_P(C.double(x), C.double(a), C.double(b)))
+}
+
+// WeibullQ returns the cumulative distribution function Q(x) for
+// the upper tail of a Weibull distribution.
+func WeibullQ(x, a, b float64) float64 {
+	return float64(C.gsl_cdf_weibull_Q(C.double(x), C.double(a), C.double(b)))
+}
+
+// WeibullPinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of a Weibull distribution.
+func WeibullPinv(P, a, b float64) float64 {
+	return float64(C.gsl_cdf_weibull_Pinv(C.double(P), C.double(a), C.double(b)))
+}
+
+// WeibullQinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of a Weibull distribution.
+func WeibullQinv(Q, a, b float64) float64 {
+	return float64(C.gsl_cdf_weibull_Qinv(C.double(Q), C.double(a), C.double(b)))
+}
+
+// Gumbel1P returns the cumulative distribution function P(x) for
+// the lower tail of a Type-1 Gumbel distribution.
+func Gumbel1P(x, a, b float64) float64 {
+	return float64(C.gsl_cdf_gumbel1_P(C.double(x), C.double(a), C.double(b)))
+}
+
+// Gumbel1Q returns the cumulative distribution function Q(x) for
+// the upper tail of a Type-1 Gumbel distribution.
+func Gumbel1Q(x, a, b float64) float64 {
+	return float64(C.gsl_cdf_gumbel1_Q(C.double(x), C.double(a), C.double(b)))
+}
+
+// Gumbel1Pinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of a Type-1 Gumbel distribution.
+func Gumbel1Pinv(P, a, b float64) float64 {
+	return float64(C.gsl_cdf_gumbel1_Pinv(C.double(P), C.double(a), C.double(b)))
+}
+
+// Gumbel1Qinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of a Type-1 Gumbel distribution.
+func Gumbel1Qinv(Q, a, b float64) float64 {
+	return float64(C.gsl_cdf_gumbel1_Qinv(C.double(Q), C.double(a), C.double(b)))
+}
+
+// Gumbel2P returns the cumulative distribution function P(x) for
+// the lower tail of a Type-2 Gumbel distribution.
+func Gumbel2P(x, a, b float64) float64 {
+	return float64(C.gsl_cdf_gumbel2_P(C.double(x), C.double(a), C.double(b)))
+}
+
+// Gumbel2Q returns the cumulative distribution function Q(x) for
+// the upper tail of a Type-2 Gumbel distribution.
+func Gumbel2Q(x, a, b float64) float64 {
+	return float64(C.gsl_cdf_gumbel2_Q(C.double(x), C.double(a), C.double(b)))
+}
+
+// Gumbel2Pinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of a Type-2 Gumbel distribution.
+func Gumbel2Pinv(P, a, b float64) float64 {
+	return float64(C.gsl_cdf_gumbel2_Pinv(C.double(P), C.double(a), C.double(b)))
+}
+
+// Gumbel2Qinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of a Type-2 Gumbel distribution.
+func Gumbel2Qinv(Q, a, b float64) float64 {
+	return float64(C.gsl_cdf_gumbel2_Qinv(C.double(Q), C.double(a), C.double(b)))
+}
+
+// PoissonP returns the cumulative distribution function P(k) for
+// the lower tail of a Poisson distribution with mean mu.
+func PoissonP(k uint32, mu float64) float64 {
+	return float64(C.gsl_cdf_poisson_P(C.uint(k), C.double(mu)))
+}
+
+// PoissonPE is like PoissonP but also returns the *gsl.Error raised by gsl if
+// the parameters are outside the domain of the distribution.
+func PoissonPE(k uint32, mu float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_poisson_P(C.uint(k), C.double(mu))
+	})
+}
+
+// PoissonQ returns the cumulative distribution function Q(k) for
+// the upper tail of a Poisson distribution with mean mu.
+func PoissonQ(k uint32, mu float64) float64 {
+	return float64(C.gsl_cdf_poisson_Q(C.uint(k), C.double(mu)))
+}
+
+// PoissonQE is like PoissonQ but also returns the *gsl.Error raised by gsl if
+// the parameters are outside the domain of the distribution.
+func PoissonQE(k uint32, mu float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_poisson_Q(C.uint(k), C.double(mu))
+	})
+}
+
+// BinomialP returns the cumulative distribution function P(k) for
+// the lower tail of a binomial distribution.
+func BinomialP(k uint32, p float64, nTrials uint32) float64 {
+	return float64(C.gsl_cdf_binomial_P(C.uint(k), C.double(p), C.uint(nTrials)))
+}
+
+// BinomialPE is like BinomialP but also returns the *gsl.Error raised by gsl if
+// the parameters are outside the domain of the distribution.
+func BinomialPE(k uint32, p float64, nTrials uint32) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_binomial_P(C.uint(k), C.double(p), C.uint(nTrials))
+	})
+}
+
+// BinomialQ returns the cumulative distribution function Q(k) for
+// the upper tail of a binomial distribution.
+func BinomialQ(k uint32, p float64, nTrials uint32) float64 {
+	return float64(C.gsl_cdf_binomial_Q(C.uint(k), C.double(p), C.uint(nTrials)))
+}
+
+// BinomialQE is like BinomialQ but also returns the *gsl.Error raised by gsl if
+// the parameters are outside the domain of the distribution.
+func BinomialQE(k uint32, p float64, nTrials uint32) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_binomial_Q(C.uint(k), C.double(p), C.uint(nTrials))
+	})
+}
+
+// NegativeBinomialP returns the cumulative distribution function P(k) for
+// the lower tail of a negative binomial distribution.
+func NegativeBinomialP(k uint32, p, nSuccess float64) float64 {
+	return float64(C.gsl_cdf_negative_binomial_P(C.uint(k), C.double(p),
+		C.double(nSuccess)))
+}
+
+// NegativeBinomialPE is like NegativeBinomialP but also returns the *gsl.Error
+// raised by gsl if the parameters are outside the domain of the distribution.
+func NegativeBinomialPE(k uint32, p, nSuccess float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_negative_binomial_P(C.uint(k), C.double(p),
+			C.double(nSuccess))
+	})
+}
+
+// NegativeBinomialQ returns the cumulative distribution function Q(k) for
+// the upper tail of a negative binomial distribution.
+func NegativeBinomialQ(k uint32, p, nSuccess float64) float64 {
+	return float64(C.gsl_cdf_negative_binomial_Q(C.uint(k), C.double(p),
+		C.double(nSuccess)))
+}
+
+// NegativeBinomialQE is like NegativeBinomialQ but also returns the *gsl.Error
+// raised by gsl if the parameters are outside the domain of the distribution.
+func NegativeBinomialQE(k uint32, p, nSuccess float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_negative_binomial_Q(C.uint(k), C.double(p),
+			C.double(nSuccess))
+	})
+}
+
+// PascalP returns the cumulative distribution function P(k) for
+// the lower tail of a Pascal distribution.
+func PascalP(k uint32, p float64, nSuccess uint32) float64 {
+	return float64(C.gsl_cdf_pascal_P(C.uint(k), C.double(p), C.uint(nSuccess)))
+}
+
+// PascalPE is like PascalP but also returns the *gsl.Error raised by gsl if the
+// parameters are outside the domain of the distribution.
+func PascalPE(k uint32, p float64, nSuccess uint32) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_pascal_P(C.uint(k), C.double(p), C.uint(nSuccess))
+	})
+}
+
+// PascalQ returns the cumulative distribution function Q(k) for
+// the upper tail of a Pascal distribution.
+func PascalQ(k uint32, p float64, nSuccess uint32) float64 {
+	return float64(C.gsl_cdf_pascal_Q(C.uint(k), C.double(p), C.uint(nSuccess)))
+}
+
+// PascalQE is like PascalQ but also returns the *gsl.Error raised by gsl if the
+// parameters are outside the domain of the distribution.
+func PascalQE(k uint32, p float64, nSuccess uint32) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_pascal_Q(C.uint(k), C.double(p), C.uint(nSuccess))
+	})
+}
+
+// GeometricP returns the cumulative distribution function P(k) for
+// the lower tail of a geometric distribution.
+func GeometricP(k uint32, p float64) float64 {
+	return float64(C.gsl_cdf_geometric_P(C.uint(k), C.double(p)))
+}
+
+// GeometricPE is like GeometricP but also returns the *gsl.Error raised by gsl
+// if the parameters are outside the domain of the distribution.
+func GeometricPE(k uint32, p float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_geometric_P(C.uint(k), C.double(p))
+	})
+}
+
+// GeometricQ returns the cumulative distribution function Q(k) for
+// the upper tail of a geometric distribution.
+func GeometricQ(k uint32, p float64) float64 {
+	return float64(C.gsl_cdf_geometric_Q(C.uint(k), C.double(p)))
+}
+
+// GeometricQE is like GeometricQ but also returns the *gsl.Error raised by gsl
+// if the parameters are outside the domain of the distribution.
+func GeometricQE(k uint32, p float64) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_geometric_Q(C.uint(k), C.double(p))
+	})
+}
+
+// HypergeometricP returns the cumulative distribution function P(k) for
+// the lower tail of a hypergeometric distribution.
+func HypergeometricP(k, n1, n2, t uint32) float64 {
+	return float64(C.gsl_cdf_hypergeometric_P(C.uint(k), C.uint(n1),
+		C.uint(n2), C.uint(t)))
+}
+
+// HypergeometricPE is like HypergeometricP but also returns the *gsl.Error
+// raised by gsl if the parameters are outside the domain of the distribution.
+func HypergeometricPE(k, n1, n2, t uint32) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_hypergeometric_P(C.uint(k), C.uint(n1),
+			C.uint(n2), C.uint(t))
+	})
+}
+
+// HypergeometricQ returns the cumulative distribution function Q(k) for
+// the upper tail of a hypergeometric distribution.
+func HypergeometricQ(k, n1, n2, t uint32) float64 {
+	return float64(C.gsl_cdf_hypergeometric_Q(C.uint(k), C.uint(n1),
+		C.uint(n2), C.uint(t)))
+}
+
+// HypergeometricQE is like HypergeometricQ but also returns the *gsl.Error
+// raised by gsl if the parameters are outside the domain of the distribution.
+func HypergeometricQE(k, n1, n2, t uint32) (float64, error) {
+	return cdfE(func() C.double {
+		return C.gsl_cdf_hypergeometric_Q(C.uint(k), C.uint(n1),
+			C.uint(n2), C.uint(t))
+	})
+}
+
+// ErlangP returns the cumulative distribution function P(x) for
+// the lower tail of an Erlang distribution with scale a and order n.
+// GSL has no separate Erlang cdf, the Erlang distribution is the
+// gamma distribution with shape n and scale a.
+func ErlangP(x, a, n float64) float64 {
+	return GammaP(x, n, a)
+}
+
+// ErlangQ returns the cumulative distribution function Q(x) for
+// the upper tail of an Erlang distribution with scale a and order n.
+func ErlangQ(x, a, n float64) float64 {
+	return GammaQ(x, n, a)
+}
+
+// ErlangPinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of an Erlang distribution with scale a and order n.
+func ErlangPinv(P, a, n float64) float64 {
+	return GammaPinv(P, n, a)
+}
+
+// ErlangPinvE is like ErlangPinv but also returns the *gsl.Error raised by gsl
+// if the parameters are invalid or the inversion fails to converge.
+func ErlangPinvE(P, a, n float64) (float64, error) {
+	return GammaPinvE(P, n, a)
+}
+
+// ErlangQinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of an Erlang distribution with scale a and order n.
+func ErlangQinv(Q, a, n float64) float64 {
+	return GammaQinv(Q, n, a)
+}
+
+// ErlangQinvE is like ErlangQinv but also returns the *gsl.Error raised by gsl
+// if the parameters are invalid or the inversion fails to converge.
+func ErlangQinvE(Q, a, n float64) (float64, error) {
+	return GammaQinvE(Q, n, a)
+}
+
+// LandauP returns the cumulative distribution function P(x) for
+// the lower tail of the Landau distribution. NaN is returned if the
+// integration fails, use LandauPE to find out why.
+// NOTE: GSL does not provide a Landau cdf. P(x) is computed by numerically
+// integrating LandauPdf with a requested relative tolerance of 1e-10. This
+// only bounds the integration error; LandauPdf is itself an approximation
+// of the Landau density and its error carries over to P(x).
+func LandauP(x float64) float64 {
+	P, _ := LandauPE(x)
+	return P
+}
+
+// LandauPE is like LandauP but returns the *gsl.Error raised if the
+// numerical integration fails.
+func LandauPE(x float64) (float64, error) {
+	return landauCdf(x, true)
+}
+
+// LandauQ returns the cumulative distribution function Q(x) for
+// the upper tail of the Landau distribution. NaN is returned if the
+// integration fails, use LandauQE to find out why.
+// NOTE: Computed via numerical integration, see LandauP.
+func LandauQ(x float64) float64 {
+	Q, _ := LandauQE(x)
+	return Q
+}
+
+// LandauQE is like LandauQ but returns the *gsl.Error raised if the
+// numerical integration fails.
+func LandauQE(x float64) (float64, error) {
+	return landauCdf(x, false)
+}
+
+// landauCdf integrates the Landau pdf over the lower tail up to x if lower
+// is true and over the upper tail starting at x otherwise
+func landauCdf(x float64, lower bool) (float64, error) {
+	var result C.double
+	var status C.int
+	err := gsl.Protect(func() {
+		if lower {
+			status = C.landau_cdf_P(C.double(x), &result)
+		} else {
+			status = C.landau_cdf_Q(C.double(x), &result)
+		}
+	})
+	if err == nil && status != 0 {
+		err = gsl.NewError(gsl.Errno(status), "Failed to integrate Landau pdf.")
+	}
+	if err != nil {
+		return math.NaN(), err
+	}
+	return float64(result), nil
+}
+
+// LandauPinv returns the inverse cumulative distribution function Pinv(x) for
+// the lower tail of the Landau distribution.
+// NOTE: Computed by bisection on LandauP. NaN is returned if LandauP fails
+// during the bisection.
+func LandauPinv(P float64) float64 {
+	switch {
+	case math.IsNaN(P) || P < 0 || P > 1:
+		return math.NaN()
+	case P == 0:
+		return math.Inf(-1)
+	case P == 1:
+		return math.Inf(1)
+	}
+	return bisect(LandauP, P)
+}
+
+// LandauQinv returns the inverse cumulative distribution function Qinv(x) for
+// the upper tail of the Landau distribution.
+// NOTE: Computed by bisection on LandauQ. NaN is returned if LandauQ fails
+// during the bisection.
+func LandauQinv(Q float64) float64 {
+	switch {
+	case math.IsNaN(Q) || Q < 0 || Q > 1:
+		return math.NaN()
+	case Q == 0:
+		return math.Inf(1)
+	case Q == 1:
+		return math.Inf(-1)
+	}
+	return bisect(func(x float64) float64 { return -LandauQ(x) }, -Q)
+}
+
+// bisect returns the x for which the monotonically increasing function f
+// equals y. The root is bracketed by expanding an initial interval and
+// then refined by bisection.
+func bisect(f func(float64) float64, y float64) float64 {
+	lo, hi := -1.0, 1.0
+	for f(lo) > y && !math.IsInf(lo, -1) {
+		lo *= 2
+	}
+	for f(hi) < y && !math.IsInf(hi, 1) {
+		hi *= 2
+	}
+
+	for i := 0; i < 200; i++ {
+		mid := lo + 0.5*(hi-lo)
+		if hi-lo <= 1e-12*math.Max(1, math.Abs(mid)) {
+			return mid
+		}
+		fmid := f(mid)
+		if math.IsNaN(fmid) {
+			return math.NaN()
+		}
+		if fmid < y {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo + 0.5*(hi-lo)
+}
@@ -6,8 +6,11 @@
 package random
 
 import (
+  "errors"
+  "math"
   "testing"
 
+  "github.com/haskelladdict/gsl"
   "github.com/haskelladdict/gsl/util"
 )
 
@@ -64,3 +67,152 @@ func Test_cdf_1(t *testing.T) {
     t.Error("cdf: error computing Qinv(0.5).")
   }
 }
+
+// test set 2
+func Test_cdf_2(t *testing.T) {
+
+  // discrete distributions
+  for k := uint32(0); k < 10; k++ {
+    if math.Abs(PoissonP(k, 3)+PoissonQ(k, 3)-1) > 1e-12 {
+      t.Error("cdf: poisson P(k) and Q(k) do not add up to 1.")
+    }
+
+    if math.Abs(BinomialP(k, 0.3, 10)+BinomialQ(k, 0.3, 10)-1) > 1e-12 {
+      t.Error("cdf: binomial P(k) and Q(k) do not add up to 1.")
+    }
+
+    if math.Abs(PascalP(k, 0.3, 4)-NegativeBinomialP(k, 0.3, 4)) > 1e-12 {
+      t.Error("cdf: pascal and negative binomial P(k) differ.")
+    }
+  }
+
+  if !util.FloatEqual(BinomialP(10, 0.3, 10), 1) {
+    t.Error("cdf: error computing binomial P(n).")
+  }
+
+  if !util.FloatEqual(GeometricP(3, 0.5), 0.875) {
+    t.Error("cdf: error computing geometric P(3).")
+  }
+
+  if !util.FloatEqual(GeometricQ(3, 0.5), 0.125) {
+    t.Error("cdf: error computing geometric Q(3).")
+  }
+
+  if !util.FloatEqual(HypergeometricP(5, 5, 5, 5), 1) {
+    t.Error("cdf: error computing hypergeometric P(t).")
+  }
+}
+
+// test set 3
+func Test_cdf_3(t *testing.T) {
+
+  // compare against closed form expressions
+  const tol = 1e-10
+  e := math.Exp(-1)
+  tests := []struct {
+    name      string
+    got, want float64
+  }{
+    {"chisq P(2)", ChisqP(2, 2), 1 - e},
+    {"fdist P(1)", FdistP(1, 5, 5), 0.5},
+    {"tdist P(0)", TdistP(0, 5), 0.5},
+    {"beta P(0.5)", BetaP(0.5, 2, 2), 0.5},
+    {"logistic P(0)", LogisticP(0, 2), 0.5},
+    {"pareto P(2)", ParetoP(2, 3, 1), 0.875},
+    {"weibull P(1)", WeibullP(1, 1, 2), 1 - e},
+    {"gumbel1 P(0)", Gumbel1P(0, 1, 1), e},
+    {"gumbel2 P(1)", Gumbel2P(1, 1, 1), e},
+    {"erlang P(1)", ErlangP(1, 1, 1), 1 - e},
+    {"erlang Q(1)", ErlangQ(1, 1, 1), e},
+  }
+  for _, test := range tests {
+    if math.Abs(test.got-test.want) > tol {
+      t.Error("cdf: error computing", test.name, test.got, test.want)
+    }
+  }
+
+  // check that Pinv and Qinv invert P and Q
+  for _, x := range []float64{0.5, 1, 2.5} {
+    if math.Abs(ChisqPinv(ChisqP(x, 3), 3)-x) > tol {
+      t.Error("cdf: error inverting chisq P.")
+    }
+    if math.Abs(FdistQinv(FdistQ(x, 4, 6), 4, 6)-x) > tol {
+      t.Error("cdf: error inverting fdist Q.")
+    }
+    if math.Abs(TdistPinv(TdistP(x, 4), 4)-x) > tol {
+      t.Error("cdf: error inverting tdist P.")
+    }
+    if math.Abs(WeibullQinv(WeibullQ(x, 1, 2), 1, 2)-x) > tol {
+      t.Error("cdf: error inverting weibull Q.")
+    }
+    if math.Abs(ErlangPinv(ErlangP(x, 2, 3), 2, 3)-x) > tol {
+      t.Error("cdf: error inverting erlang P.")
+    }
+  }
+
+  // landau
+  for _, x := range []float64{-2, -0.2, 1, 10} {
+    if math.Abs(LandauP(x)+LandauQ(x)-1) > 1e-8 {
+      t.Error("cdf: landau P(x) and Q(x) do not add up to 1.")
+    }
+    if math.Abs(LandauPinv(LandauP(x))-x) > 1e-6 {
+      t.Error("cdf: error inverting landau P.", x)
+    }
+    if math.Abs(LandauQinv(LandauQ(x))-x) > 1e-6 {
+      t.Error("cdf: error inverting landau Q.", x)
+    }
+    P, errP := LandauPE(x)
+    Q, errQ := LandauQE(x)
+    if errP != nil || errQ != nil || P != LandauP(x) || Q != LandauQ(x) {
+      t.Error("cdf: unexpected result of landau PE or QE.", x, errP, errQ)
+    }
+  }
+}
+
+// test set 4
+func Test_cdf_4(t *testing.T) {
+
+  // test 1: E variants agree with the plain cdfs for valid arguments
+  value, err := GammaPinvE(0.3, 2, 3)
+  if err != nil || value != GammaPinv(0.3, 2, 3) {
+    t.Error("cdf: unexpected result of gamma PinvE.", value, err)
+  }
+  value, err = ChisqQinvE(0.3, 4)
+  if err != nil || value != ChisqQinv(0.3, 4) {
+    t.Error("cdf: unexpected result of chisq QinvE.", value, err)
+  }
+  value, err = FdistPinvE(0.7, 3, 5)
+  if err != nil || value != FdistPinv(0.7, 3, 5) {
+    t.Error("cdf: unexpected result of fdist PinvE.", value, err)
+  }
+  value, err = TdistQinvE(0.2, 4)
+  if err != nil || value != TdistQinv(0.2, 4) {
+    t.Error("cdf: unexpected result of tdist QinvE.", value, err)
+  }
+  value, err = ErlangQinvE(0.4, 2, 3)
+  if err != nil || value != ErlangQinv(0.4, 2, 3) {
+    t.Error("cdf: unexpected result of erlang QinvE.", value, err)
+  }
+  value, err = BinomialPE(3, 0.4, 10)
+  if err != nil || value != BinomialP(3, 0.4, 10) {
+    t.Error("cdf: unexpected result of binomial PE.", value, err)
+  }
+  value, err = HypergeometricQE(1, 4, 5, 3)
+  if err != nil || value != HypergeometricQ(1, 4, 5, 3) {
+    t.Error("cdf: unexpected result of hypergeometric QE.", value, err)
+  }
+
+  // test 2: invalid arguments are reported as gsl.EDOM
+  if _, err := BetaPinvE(1.5, 2, 3); !errors.Is(err, gsl.EDOM) {
+    t.Error("cdf: expected EDOM from beta PinvE, got ", err)
+  }
+  if _, err := BinomialPE(3, 1.5, 10); !errors.Is(err, gsl.EDOM) {
+    t.Error("cdf: expected EDOM from binomial PE, got ", err)
+  }
+  if _, err := GeometricQE(3, -0.5); !errors.Is(err, gsl.EDOM) {
+    t.Error("cdf: expected EDOM from geometric QE, got ", err)
+  }
+  if _, err := HypergeometricPE(1, 2, 3, 10); !errors.Is(err, gsl.EDOM) {
+    t.Error("cdf: expected EDOM from hypergeometric PE, got ", err)
+  }
+}
@@ -0,0 +1,191 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// discrete wraps gsl's general discrete distributions based on Walker's
+// alias method
+package random
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_randist.h>
+// #include "fill.h"
+import "C"
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"unsafe"
+)
+
+// DiscreteTable stores the lookup table for sampling from a general
+// discrete distribution with K possible outcomes. After the one time
+// preprocessing step each sample can be drawn in O(1).
+type DiscreteTable struct {
+	table *C.gsl_ran_discrete_t
+	k     uint64
+}
+
+// DiscretePreproc builds the lookup table for the discrete distribution
+// given by weights. The weights don't need to be normalized but have to
+// be non-negative with a positive sum. The memory associated with the
+// table is released automatically once it is garbage collected; call Free
+// to release it right away.
+func DiscretePreproc(weights []float64) (*DiscreteTable, error) {
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("Cannot build discrete table from empty weights.")
+	}
+
+	sum := 0.0
+	for i, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("Invalid weight %g at position %d.", w, i)
+		}
+		sum += w
+	}
+	if sum == 0 {
+		return nil, fmt.Errorf("Weights of discrete table sum to zero.")
+	}
+
+	table := C.gsl_ran_discrete_preproc(C.size_t(len(weights)),
+		(*C.double)(&weights[0]))
+	if table == nil {
+		return nil, fmt.Errorf("Failed to build discrete table.")
+	}
+
+	d := &DiscreteTable{table, uint64(len(weights))}
+	runtime.SetFinalizer(d, (*DiscreteTable).Free)
+	return d, nil
+}
+
+// Free releases all the memory associated with the table within the C
+// part of gsl. It is safe to call Free more than once.
+func (d *DiscreteTable) Free() {
+	if d.table == nil {
+		return
+	}
+	C.gsl_ran_discrete_free(d.table)
+	d.table = nil
+	runtime.SetFinalizer(d, nil)
+}
+
+// Len returns the number of possible outcomes K of the distribution
+func (d *DiscreteTable) Len() uint64 {
+	return d.k
+}
+
+// get returns the underlying gsl table or ErrFreed if it has been freed
+func (d *DiscreteTable) get() (*C.gsl_ran_discrete_t, error) {
+	if d.table == nil {
+		return nil, ErrFreed
+	}
+	return d.table, nil
+}
+
+// lookupTable returns the underlying gsl table and panics with ErrFreed
+// if the table has already been freed
+func (d *DiscreteTable) lookupTable() *C.gsl_ran_discrete_t {
+	table, err := d.get()
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+// Sample returns a random outcome in [0, K) from the discrete
+// distribution.
+func (d *DiscreteTable) Sample(rng RngState) uint64 {
+	defer runtime.KeepAlive(rng.owner)
+	k := uint64(C.gsl_ran_discrete(rng.ptr(), d.lookupTable()))
+	runtime.KeepAlive(d)
+	return k
+}
+
+// SampleE is like Sample but returns ErrFreed instead of panicking if
+// either d or rng has been freed.
+func (d *DiscreteTable) SampleE(rng RngState) (uint64, error) {
+	table, err := d.get()
+	if err != nil {
+		return 0, err
+	}
+	defer runtime.KeepAlive(d)
+	var k C.size_t
+	err = rng.protect(func(state *C.gsl_rng) {
+		k = C.gsl_ran_discrete(state, table)
+	})
+	return uint64(k), err
+}
+
+// SampleSlice generates a slice of length n of random outcomes from the
+// discrete distribution.
+func (d *DiscreteTable) SampleSlice(rng RngState, n uint64) []uint64 {
+	data := make([]uint64, n)
+	d.Fill(rng, data)
+	return data
+}
+
+// SampleSliceE is like SampleSlice but returns ErrFreed instead of
+// panicking if either d or rng has been freed.
+func (d *DiscreteTable) SampleSliceE(rng RngState, n uint64) ([]uint64,
+	error) {
+	data := make([]uint64, n)
+	if err := d.FillE(rng, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Fill fills dst with random outcomes from the discrete distribution
+// using a single call into gsl.
+func (d *DiscreteTable) Fill(rng RngState, dst []uint64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	defer runtime.KeepAlive(d)
+	C.ran_discrete_fill(rng.ptr(), d.lookupTable(),
+		(*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+}
+
+// FillE is like Fill but returns ErrFreed instead of panicking if either
+// d or rng has been freed.
+func (d *DiscreteTable) FillE(rng RngState, dst []uint64) error {
+	table, err := d.get()
+	if err != nil {
+		return err
+	}
+	defer runtime.KeepAlive(d)
+	return rng.protect(func(state *C.gsl_rng) {
+		if len(dst) > 0 {
+			C.ran_discrete_fill(state, table,
+				(*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+		}
+	})
+}
+
+// Pdf returns the probability P[k] of observing outcome k. Outcomes
+// outside of [0, K) have probability zero.
+func (d *DiscreteTable) Pdf(k uint64) float64 {
+	table := d.lookupTable()
+	if k >= d.k {
+		return 0
+	}
+	p := float64(C.gsl_ran_discrete_pdf(C.size_t(k), table))
+	runtime.KeepAlive(d)
+	return p
+}
+
+// PdfE is like Pdf but returns ErrFreed instead of panicking if d has
+// been freed.
+func (d *DiscreteTable) PdfE(k uint64) (float64, error) {
+	table, err := d.get()
+	if err != nil {
+		return 0, err
+	}
+	if k >= d.k {
+		return 0, nil
+	}
+	p := float64(C.gsl_ran_discrete_pdf(C.size_t(k), table))
+	runtime.KeepAlive(d)
+	return p, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// discrete wraps gsl's general discrete distributions based on Walker's
+// alias method
+package random
+
+import (
+  "math"
+  "testing"
+)
+
+// test set 1
+func Test_discrete_1(t *testing.T) {
+
+  // test 1: pdf matches normalized weights
+  table, err := DiscretePreproc([]float64{1, 2, 7})
+  if err != nil {
+    t.Fatal("Test 1: Failed to build discrete table.", err)
+  }
+
+  for k, p := range []float64{0.1, 0.2, 0.7, 0} {
+    if math.Abs(table.Pdf(uint64(k))-p) > 1e-12 {
+      t.Error("Test 1: Incorrect probability for outcome", k)
+    }
+  }
+
+  // test 2: sample frequencies match the weights
+  rng_state := Rng_alloc(Ranlxd2)
+  counts := make([]float64, 3)
+  for _, k := range table.SampleSlice(rng_state, numSamples) {
+    if k >= 3 {
+      t.Fatal("Test 2: Sampled outcome out of range.")
+    }
+    counts[k]++
+  }
+  for k, c := range counts {
+    if math.Abs(c/float64(numSamples)-table.Pdf(uint64(k))) > 5e-3 {
+      t.Error("Test 2: Sampled frequency does not match weight", k)
+    }
+  }
+
+  // test 3: invalid weights are rejected
+  if _, err := DiscretePreproc([]float64{}); err == nil {
+    t.Error("Test 3: Failed to reject empty weights.")
+  }
+
+  if _, err := DiscretePreproc([]float64{1, -1}); err == nil {
+    t.Error("Test 3: Failed to reject negative weights.")
+  }
+
+  if _, err := DiscretePreproc([]float64{0, 0}); err == nil {
+    t.Error("Test 3: Failed to reject zero weights.")
+  }
+
+  // test 4: E variants agree with their panicking counterparts
+  rng_state.Set(1)
+  k := table.Sample(rng_state)
+  rng_state.Set(1)
+  if kE, err := table.SampleE(rng_state); err != nil || kE != k {
+    t.Error("Test 4: SampleE differs from Sample.", err)
+  }
+  if ks, err := table.SampleSliceE(rng_state, 10); err != nil ||
+    len(ks) != 10 {
+    t.Error("Test 4: SampleSliceE failed.", err)
+  }
+  if p, err := table.PdfE(2); err != nil || p != table.Pdf(2) {
+    t.Error("Test 4: PdfE differs from Pdf.", err)
+  }
+
+  table.Free()
+  table.Free()
+
+  // test 5: freed tables report ErrFreed
+  if _, err := table.SampleE(rng_state); err != ErrFreed {
+    t.Error("Test 5: Expected ErrFreed from SampleE, got ", err)
+  }
+  if _, err := table.SampleSliceE(rng_state, 10); err != ErrFreed {
+    t.Error("Test 5: Expected ErrFreed from SampleSliceE, got ", err)
+  }
+  if err := table.FillE(rng_state, make([]uint64, 4)); err != ErrFreed {
+    t.Error("Test 5: Expected ErrFreed from FillE, got ", err)
+  }
+  if _, err := table.PdfE(0); err != ErrFreed {
+    t.Error("Test 5: Expected ErrFreed from PdfE, got ", err)
+  }
+  func() {
+    defer func() {
+      if r := recover(); r != ErrFreed {
+        t.Error("Test 5: Expected panic with ErrFreed, got ", r)
+      }
+    }()
+    table.Sample(rng_state)
+  }()
+
+  rng_state.Free()
+}
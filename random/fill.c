@@ -0,0 +1,173 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * fill provides functions filling Go allocated buffers with n samples
+ * from a generator or distribution in a single call
+ */
+
+#include <gsl/gsl_randist.h>
+
+#include "fill.h"
+
+/* RAN_FILL<k> define the fill function declared by RAN_FILL_PROTO<k> */
+#define RAN_FILL0(name, T) \
+  RAN_FILL_PROTO0(name, T) { \
+    for (size_t i = 0; i < n; i++) { \
+      dst[i] = gsl_ran_##name(r); \
+    } \
+  }
+#define RAN_FILL1(name, T, T1) \
+  RAN_FILL_PROTO1(name, T, T1) { \
+    for (size_t i = 0; i < n; i++) { \
+      dst[i] = gsl_ran_##name(r, a); \
+    } \
+  }
+#define RAN_FILL2(name, T, T1, T2) \
+  RAN_FILL_PROTO2(name, T, T1, T2) { \
+    for (size_t i = 0; i < n; i++) { \
+      dst[i] = gsl_ran_##name(r, a, b); \
+    } \
+  }
+#define RAN_FILL3(name, T, T1, T2, T3) \
+  RAN_FILL_PROTO3(name, T, T1, T2, T3) { \
+    for (size_t i = 0; i < n; i++) { \
+      dst[i] = gsl_ran_##name(r, a, b, c); \
+    } \
+  }
+
+
+void rng_get_fill(const gsl_rng *r, uint64_t *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    dst[i] = gsl_rng_get(r);
+  }
+}
+
+
+void rng_uniform_fill(const gsl_rng *r, double *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    dst[i] = gsl_rng_uniform(r);
+  }
+}
+
+
+void rng_uniform_int_fill(const gsl_rng *r, unsigned long int limit,
+  uint64_t *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    dst[i] = gsl_rng_uniform_int(r, limit);
+  }
+}
+
+
+/* qrng_get_fill stores n points of the generator's dimension in dst */
+void qrng_get_fill(const gsl_qrng *q, double *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    gsl_qrng_get(q, dst + i*q->dimension);
+  }
+}
+
+
+/* continuous distributions */
+RAN_FILL1(gaussian, double, double)
+RAN_FILL1(gaussian_ziggurat, double, double)
+RAN_FILL1(gaussian_ratio_method, double, double)
+RAN_FILL0(ugaussian, double)
+RAN_FILL0(ugaussian_ratio_method, double)
+RAN_FILL2(gaussian_tail, double, double, double)
+RAN_FILL1(ugaussian_tail, double, double)
+RAN_FILL1(exponential, double, double)
+RAN_FILL1(laplace, double, double)
+RAN_FILL2(exppow, double, double, double)
+RAN_FILL1(cauchy, double, double)
+RAN_FILL1(rayleigh, double, double)
+RAN_FILL2(rayleigh_tail, double, double, double)
+RAN_FILL0(landau, double)
+RAN_FILL2(levy, double, double, double)
+RAN_FILL3(levy_skew, double, double, double, double)
+RAN_FILL2(gamma, double, double, double)
+RAN_FILL2(flat, double, double, double)
+RAN_FILL2(lognormal, double, double, double)
+RAN_FILL1(chisq, double, double)
+RAN_FILL2(fdist, double, double, double)
+RAN_FILL1(tdist, double, double)
+RAN_FILL2(beta, double, double, double)
+RAN_FILL1(logistic, double, double)
+RAN_FILL2(pareto, double, double, double)
+RAN_FILL2(weibull, double, double, double)
+RAN_FILL2(gumbel1, double, double, double)
+RAN_FILL2(gumbel2, double, double, double)
+RAN_FILL2(erlang, double, double, double)
+
+
+/* discrete distributions */
+RAN_FILL1(poisson, uint64_t, double)
+RAN_FILL1(bernoulli, uint64_t, double)
+RAN_FILL2(binomial, uint64_t, double, unsigned int)
+RAN_FILL2(negative_binomial, uint64_t, double, double)
+RAN_FILL2(pascal, uint64_t, double, unsigned int)
+RAN_FILL1(geometric, uint64_t, double)
+RAN_FILL3(hypergeometric, uint64_t, unsigned int, unsigned int,
+  unsigned int)
+RAN_FILL1(logarithmic, uint64_t, double)
+RAN_FILL1(discrete, uint64_t, const gsl_ran_discrete_t *)
+
+
+/* vector valued distributions */
+void ran_bivariate_gaussian_fill(const gsl_rng *r, double sigma_x,
+  double sigma_y, double rho, double *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    gsl_ran_bivariate_gaussian(r, sigma_x, sigma_y, rho, &dst[2*i],
+      &dst[2*i+1]);
+  }
+}
+
+
+void ran_dir_2d_fill(const gsl_rng *r, double *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    gsl_ran_dir_2d(r, &dst[2*i], &dst[2*i+1]);
+  }
+}
+
+
+void ran_dir_2d_trig_method_fill(const gsl_rng *r, double *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    gsl_ran_dir_2d_trig_method(r, &dst[2*i], &dst[2*i+1]);
+  }
+}
+
+
+void ran_dir_3d_fill(const gsl_rng *r, double *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    gsl_ran_dir_3d(r, &dst[3*i], &dst[3*i+1], &dst[3*i+2]);
+  }
+}
+
+
+void ran_dir_nd_fill(const gsl_rng *r, size_t dim, double *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    gsl_ran_dir_nd(r, dim, dst + i*dim);
+  }
+}
+
+
+void ran_dirichlet_fill(const gsl_rng *r, size_t K, const double *alpha,
+  double *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    gsl_ran_dirichlet(r, K, alpha, dst + i*K);
+  }
+}
+
+
+/* ran_multinomial_fill samples n multinomial count vectors of length K.
+ * gsl returns the counts as unsigned int so they are converted via the
+ * work buffer counts which needs to provide space for K values. */
+void ran_multinomial_fill(const gsl_rng *r, size_t K, unsigned int N,
+  const double *p, unsigned int *counts, uint64_t *dst, size_t n) {
+  for (size_t i = 0; i < n; i++) {
+    gsl_ran_multinomial(r, K, N, p, counts);
+    for (size_t j = 0; j < K; j++) {
+      dst[i*K + j] = counts[j];
+    }
+  }
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * fill provides functions filling Go allocated buffers with n samples
+ * from a generator or distribution in a single call
+ */
+
+
+#ifndef FILL_H
+#define FILL_H
+
+#include <stddef.h>
+#include <stdint.h>
+
+#include <gsl/gsl_qrng.h>
+#include <gsl/gsl_randist.h>
+#include <gsl/gsl_rng.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+/* RAN_FILL_PROTO<k> declare the fill function for gsl_ran_<name> which
+ * takes k parameters of types T1, ..., Tk and returns values of type T */
+#define RAN_FILL_PROTO0(name, T) \
+  void ran_##name##_fill(const gsl_rng *r, T *dst, size_t n)
+#define RAN_FILL_PROTO1(name, T, T1) \
+  void ran_##name##_fill(const gsl_rng *r, T1 a, T *dst, size_t n)
+#define RAN_FILL_PROTO2(name, T, T1, T2) \
+  void ran_##name##_fill(const gsl_rng *r, T1 a, T2 b, T *dst, size_t n)
+#define RAN_FILL_PROTO3(name, T, T1, T2, T3) \
+  void ran_##name##_fill(const gsl_rng *r, T1 a, T2 b, T3 c, T *dst, \
+    size_t n)
+
+void rng_get_fill(const gsl_rng *r, uint64_t *dst, size_t n);
+void rng_uniform_fill(const gsl_rng *r, double *dst, size_t n);
+void rng_uniform_int_fill(const gsl_rng *r, unsigned long int limit,
+  uint64_t *dst, size_t n);
+
+void qrng_get_fill(const gsl_qrng *q, double *dst, size_t n);
+
+/* continuous distributions */
+RAN_FILL_PROTO1(gaussian, double, double);
+RAN_FILL_PROTO1(gaussian_ziggurat, double, double);
+RAN_FILL_PROTO1(gaussian_ratio_method, double, double);
+RAN_FILL_PROTO0(ugaussian, double);
+RAN_FILL_PROTO0(ugaussian_ratio_method, double);
+RAN_FILL_PROTO2(gaussian_tail, double, double, double);
+RAN_FILL_PROTO1(ugaussian_tail, double, double);
+RAN_FILL_PROTO1(exponential, double, double);
+RAN_FILL_PROTO1(laplace, double, double);
+RAN_FILL_PROTO2(exppow, double, double, double);
+RAN_FILL_PROTO1(cauchy, double, double);
+RAN_FILL_PROTO1(rayleigh, double, double);
+RAN_FILL_PROTO2(rayleigh_tail, double, double, double);
+RAN_FILL_PROTO0(landau, double);
+RAN_FILL_PROTO2(levy, double, double, double);
+RAN_FILL_PROTO3(levy_skew, double, double, double, double);
+RAN_FILL_PROTO2(gamma, double, double, double);
+RAN_FILL_PROTO2(flat, double, double, double);
+RAN_FILL_PROTO2(lognormal, double, double, double);
+RAN_FILL_PROTO1(chisq, double, double);
+RAN_FILL_PROTO2(fdist, double, double, double);
+RAN_FILL_PROTO1(tdist, double, double);
+RAN_FILL_PROTO2(beta, double, double, double);
+RAN_FILL_PROTO1(logistic, double, double);
+RAN_FILL_PROTO2(pareto, double, double, double);
+RAN_FILL_PROTO2(weibull, double, double, double);
+RAN_FILL_PROTO2(gumbel1, double, double, double);
+RAN_FILL_PROTO2(gumbel2, double, double, double);
+RAN_FILL_PROTO2(erlang, double, double, double);
+
+/* discrete distributions */
+RAN_FILL_PROTO1(poisson, uint64_t, double);
+RAN_FILL_PROTO1(bernoulli, uint64_t, double);
+RAN_FILL_PROTO2(binomial, uint64_t, double, unsigned int);
+RAN_FILL_PROTO2(negative_binomial, uint64_t, double, double);
+RAN_FILL_PROTO2(pascal, uint64_t, double, unsigned int);
+RAN_FILL_PROTO1(geometric, uint64_t, double);
+RAN_FILL_PROTO3(hypergeometric, uint64_t, unsigned int, unsigned int,
+  unsigned int);
+RAN_FILL_PROTO1(logarithmic, uint64_t, double);
+RAN_FILL_PROTO1(discrete, uint64_t, const gsl_ran_discrete_t *);
+
+/* vector valued distributions; dst receives the n vectors consecutively */
+void ran_bivariate_gaussian_fill(const gsl_rng *r, double sigma_x,
+  double sigma_y, double rho, double *dst, size_t n);
+void ran_dir_2d_fill(const gsl_rng *r, double *dst, size_t n);
+void ran_dir_2d_trig_method_fill(const gsl_rng *r, double *dst, size_t n);
+void ran_dir_3d_fill(const gsl_rng *r, double *dst, size_t n);
+void ran_dir_nd_fill(const gsl_rng *r, size_t dim, double *dst, size_t n);
+void ran_dirichlet_fill(const gsl_rng *r, size_t K, const double *alpha,
+  double *dst, size_t n);
+void ran_multinomial_fill(const gsl_rng *r, size_t K, unsigned int N,
+  const double *p, unsigned int *counts, uint64_t *dst, size_t n);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,324 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// fill provides functions filling Go allocated buffers with n samples
+// from a generator or distribution in a single call
+package random
+
+import (
+  "testing"
+)
+
+// test set 1
+func Test_fill_1(t *testing.T) {
+
+  numRands := 1000
+  rng1 := Rng_alloc(Ranlxd2)
+  rng2 := Rng_alloc(Ranlxd2)
+
+  // test 1
+  rng1.Set(1)
+  rng2.Set(1)
+  uniform := make([]float64, numRands)
+  rng1.UniformFill(uniform)
+  for i := range uniform {
+    if uniform[i] != rng2.Uniform() {
+      t.Error("Test 1: UniformFill differs from Uniform at", i)
+      break
+    }
+  }
+
+  // test 2
+  ints := rng1.GetSlice(uint64(numRands))
+  for i := range ints {
+    if ints[i] != rng2.Get() {
+      t.Error("Test 2: GetSlice differs from Get at", i)
+      break
+    }
+  }
+
+  ints = rng1.UniformIntSlice(17, uint64(numRands))
+  for i := range ints {
+    if ints[i] != rng2.UniformInt(17) {
+      t.Error("Test 2: UniformIntSlice differs from UniformInt at", i)
+      break
+    }
+  }
+
+  // test 3
+  gauss := GaussianSlice(rng1, 2.0, uint64(numRands))
+  for i := range gauss {
+    if gauss[i] != Gaussian(rng2, 2.0) {
+      t.Error("Test 3: GaussianSlice differs from Gaussian at", i)
+      break
+    }
+  }
+
+  levy := make([]float64, numRands)
+  LevySkewFill(rng1, 1.0, 1.5, 0.5, levy)
+  for i := range levy {
+    if levy[i] != LevySkew(rng2, 1.0, 1.5, 0.5) {
+      t.Error("Test 3: LevySkewFill differs from LevySkew at", i)
+      break
+    }
+  }
+
+  // test 4
+  hyper := HypergeometricSlice(rng1, 5, 20, 3, uint64(numRands))
+  for i := range hyper {
+    if hyper[i] != Hypergeometric(rng2, 5, 20, 3) {
+      t.Error("Test 4: HypergeometricSlice differs from Hypergeometric at", i)
+      break
+    }
+  }
+
+  binom := make([]uint64, numRands)
+  BinomialFill(rng1, 0.3, 10, binom)
+  for i := range binom {
+    if binom[i] != Binomial(rng2, 0.3, 10) {
+      t.Error("Test 4: BinomialFill differs from Binomial at", i)
+      break
+    }
+  }
+
+  // test 5
+  dir3d := Dir3dSlice(rng1, uint64(numRands))
+  for i := range dir3d {
+    x, y, z := Dir3d(rng2)
+    if dir3d[i] != (Triple{x, y, z}) {
+      t.Error("Test 5: Dir3dSlice differs from Dir3d at", i)
+      break
+    }
+  }
+
+  bivariate := BivariateGaussianSlice(rng1, 1.0, 2.0, 0.5, uint64(numRands))
+  for i := range bivariate {
+    x, y := BivariateGaussian(rng2, 1.0, 2.0, 0.5)
+    if bivariate[i] != (Pair{x, y}) {
+      t.Error("Test 5: BivariateGaussianSlice differs from BivariateGaussian "+
+        "at", i)
+      break
+    }
+  }
+
+  // test 6
+  alpha := []float64{1.0, 2.0, 3.0}
+  dirichlet := DirichletSlice(rng1, alpha, uint64(numRands))
+  for i := range dirichlet {
+    theta := Dirichlet(rng2, alpha)
+    if len(dirichlet[i]) != len(alpha) || dirichlet[i][2] != theta[2] {
+      t.Error("Test 6: DirichletSlice differs from Dirichlet at", i)
+      break
+    }
+  }
+
+  p := []float64{0.2, 0.3, 0.5}
+  multinomial := MultinomialSlice(rng1, 10, p, uint64(numRands))
+  for i := range multinomial {
+    counts := Multinomial(rng2, 10, p)
+    if len(multinomial[i]) != len(p) || multinomial[i][1] != counts[1] {
+      t.Error("Test 6: MultinomialSlice differs from Multinomial at", i)
+      break
+    }
+  }
+
+  mu := []float64{1.0, -1.0}
+  L := []float64{1.0, 0.0, 0.5, 2.0}
+  mvg, err := MultivariateGaussianSlice(rng1, mu, L, uint64(numRands))
+  if err != nil {
+    t.Fatal("Test 6: Failed to sample multivariate Gaussian: ", err)
+  }
+  for i := range mvg {
+    x, _ := MultivariateGaussian(rng2, mu, L)
+    if mvg[i][0] != x[0] || mvg[i][1] != x[1] {
+      t.Error("Test 6: MultivariateGaussianSlice differs from "+
+        "MultivariateGaussian at", i)
+      break
+    }
+  }
+  err = MultivariateGaussianFill(rng1, mu, L, make([]float64, 3))
+  if err == nil {
+    t.Error("Test 6: Expected error for dst of invalid length.")
+  }
+
+  // test 7
+  dirNd := DirNdSlice(rng1, 4, 10)
+  dirNd[0] = append(dirNd[0], 1.0)
+  if dirNd[1][0] == 1.0 && len(dirNd[1]) == 4 {
+    t.Error("Test 7: Vectors returned by DirNdSlice overlap.")
+  }
+
+  // test 8
+  rng1.Set(1)
+  rng2.Set(1)
+  table, err := DiscretePreproc([]float64{1, 2, 7})
+  if err != nil {
+    t.Fatal("Test 8: DiscretePreproc failed:", err)
+  }
+  discrete := table.SampleSlice(rng1, uint64(numRands))
+  for i := range discrete {
+    if discrete[i] != table.Sample(rng2) {
+      t.Error("Test 8: DiscreteTable.SampleSlice differs from Sample at", i)
+      break
+    }
+  }
+  table.Free()
+
+  rng1.Free()
+  rng2.Free()
+}
+
+// test set 2
+func Test_fill_2(t *testing.T) {
+
+  // test 1
+  qrng1 := Qrng_alloc(Sobol, 3)
+  qrng2 := Qrng_alloc(Sobol, 3)
+  defer qrng1.Free()
+  defer qrng2.Free()
+  points := qrng1.GetSlice(100)
+  for i := range points {
+    point := qrng2.Get()
+    if len(points[i]) != 3 || points[i][0] != point[0] ||
+      points[i][2] != point[2] {
+      t.Error("Test 1: Qrng GetSlice differs from Get at", i)
+      break
+    }
+  }
+
+  // test 2
+  defer func() {
+    if recover() == nil {
+      t.Error("Test 2: Expected panic for dst of invalid length.")
+    }
+  }()
+  qrng1.GetFill(make([]float64, 4))
+}
+
+// benchmarks comparing sampling via one cgo call per sample with filling
+// a buffer in a single cgo call
+const benchSize = 10000
+
+func BenchmarkUniformPerSample(b *testing.B) {
+  rng_state := Rng_alloc(Ranlxd2)
+  data := make([]float64, benchSize)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    for i := range data {
+      data[i] = rng_state.Uniform()
+    }
+  }
+  b.StopTimer()
+  rng_state.Free()
+}
+
+func BenchmarkUniformFill(b *testing.B) {
+  rng_state := Rng_alloc(Ranlxd2)
+  data := make([]float64, benchSize)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    rng_state.UniformFill(data)
+  }
+  b.StopTimer()
+  rng_state.Free()
+}
+
+func BenchmarkGaussianPerSample(b *testing.B) {
+  rng_state := Rng_alloc(Mt19937)
+  data := make([]float64, benchSize)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    for i := range data {
+      data[i] = GaussianZiggurat(rng_state, 1.0)
+    }
+  }
+  b.StopTimer()
+  rng_state.Free()
+}
+
+func BenchmarkGaussianFill(b *testing.B) {
+  rng_state := Rng_alloc(Mt19937)
+  data := make([]float64, benchSize)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    GaussianZigguratFill(rng_state, 1.0, data)
+  }
+  b.StopTimer()
+  rng_state.Free()
+}
+
+func BenchmarkPoissonPerSample(b *testing.B) {
+  rng_state := Rng_alloc(Mt19937)
+  data := make([]uint64, benchSize)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    for i := range data {
+      data[i] = Poisson(rng_state, 3.0)
+    }
+  }
+  b.StopTimer()
+  rng_state.Free()
+}
+
+func BenchmarkPoissonFill(b *testing.B) {
+  rng_state := Rng_alloc(Mt19937)
+  data := make([]uint64, benchSize)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    PoissonFill(rng_state, 3.0, data)
+  }
+  b.StopTimer()
+  rng_state.Free()
+}
+
+func BenchmarkDiscretePerSample(b *testing.B) {
+  rng_state := Rng_alloc(Mt19937)
+  table, _ := DiscretePreproc([]float64{1, 2, 7})
+  data := make([]uint64, benchSize)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    for i := range data {
+      data[i] = table.Sample(rng_state)
+    }
+  }
+  b.StopTimer()
+  table.Free()
+  rng_state.Free()
+}
+
+func BenchmarkDiscreteFill(b *testing.B) {
+  rng_state := Rng_alloc(Mt19937)
+  table, _ := DiscretePreproc([]float64{1, 2, 7})
+  data := make([]uint64, benchSize)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    table.Fill(rng_state, data)
+  }
+  b.StopTimer()
+  table.Free()
+  rng_state.Free()
+}
+
+func BenchmarkQrngPerSample(b *testing.B) {
+  qrng_state := Qrng_alloc(Sobol, 2)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    for i := 0; i < benchSize; i++ {
+      qrng_state.Get()
+    }
+  }
+  b.StopTimer()
+  qrng_state.Free()
+}
+
+func BenchmarkQrngFill(b *testing.B) {
+  qrng_state := Qrng_alloc(Sobol, 2)
+  data := make([]float64, 2*benchSize)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    qrng_state.GetFill(data)
+  }
+  b.StopTimer()
+  qrng_state.Free()
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * gorng provides the C side of gsl rng types implemented in Go
+ */
+
+#include <gsl/gsl_rng.h>
+#include <stdlib.h>
+
+#include "gorng.h"
+#include "_cgo_export.h"
+
+
+/* The set function of a gsl_rng_type does not receive the type itself.
+ * Each Go implemented type therefore needs its own set function to tell
+ * the Go side which kind of generator to create for a fresh state. */
+#define GORNG_SETTER(slot)                                             \
+  static void gorng_set_##slot(void *state, unsigned long int seed) {  \
+    goRngSet(slot, state, seed);                                       \
+  }
+
+GORNG_SETTER(0)
+GORNG_SETTER(1)
+GORNG_SETTER(2)
+GORNG_SETTER(3)
+GORNG_SETTER(4)
+GORNG_SETTER(5)
+GORNG_SETTER(6)
+GORNG_SETTER(7)
+GORNG_SETTER(8)
+GORNG_SETTER(9)
+GORNG_SETTER(10)
+GORNG_SETTER(11)
+GORNG_SETTER(12)
+GORNG_SETTER(13)
+GORNG_SETTER(14)
+GORNG_SETTER(15)
+
+static void (*gorng_setters[GORNG_SLOTS])(void *, unsigned long int) = {
+  gorng_set_0,
+  gorng_set_1,
+  gorng_set_2,
+  gorng_set_3,
+  gorng_set_4,
+  gorng_set_5,
+  gorng_set_6,
+  gorng_set_7,
+  gorng_set_8,
+  gorng_set_9,
+  gorng_set_10,
+  gorng_set_11,
+  gorng_set_12,
+  gorng_set_13,
+  gorng_set_14,
+  gorng_set_15
+};
+
+
+/* get and get_double look up the Go generator via the state address
+ * and are therefore shared between all slots */
+static unsigned long int gorng_get(void *state) {
+  return goRngGet(state);
+}
+
+
+static double gorng_get_double(void *state) {
+  return goRngGetDouble(state);
+}
+
+
+/* gorng_type_alloc creates a new gsl_rng_type for the Go generator in the
+ * given slot. The type is never freed since gsl expects types to be
+ * static. Returns NULL if the slot is invalid or allocation fails. */
+gsl_rng_type *gorng_type_alloc(int slot, const char *name,
+  unsigned long int min, unsigned long int max) {
+
+  if (slot < 0 || slot >= GORNG_SLOTS) {
+    return NULL;
+  }
+
+  gsl_rng_type *t = malloc(sizeof(gsl_rng_type));
+  if (t == NULL) {
+    return NULL;
+  }
+
+  t->name = name;
+  t->max = max;
+  t->min = min;
+  t->size = sizeof(gorng_state_t);
+  t->set = gorng_setters[slot];
+  t->get = &gorng_get;
+  t->get_double = &gorng_get_double;
+
+  return t;
+}
@@ -0,0 +1,197 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// gorng allows random number generators implemented in Go to be used as
+// gsl rng types. Once registered via NewRngType a Go generator can be
+// allocated with Rng_alloc and used with all functions in this package,
+// e.g., Gaussian or any of the ...Slice functions.
+//
+// NOTE: Every number drawn from a Go generator involves a call from C
+// back into Go which is considerably slower than using one of the
+// builtin gsl generators.
+package random
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_rng.h>
+// #include "gorng.h"
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// Generator is the interface implemented by random number generators
+// written in Go which can be registered as gsl rng types.
+type Generator interface {
+	// Seed initializes the generator with seed.
+	Seed(seed uint64)
+
+	// Uint64 returns a random integer uniformly distributed in
+	// [Min(), Max()].
+	Uint64() uint64
+
+	// Float64 returns a random float uniformly distributed in [0, 1).
+	Float64() float64
+
+	// Min and Max return the smallest and largest value returned by
+	// Uint64. Both must not change during the lifetime of the generator.
+	Min() uint64
+	Max() uint64
+}
+
+// Cloner is an optional interface for Generators. Generators implementing
+// Cloner support Clone and Memcpy of RngStates, i.e., the copy continues
+// with the same stream as the original but independently of it. Copies
+// of RngStates whose Generator does not implement Cloner share a single
+// Generator and therefore a single stream.
+type Cloner interface {
+	Clone() Generator
+}
+
+// goRngType describes a registered Go generator type
+type goRngType struct {
+	name         string
+	newGenerator func() Generator
+	rngType      RngType
+}
+
+// goRngs keeps track of all registered Go generator types and of the
+// Generator backing each allocated gsl state. Generators are keyed by the
+// address of their gsl state.
+var goRngs = struct {
+	sync.Mutex
+	types      []*goRngType
+	generators map[uintptr]Generator
+}{
+	generators: make(map[uintptr]Generator),
+}
+
+// NewRngType registers a new rng type called name implemented in Go.
+// newGenerator is called to create a separate Generator for each
+// RngState allocated with the returned type. The number of Go rng
+// types which can be registered is limited to 16.
+func NewRngType(name string, newGenerator func() Generator) (RngType, error) {
+	if _, ok := rngTypeByName(name); ok {
+		return RngType{}, fmt.Errorf("Rng type %s already exists.", name)
+	}
+
+	proto := newGenerator()
+	if proto == nil {
+		return RngType{}, fmt.Errorf("Failed to create %s generator.", name)
+	}
+	min, max := proto.Min(), proto.Max()
+	if max <= min {
+		return RngType{}, fmt.Errorf("Invalid range [%d, %d] of %s generator.",
+			min, max, name)
+	}
+
+	goRngs.Lock()
+	defer goRngs.Unlock()
+
+	for _, t := range goRngs.types {
+		if t.name == name {
+			return RngType{}, fmt.Errorf("Rng type %s already exists.", name)
+		}
+	}
+	slot := len(goRngs.types)
+	if slot >= C.GORNG_SLOTS {
+		return RngType{}, fmt.Errorf("Cannot register more than %d Go rng types.",
+			C.GORNG_SLOTS)
+	}
+
+	// the name and type are never freed since gsl rng types are static
+	cType := C.gorng_type_alloc(C.int(slot), C.CString(name), C.ulong(min),
+		C.ulong(max))
+	if cType == nil {
+		return RngType{}, fmt.Errorf("Failed to allocate rng type %s.", name)
+	}
+	t := &goRngType{name, newGenerator, RngType{cType}}
+	goRngs.types = append(goRngs.types, t)
+	return t.rngType, nil
+}
+
+// isGoRng returns true if the gsl generator state is implemented in Go
+func isGoRng(state *C.gsl_rng) bool {
+	if state == nil {
+		return false
+	}
+
+	goRngs.Lock()
+	defer goRngs.Unlock()
+	for _, t := range goRngs.types {
+		if t.rngType.rng == state._type {
+			return true
+		}
+	}
+	return false
+}
+
+// generator returns the Generator backing the gsl state. If the state was
+// copied from another state via gsl_rng_clone or gsl_rng_memcpy the copy
+// is given its own clone of the original Generator first.
+// NOTE: goRngs needs to be locked by the caller.
+func generator(state unsafe.Pointer) Generator {
+	s := (*C.gorng_state_t)(state)
+	key := uintptr(state)
+	if s.owner != state {
+		g := goRngs.generators[uintptr(s.owner)]
+		if c, ok := g.(Cloner); ok {
+			g = c.Clone()
+		}
+		goRngs.generators[key] = g
+		s.owner = state
+	}
+	return goRngs.generators[key]
+}
+
+// syncGoRng makes sure a gsl state of a Go generator which was just
+// copied into by gsl_rng_clone or gsl_rng_memcpy gets its own Generator
+// before the original state advances any further.
+func syncGoRng(state unsafe.Pointer) {
+	goRngs.Lock()
+	defer goRngs.Unlock()
+	generator(state)
+}
+
+// releaseGoRng forgets the Generator backing the gsl state. It needs to
+// be called before the state is freed.
+func releaseGoRng(state unsafe.Pointer) {
+	goRngs.Lock()
+	defer goRngs.Unlock()
+	delete(goRngs.generators, uintptr(state))
+}
+
+//export goRngSet
+func goRngSet(slot C.int, state unsafe.Pointer, seed C.ulong) {
+	goRngs.Lock()
+	s := (*C.gorng_state_t)(state)
+	key := uintptr(state)
+	g, ok := goRngs.generators[key]
+	if !ok || s.owner != state {
+		g = goRngs.types[slot].newGenerator()
+		goRngs.generators[key] = g
+		s.owner = state
+	}
+	goRngs.Unlock()
+
+	g.Seed(uint64(seed))
+}
+
+//export goRngGet
+func goRngGet(state unsafe.Pointer) C.ulong {
+	goRngs.Lock()
+	g := generator(state)
+	goRngs.Unlock()
+	return C.ulong(g.Uint64())
+}
+
+//export goRngGetDouble
+func goRngGetDouble(state unsafe.Pointer) C.double {
+	goRngs.Lock()
+	g := generator(state)
+	goRngs.Unlock()
+	return C.double(g.Float64())
+}
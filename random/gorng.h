@@ -0,0 +1,37 @@
+/*
+ * Copyright 2014 Markus Dittrich. All rights reserved.
+ * Use of this source code is governed by a BSD-style
+ * license that can be found in the LICENSE file.
+ *
+ * gorng provides the C side of gsl rng types implemented in Go
+ */
+
+
+#ifndef GORNG_H
+#define GORNG_H
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+
+/* maximum number of rng types which can be implemented in Go */
+#define GORNG_SLOTS 16
+
+/* gorng_state_t is the gsl state of a Go implemented generator. It
+ * only records the address of the state which created the associated Go
+ * generator. If the address differs from the state's own address the
+ * state was copied via gsl_rng_clone or gsl_rng_memcpy. */
+typedef struct {
+  void *owner;
+} gorng_state_t;
+
+gsl_rng_type *gorng_type_alloc(int slot, const char *name,
+  unsigned long int min, unsigned long int max);
+
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif
@@ -0,0 +1,123 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// gorng allows random number generators implemented in Go to be used as
+// gsl rng types.
+package random
+
+import (
+  "math"
+  "sync"
+  "testing"
+
+  "github.com/haskelladdict/gsl/stats"
+)
+
+// splitMix64 is a simple Go generator used for testing
+type splitMix64 struct {
+  x uint64
+}
+
+func (s *splitMix64) Seed(seed uint64) { s.x = seed }
+
+func (s *splitMix64) Uint64() uint64 {
+  s.x += 0x9e3779b97f4a7c15
+  z := s.x
+  z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+  z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+  return z ^ (z >> 31)
+}
+
+func (s *splitMix64) Float64() float64 {
+  return float64(s.Uint64()>>11) / (1 << 53)
+}
+
+func (s *splitMix64) Min() uint64 { return 0 }
+
+func (s *splitMix64) Max() uint64 { return math.MaxUint64 }
+
+func (s *splitMix64) Clone() Generator {
+  c := *s
+  return &c
+}
+
+var (
+  splitMixOnce sync.Once
+  splitMixType RngType
+  splitMixErr  error
+)
+
+// splitMix64Type registers the test generator exactly once
+func splitMix64Type() (RngType, error) {
+  splitMixOnce.Do(func() {
+    splitMixType, splitMixErr = NewRngType("splitmix64",
+      func() Generator { return &splitMix64{} })
+  })
+  return splitMixType, splitMixErr
+}
+
+// test set 1
+func Test_gorng_1(t *testing.T) {
+
+  rng_type, err := splitMix64Type()
+  if err != nil {
+    t.Fatal("Failed to register Go rng type.", err)
+  }
+
+  // test 1: the gsl rng reproduces the Go generator
+  rng_state := Rng_alloc(rng_type)
+  if rng_state.Name() != "splitmix64" || rng_state.Max() != math.MaxUint64 {
+    t.Error("Test 1: Go rng has wrong name or range.")
+  }
+
+  rng_state.Set(17)
+  ref := &splitMix64{}
+  ref.Seed(17)
+  for i := 0; i < 100; i++ {
+    if rng_state.Get() != ref.Uint64() {
+      t.Fatal("Test 1: Go rng does not match Go generator.")
+    }
+  }
+
+  // test 2: distributions work with Go rngs
+  gaus := stats.FloatSlice(GaussianSlice(rng_state, 1, numSamples))
+  if math.Abs(mean(t, gaus)) > 1e-2 || math.Abs(sd(t, gaus)-1) > 1e-2 {
+    t.Error("Test 2: Gaussian from Go rng has wrong moments.")
+  }
+
+  // test 3: clones continue the stream independently
+  rng_state_1 := rng_state.Clone()
+  for i := 0; i < 10; i++ {
+    if rng_state.Get() != rng_state_1.Get() {
+      t.Fatal("Test 3: Failed to clone Go rng.")
+    }
+  }
+
+  // test 4: each allocated state has its own generator
+  rng_state_2 := Rng_alloc(rng_type)
+  rng_state_2.Set(17)
+  rng_state.Set(17)
+  rng_state.GetSlice(5)
+  ref.Seed(17)
+  for i := 0; i < 10; i++ {
+    if rng_state_2.Get() != ref.Uint64() {
+      t.Fatal("Test 4: Go rngs share state.")
+    }
+  }
+
+  // test 5: Go rngs can't be serialized
+  if _, err := rng_state.MarshalBinary(); err == nil {
+    t.Error("Test 5: Failed to reject serialization of Go rng.")
+  }
+
+  // test 6: names have to be unique
+  if _, err := NewRngType("splitmix64",
+    func() Generator { return &splitMix64{} }); err == nil {
+    t.Error("Test 6: Failed to reject duplicate rng type name.")
+  }
+
+  rng_state.Free()
+  rng_state_1.Free()
+  rng_state_2.Free()
+}
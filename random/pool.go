@@ -0,0 +1,176 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// pool provides concurrency safe access to random number generators and
+// pools of independent generators for parallel simulations
+package random
+
+import (
+	"fmt"
+	"sync"
+)
+
+// LockedRng wraps an RngState and serializes all access to it via a
+// mutex so that a single generator can be shared between goroutines.
+// The order in which goroutines draw numbers, and hence the numbers each
+// of them gets, depends on the scheduling. Use an RngPool instead if
+// results need to be reproducible.
+type LockedRng struct {
+	mu  sync.Mutex
+	rng RngState
+}
+
+// NewLockedRng returns a LockedRng guarding rng. The generator is not
+// copied, so rng must not be used directly anymore afterwards.
+func NewLockedRng(rng RngState) *LockedRng {
+	return &LockedRng{rng: rng}
+}
+
+// Set seeds the generator
+func (l *LockedRng) Set(seed uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rng.Set(seed)
+}
+
+// Get returns a random integer from the generator. See RngState.Get.
+func (l *LockedRng) Get() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rng.Get()
+}
+
+// Uniform returns a double precision floating point number uniformly
+// distributed in the range [0,1). See RngState.Uniform.
+func (l *LockedRng) Uniform() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rng.Uniform()
+}
+
+// UniformPos returns a positive double precision floating point number
+// uniformly distributed in the range (0,1). See RngState.UniformPos.
+func (l *LockedRng) UniformPos() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rng.UniformPos()
+}
+
+// UniformInt returns a random integer from 0 to limit - 1 inclusive. See
+// RngState.UniformInt.
+func (l *LockedRng) UniformInt(limit uint64) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rng.UniformInt(limit)
+}
+
+// Do calls f with the guarded generator while holding the lock. This
+// allows any of the random distributions to be sampled safely, e.g.,
+//
+//	l.Do(func(rng RngState) { x = Gaussian(rng, 1.0) })
+//
+// f must not retain rng after it returns.
+func (l *LockedRng) Do(f func(rng RngState)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f(l.rng)
+}
+
+// Free releases the guarded generator
+func (l *LockedRng) Free() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rng.Free()
+}
+
+// RngPool holds a fixed number of independent generators (streams) of
+// the same type which are meant to be used by one goroutine each.
+//
+// The streams are seeded deterministically from a single master seed:
+// a splitmix64 sequence started at the master seed is reduced to 31 bit
+// seeds and seeds which are zero or already in use are skipped, so every
+// stream gets a distinct seed that is accepted by all gsl generators
+// (several of them only use the lower 31 or 32 bits of the seed).
+// Stream i therefore always produces the same sequence for a given
+// master seed, number of streams and rng type, no matter how goroutines
+// are scheduled.
+//
+// Distinct seeds do not strictly guarantee that the streams never
+// overlap. For generators with very long periods and good seeding
+// algorithms like Ranlxd1, Ranlxd2, Ranlxs2 or Mt19937 the probability
+// of overlap between any of the streams is negligible and these should be
+// preferred for parallel simulations. Generators with short periods or
+// simple seeding (e.g. the LCG based ones) should not be used.
+type RngPool struct {
+	streams []RngState
+}
+
+// poolSeeds is a splitmix64 sequence used to derive the stream seeds
+type poolSeeds struct {
+	x uint64
+}
+
+// next returns the next 31 bit value of the sequence
+func (s *poolSeeds) next() uint64 {
+	s.x += 0x9e3779b97f4a7c15
+	z := s.x
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return (z ^ (z >> 31)) >> 33
+}
+
+// NewRngPool allocates n generators of type rngType seeded from the
+// master seed as described for RngPool
+func NewRngPool(rngType RngType, seed uint64, n int) (*RngPool, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("Number of streams has to be positive.")
+	}
+
+	seeds := poolSeeds{seed}
+	used := make(map[uint64]bool, n)
+	streams := make([]RngState, n)
+	for i := range streams {
+		s := seeds.next()
+		for s == 0 || used[s] {
+			s = seeds.next()
+		}
+		used[s] = true
+
+		streams[i] = Rng_alloc(rngType)
+		streams[i].Set(s)
+	}
+	return &RngPool{streams}, nil
+}
+
+// Len returns the number of streams in the pool
+func (p *RngPool) Len() int {
+	return len(p.streams)
+}
+
+// Stream returns the generator of stream i. Each stream must only be
+// used by a single goroutine at a time.
+func (p *RngPool) Stream(i int) RngState {
+	return p.streams[i]
+}
+
+// Parallel runs f once for each stream in its own goroutine, passing it
+// the stream index and generator, and waits for all of them to finish.
+func (p *RngPool) Parallel(f func(i int, rng RngState)) {
+	var wg sync.WaitGroup
+	for i, rng := range p.streams {
+		wg.Add(1)
+		go func(i int, rng RngState) {
+			defer wg.Done()
+			f(i, rng)
+		}(i, rng)
+	}
+	wg.Wait()
+}
+
+// Free releases all generators in the pool
+func (p *RngPool) Free() {
+	for _, rng := range p.streams {
+		rng.Free()
+	}
+}
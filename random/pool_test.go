@@ -0,0 +1,114 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// pool provides concurrency safe access to random number generators and
+// pools of independent generators for parallel simulations
+package random
+
+import (
+  "sync"
+  "testing"
+)
+
+// test set 1
+func Test_pool_1(t *testing.T) {
+
+  // test 1
+  numStreams := 8
+  numRands := uint64(100)
+  pool1, err := NewRngPool(Ranlxd2, 42, numStreams)
+  if err != nil {
+    t.Fatal("Test 1: Failed to allocate rng pool: ", err)
+  }
+  pool2, err := NewRngPool(Ranlxd2, 42, numStreams)
+  if err != nil {
+    t.Fatal("Test 1: Failed to allocate rng pool: ", err)
+  }
+  if pool1.Len() != numStreams {
+    t.Error("Test 1: Unexpected number of streams ", pool1.Len())
+  }
+
+  // test 2
+  results := make([][]uint64, numStreams)
+  pool1.Parallel(func(i int, rng RngState) {
+    results[i] = rng.GetSlice(numRands)
+  })
+  for i := 0; i < numStreams; i++ {
+    nums := pool2.Stream(i).GetSlice(numRands)
+    for j := range nums {
+      if nums[j] != results[i][j] {
+        t.Error("Test 2: Stream", i, "is not reproducible.")
+        break
+      }
+    }
+  }
+
+  // test 3
+  for i := 0; i < numStreams; i++ {
+    for j := i + 1; j < numStreams; j++ {
+      if results[i][0] == results[j][0] && results[i][1] == results[j][1] {
+        t.Error("Test 3: Streams", i, "and", j, "are not independent.")
+      }
+    }
+  }
+
+  // test 4
+  pool3, err := NewRngPool(Ranlxd2, 43, numStreams)
+  if err != nil {
+    t.Fatal("Test 4: Failed to allocate rng pool: ", err)
+  }
+  if pool3.Stream(0).Get() == results[0][0] {
+    t.Error("Test 4: Different master seeds produced the same stream.")
+  }
+
+  // test 5
+  if _, err := NewRngPool(Ranlxd2, 42, 0); err == nil {
+    t.Error("Test 5: Expected error for empty rng pool.")
+  }
+
+  pool1.Free()
+  pool2.Free()
+  pool3.Free()
+}
+
+// test set 2
+func Test_pool_2(t *testing.T) {
+
+  // test 1
+  rng_state := Rng_alloc(Ranlxd2)
+  locked := NewLockedRng(rng_state)
+  locked.Set(1)
+
+  numWorkers := 8
+  numRands := 1000
+  var wg sync.WaitGroup
+  for i := 0; i < numWorkers; i++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+      for j := 0; j < numRands; j++ {
+        if u := locked.Uniform(); u < 0 || u >= 1 {
+          t.Error("Test 1: Generated uniform random number out of range.")
+        }
+        locked.Do(func(rng RngState) {
+          rng.Uniform()
+        })
+      }
+    }()
+  }
+  wg.Wait()
+
+  // test 2
+  reference := Rng_alloc(Ranlxd2)
+  reference.Set(1)
+  for i := 0; i < 2*numWorkers*numRands; i++ {
+    reference.Uniform()
+  }
+  if locked.Uniform() != reference.Uniform() {
+    t.Error("Test 2: Locked rng did not advance by the expected count.")
+  }
+
+  locked.Free()
+  reference.Free()
+}
@@ -7,10 +7,26 @@ package random
 
 // #cgo pkg-config: gsl
 // #include <gsl/gsl_qrng.h>
+// #include "fill.h"
 import "C"
 
-// QrngState stores the quasi random number generator state
+import (
+  "fmt"
+  "runtime"
+
+  "github.com/haskelladdict/gsl"
+)
+
+// QrngState is a handle to a quasi random number generator. Like
+// RngState it only refers to an internal owner of the gsl generator so
+// all copies of a QrngState share the same generator. The generator is
+// released once it becomes unreachable or explicitly via Free or Close.
 type QrngState struct {
+  owner *qrngOwner
+}
+
+// qrngOwner owns the gsl generator shared by all copies of a QrngState
+type qrngOwner struct {
   state *C.gsl_qrng
   dim   uint
 }
@@ -33,35 +49,152 @@ var (
   ReverseHalton  = QrngType{C.gsl_qrng_reversehalton}
 )
 
+// maxQrngDim holds the largest dimension supported by each qrng type.
+// gsl_qrng_alloc ignores the status of the type specific initialization
+// and returns a generator producing garbage for unsupported dimensions,
+// so they have to be rejected before calling gsl.
+var maxQrngDim = map[QrngType]uint{
+  Niederreiter_2: 12,
+  Sobol:          40,
+  Halton:         1229,
+  ReverseHalton:  1229,
+}
+
 // RNG initialization
 
+// newQrngState wraps the gsl generator state into a QrngState whose
+// generator is freed automatically once it becomes unreachable
+func newQrngState(state *C.gsl_qrng, dim uint) QrngState {
+  owner := &qrngOwner{state, dim}
+  runtime.SetFinalizer(owner, (*qrngOwner).free)
+  return QrngState{owner}
+}
+
+// free releases the gsl generator. It is safe to call free more than once.
+func (o *qrngOwner) free() {
+  if o.state == nil {
+    return
+  }
+  C.gsl_qrng_free(o.state)
+  o.state = nil // to make sure we don't use after freeing
+}
+
+// get returns the underlying gsl generator or ErrFreed if it has been
+// freed or was never allocated
+func (s QrngState) get() (*C.gsl_qrng, error) {
+  if s.owner == nil || s.owner.state == nil {
+    return nil, ErrFreed
+  }
+  return s.owner.state, nil
+}
+
+// ptr returns the underlying gsl generator and panics with ErrFreed if it
+// has been freed
+func (s QrngState) ptr() *C.gsl_qrng {
+  state, err := s.get()
+  if err != nil {
+    panic(err)
+  }
+  return state
+}
+
+// protect calls f with the underlying gsl generator and returns the
+// *gsl.Error raised by gsl during the call, if any. f is not called and
+// ErrFreed is returned if s has been freed.
+func (s QrngState) protect(f func(state *C.gsl_qrng)) error {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return err
+  }
+  return gsl.Protect(func() {
+    f(state)
+  })
+}
+
+// checkQrngDim returns a *gsl.Error with code gsl.EINVAL if qrngType does
+// not support points of dimension dim
+func checkQrngDim(qrngType QrngType, dim uint) error {
+  if max, ok := maxQrngDim[qrngType]; dim == 0 || (ok && dim > max) {
+    return gsl.NewError(gsl.EINVAL, "Invalid dimension %d for qrng %s.",
+      dim, qrngType.Name())
+  }
+  return nil
+}
+
 // Qrng_Alloc creates a new quasirandom number generator of the
 // requested type and dimension and returns it as a QrngState
-// object.
-// XXX: using SetFinalizer to release the generator doesn't work
-// properly since the go runtime destroys the object prematurely.
+// object. The memory associated with the generator within gsl is
+// released automatically once the QrngState (and all its copies) are
+// garbage collected. Use Free or Close to release it right away.
+// The supported dimensions range from 1 to 12 for Niederreiter_2, to 40
+// for Sobol and to 1229 for Halton and ReverseHalton. Qrng_alloc panics
+// with the *gsl.Error returned by Qrng_allocE if dim is outside this range
+// or the generator can not be allocated.
 func Qrng_alloc(qrngType QrngType, dim uint) QrngState {
-  state := QrngState{C.gsl_qrng_alloc(qrngType.qrng, C.uint(dim)), dim}
+  s, err := Qrng_allocE(qrngType, dim)
+  if err != nil {
+    panic(err)
+  }
+  return s
+}
 
-  // make sure we get rid of any memory associated with the
-  // rng within gsl
-  //runtime.SetFinalizer(&state,
-  //  func(qrng *QrngState) {C.gsl_qrng_free(qrng.state)})
-  return state
+// Qrng_allocE is like Qrng_alloc but returns a *gsl.Error with code
+// gsl.EINVAL if qrngType does not support dimension dim and the
+// *gsl.Error raised by gsl if the generator can not be allocated.
+func Qrng_allocE(qrngType QrngType, dim uint) (QrngState, error) {
+  if err := checkQrngDim(qrngType, dim); err != nil {
+    return QrngState{}, err
+  }
+  var state *C.gsl_qrng
+  err := gsl.Protect(func() {
+    state = C.gsl_qrng_alloc(qrngType.qrng, C.uint(dim))
+  })
+  if err != nil {
+    return QrngState{}, err
+  }
+  if state == nil {
+    return QrngState{}, gsl.NewError(gsl.ENOMEM, "Failed to allocate qrng.")
+  }
+  return newQrngState(state, dim), nil
 }
 
 // Free releases all the memory associated with the generator
-// within the C part of gsl
-func (s *QrngState) Free() {
-  C.gsl_qrng_free(s.state)
-  s.state = nil // to make sure we don't use after freeing
+// within the C part of gsl. Free affects all copies of s and it is
+// safe to call Free more than once.
+func (s QrngState) Free() {
+  if s.owner == nil {
+    return
+  }
+  s.owner.free()
+  runtime.SetFinalizer(s.owner, nil)
+}
+
+// Close releases the generator like Free but returns ErrFreed if the
+// generator has already been freed. It implements the io.Closer
+// interface.
+func (s QrngState) Close() error {
+  if _, err := s.get(); err != nil {
+    return err
+  }
+  s.Free()
+  return nil
 }
 
 // Init reinitializes the generator q to its starting point. Note
 // that quasirandom sequences do not use a seed and always produce the
 // same set of values.
-func (s *QrngState) Init() {
-  C.gsl_qrng_init(s.state)
+func (s QrngState) Init() {
+  defer runtime.KeepAlive(s.owner)
+  C.gsl_qrng_init(s.ptr())
+}
+
+// InitE is like Init but returns ErrFreed instead of panicking if s has
+// been freed.
+func (s QrngState) InitE() error {
+  return s.protect(func(state *C.gsl_qrng) {
+    C.gsl_qrng_init(state)
+  })
 }
 
 // RNG sampling functions
@@ -72,58 +205,217 @@ func (s *QrngState) Init() {
 // 0 < p_i < 1 for each p_i .
 // XXX: The gsl manual does not say what the return value of
 // gsl_qrng_get signifies so we ignore it for now.
-func (s *QrngState) Get() QrngPoint {
-  point := make(QrngPoint, s.dim)
-  C.gsl_qrng_get(s.state, (*C.double)(&point[0]))
+func (s QrngState) Get() QrngPoint {
+  defer runtime.KeepAlive(s.owner)
+  state := s.ptr()
+  point := make(QrngPoint, s.owner.dim)
+  C.gsl_qrng_get(state, (*C.double)(&point[0]))
   return point
 }
 
+// GetE is like Get but returns ErrFreed instead of panicking if s has been
+// freed.
+func (s QrngState) GetE() (QrngPoint, error) {
+  var point QrngPoint
+  err := s.protect(func(state *C.gsl_qrng) {
+    point = make(QrngPoint, s.owner.dim)
+    C.gsl_qrng_get(state, (*C.double)(&point[0]))
+  })
+  if err != nil {
+    return nil, err
+  }
+  return point, nil
+}
+
 // GetSlice is a convenience function and returns a slice of length
 // n of QrngPoints
-func (s *QrngState) GetSlice(n uint64) []QrngPoint {
+func (s QrngState) GetSlice(n uint64) []QrngPoint {
+  dim := uint64(s.Dim())
+  buf := make([]float64, dim*n)
+  s.GetFill(buf)
+
   slice := make([]QrngPoint, n)
   for i := uint64(0); i < n; i++ {
-    slice[i] = s.Get()
+    slice[i] = QrngPoint(buf[i*dim : (i+1)*dim : (i+1)*dim])
   }
   return slice
 }
 
+// GetSliceE is like GetSlice but returns ErrFreed instead of panicking if
+// s has been freed.
+func (s QrngState) GetSliceE(n uint64) ([]QrngPoint, error) {
+  if _, err := s.get(); err != nil {
+    return nil, err
+  }
+  return s.GetSlice(n), nil
+}
+
+// GetFill fills dst with consecutive points from the sequence generator
+// using a single call into gsl. The length of dst has to be a multiple of
+// the dimension of the generator.
+func (s QrngState) GetFill(dst []float64) {
+  defer runtime.KeepAlive(s.owner)
+  state := s.ptr()
+  dim := int(s.owner.dim)
+  if dim == 0 || len(dst)%dim != 0 {
+    panic(fmt.Sprintf("Length %d of dst is not a multiple of dimension %d.",
+      len(dst), dim))
+  }
+  if len(dst) == 0 {
+    return
+  }
+  C.qrng_get_fill(state, (*C.double)(&dst[0]), C.size_t(len(dst)/dim))
+}
+
+// GetFillE is like GetFill but returns ErrFreed if s has been freed and a
+// *gsl.Error with code gsl.EBADLEN if the length of dst is not a multiple
+// of the dimension of the generator instead of panicking.
+func (s QrngState) GetFillE(dst []float64) error {
+  if _, err := s.get(); err != nil {
+    return err
+  }
+  if dim := s.owner.dim; dim == 0 || len(dst)%int(dim) != 0 {
+    return gsl.NewError(gsl.EBADLEN, "Length %d of dst is not a multiple "+
+      "of dimension %d.", len(dst), dim)
+  }
+  s.GetFill(dst)
+  return nil
+}
+
 // RNG auxiliary functions
 
 // Name returns the name of the quasirandom number generator
-func (s *QrngState) Name() string {
-  return C.GoString(C.gsl_qrng_name(s.state))
+func (s QrngState) Name() string {
+  defer runtime.KeepAlive(s.owner)
+  return C.GoString(C.gsl_qrng_name(s.ptr()))
+}
+
+// NameE is like Name but returns ErrFreed instead of panicking if s has
+// been freed.
+func (s QrngState) NameE() (string, error) {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return "", err
+  }
+  return C.GoString(C.gsl_qrng_name(state)), nil
+}
+
+// Name returns the name of the quasirandom number generator type
+func (t *QrngType) Name() string {
+  return C.GoString(t.qrng.name)
 }
 
 // String provides a printable string representation for
 // an QrngState
-func (s *QrngState) String() string {
+func (s QrngState) String() string {
+  if _, err := s.get(); err != nil {
+    return "<freed>"
+  }
   return s.Name()
 }
 
+// Dim returns the dimension of the points produced by the generator
+func (s QrngState) Dim() uint {
+  s.ptr()
+  return s.owner.dim
+}
+
+// DimE is like Dim but returns ErrFreed instead of panicking if s has been
+// freed.
+func (s QrngState) DimE() (uint, error) {
+  if _, err := s.get(); err != nil {
+    return 0, err
+  }
+  return s.owner.dim, nil
+}
+
 // Size returns the size of the generator
-func (s *QrngState) Size() uint64 {
-  return uint64(C.gsl_qrng_size(s.state))
+func (s QrngState) Size() uint64 {
+  defer runtime.KeepAlive(s.owner)
+  return uint64(C.gsl_qrng_size(s.ptr()))
 }
 
-// State returns a pointer to the underlying qrng state from gsl
-func (s *QrngState) State() StatePointer {
-  return StatePointer(C.gsl_qrng_state(s.state))
+// SizeE is like Size but returns ErrFreed instead of panicking if s has
+// been freed.
+func (s QrngState) SizeE() (uint64, error) {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return 0, err
+  }
+  return uint64(C.gsl_qrng_size(state)), nil
+}
+
+// State returns a pointer to the underlying qrng state from gsl.
+// The pointer is only valid as long as s has not been freed.
+func (s QrngState) State() StatePointer {
+  defer runtime.KeepAlive(s.owner)
+  return StatePointer(C.gsl_qrng_state(s.ptr()))
+}
+
+// StateE is like State but returns ErrFreed instead of panicking if s has
+// been freed.
+func (s QrngState) StateE() (StatePointer, error) {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return nil, err
+  }
+  return StatePointer(C.gsl_qrng_state(state)), nil
 }
 
 // Copying, cloning, writing and reading rng state
 
 // Memcpy copies the quasi random number generator src into the
 // pre-existing generator dest, making dest into an exact copy of src.
-// The two generators must be of the same type.
+// The two generators must be of the same type. Returns ErrFreed if
+// either generator has been freed and a *gsl.Error with code gsl.EINVAL
+// if the types or dimensions differ.
 // XXX: currently this ignores the return type of gsl_rng_memcpy since
 // I don't know what it does (the manual is quiet on that)
-func (s *QrngState) Memcpy(dest *QrngState) {
-  C.gsl_qrng_memcpy(dest.state, s.state)
+func (s QrngState) Memcpy(dest QrngState) error {
+  defer runtime.KeepAlive(s.owner)
+  defer runtime.KeepAlive(dest.owner)
+
+  src, err := s.get()
+  if err != nil {
+    return err
+  }
+  dst, err := dest.get()
+  if err != nil {
+    return err
+  }
+  // gsl does not check the dimensions and would overrun dest if the
+  // state of s is larger
+  if s.owner.dim != dest.owner.dim {
+    return gsl.NewError(gsl.EINVAL, "Cannot copy qrng of dimension %d "+
+      "into qrng of dimension %d.", s.owner.dim, dest.owner.dim)
+  }
+  return gsl.Protect(func() {
+    C.gsl_qrng_memcpy(dst, src)
+  })
 }
 
 // Clone returns a newly created generator which is an exact copy
 // of the generator r.
-func (s *QrngState) Clone() QrngState {
-  return QrngState{C.gsl_qrng_clone(s.state), s.dim}
+func (s QrngState) Clone() QrngState {
+  defer runtime.KeepAlive(s.owner)
+  return newQrngState(C.gsl_qrng_clone(s.ptr()), s.owner.dim)
+}
+
+// CloneE is like Clone but returns ErrFreed instead of panicking if s has
+// been freed and the *gsl.Error raised if the clone can not be allocated.
+func (s QrngState) CloneE() (QrngState, error) {
+  var clone *C.gsl_qrng
+  err := s.protect(func(state *C.gsl_qrng) {
+    clone = C.gsl_qrng_clone(state)
+  })
+  if err != nil {
+    return QrngState{}, err
+  }
+  if clone == nil {
+    return QrngState{}, gsl.NewError(gsl.ENOMEM, "Failed to clone qrng.")
+  }
+  return newQrngState(clone, s.owner.dim), nil
 }
@@ -6,9 +6,11 @@
 package random
 
 import (
+  "errors"
   "fmt"
   "testing"
 
+  "github.com/haskelladdict/gsl"
   "github.com/haskelladdict/gsl/stats"
 )
 
@@ -48,8 +50,8 @@ func test_helper(t *testing.T, rng_type QrngType) {
     points1_slice[i] = float64(points1[i][0])
     points2_slice[i] = float64(points1[i][1])
   }
-  min1, max1 := points1_slice.MinMax(1)
-  min2, max2 := points2_slice.MinMax(1)
+  min1, max1 := minMax(t, points1_slice)
+  min2, max2 := minMax(t, points2_slice)
 
   if min1 < 0 || max1 >= 1 {
     t.Error(rng_state.Name() + "Generated quasirandom numbers are out of range.")
@@ -60,3 +62,143 @@ func test_helper(t *testing.T, rng_type QrngType) {
   }
   rng_state.Free()
 }
+
+// test set 2
+func Test_qrandom_2(t *testing.T) {
+
+  // test 1
+  rng_state := Qrng_alloc(Sobol, 3)
+  if rng_state.Dim() != 3 {
+    t.Error("Test 1: Expected dimension 3, got ", rng_state.Dim())
+  }
+  rng_clone := rng_state.Clone()
+  rng_clone.Get()
+  point := rng_clone.Get()
+
+  rng_copy := rng_state
+  rng_copy.Get()
+  if rng_state.Get()[0] != point[0] {
+    t.Error("Test 1: Copies of a qrng state do not share the generator.")
+  }
+
+  // test 2
+  rng_copy.Free()
+  if err := rng_state.Close(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed when closing freed qrng, got ", err)
+  }
+  if err := rng_clone.Memcpy(rng_state); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from Memcpy into freed qrng, got ", err)
+  }
+  rng_clone.Free()
+
+  // test 3
+  defer func() {
+    if r := recover(); r != ErrFreed {
+      t.Error("Test 3: Expected panic with ErrFreed, got ", r)
+    }
+  }()
+  rng_state.Get()
+}
+
+// test set 3
+func Test_qrandom_3(t *testing.T) {
+
+  // test 1
+  rng_state, err := Qrng_allocE(Sobol, 2)
+  if err != nil || len(rng_state.Get()) != 2 {
+    t.Error("Test 1: Failed to allocate sobol qrng: ", err)
+  }
+
+  // test 2
+  if _, err := Qrng_allocE(Sobol, 100000); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 2: Expected EINVAL for invalid dimension, got ", err)
+  }
+  limits := []struct {
+    qrngType QrngType
+    max      uint
+  }{{Niederreiter_2, 12}, {Sobol, 40}, {Halton, 1229}, {ReverseHalton, 1229}}
+  for _, l := range limits {
+    if _, err := Qrng_allocE(l.qrngType, 0); !errors.Is(err, gsl.EINVAL) {
+      t.Error("Test 2: Expected EINVAL for dimension 0, got ", err)
+    }
+    qrng, err := Qrng_allocE(l.qrngType, l.max)
+    if err != nil || len(qrng.Get()) != int(l.max) {
+      t.Error("Test 2: Failed to allocate qrng of maximum dimension: ", err)
+    }
+    qrng.Free()
+    _, err = Qrng_allocE(l.qrngType, l.max+1)
+    if !errors.Is(err, gsl.EINVAL) {
+      t.Error("Test 2: Expected EINVAL for dimension ", l.max+1, ", got ",
+        err)
+    }
+    func() {
+      defer func() {
+        err, _ := recover().(error)
+        if !errors.Is(err, gsl.EINVAL) {
+          t.Error("Test 2: Expected panic with EINVAL for dimension ",
+            l.max+1, ", got ", err)
+        }
+      }()
+      Qrng_alloc(l.qrngType, l.max+1)
+    }()
+  }
+
+  // test 3
+  rng_state_1 := Qrng_alloc(Sobol, 3)
+  if err := rng_state.Memcpy(rng_state_1); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 3: Expected EINVAL for memcpy of different dimensions, "+
+      "got ", err)
+  }
+
+  rng_state.Free()
+  rng_state_1.Free()
+}
+
+// test set 4
+func Test_qrandom_4(t *testing.T) {
+
+  // test 1: E variants agree with their plain counterparts
+  rng_state := Qrng_alloc(Halton, 2)
+  rng_clone := rng_state.Clone()
+  point, err := rng_clone.GetE()
+  expected := rng_state.Get()
+  if err != nil || point[0] != expected[0] || point[1] != expected[1] {
+    t.Error("Test 1: GetE does not match Get: ", point, err)
+  }
+  if dim, err := rng_clone.DimE(); err != nil || dim != 2 {
+    t.Error("Test 1: Expected dimension 2, got ", dim, err)
+  }
+  if name, err := rng_clone.NameE(); err != nil || name != "halton" {
+    t.Error("Test 1: Unexpected qrng name: ", name, err)
+  }
+  err = rng_clone.GetFillE(make([]float64, 3))
+  if !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 1: Expected EBADLEN for fill of odd length, got ", err)
+  }
+
+  // test 2: E variants return ErrFreed for freed generators
+  rng_clone.Free()
+  if err := rng_clone.InitE(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from InitE, got ", err)
+  }
+  if _, err := rng_clone.GetE(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from GetE, got ", err)
+  }
+  if _, err := rng_clone.GetSliceE(10); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from GetSliceE, got ", err)
+  }
+  if err := rng_clone.GetFillE(make([]float64, 4)); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from GetFillE, got ", err)
+  }
+  if _, err := rng_clone.DimE(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from DimE, got ", err)
+  }
+  if _, err := rng_clone.SizeE(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from SizeE, got ", err)
+  }
+  if _, err := rng_clone.CloneE(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from CloneE, got ", err)
+  }
+
+  rng_state.Free()
+}
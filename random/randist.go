@@ -4,34 +4,57 @@
 //
 // randist wraps gsl random number distributions
 //
-// XXX: All of the slice functions could be made more efficient
-// by calling the underlying GSL routines directly rather than
-// calling the go wrappers.
+// Each distribution provides a function returning a single sample, a
+// Slice function returning n samples and a Fill function filling a
+// caller supplied slice. Slice and Fill functions generate all samples in
+// a single call into gsl which avoids the cgo overhead per sample.
 package random
 
 // #cgo pkg-config: gsl
 // #include <gsl/gsl_randist.h>
+// #include "fill.h"
+// #include "random_wrap.h"
 import "C"
 
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"unsafe"
+)
+
 // pair encapsulates an array of two doubles
 type Pair [2]float64
 
+// Triple encapsulates an array of three doubles
+type Triple [3]float64
+
 // Gaussian returns a Gaussian random variate, with mean zero and
 // standard deviation sigma.
 func Gaussian(rng RngState, sigma float64) float64 {
-	return float64(C.gsl_ran_gaussian(rng.state, C.double(sigma)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_gaussian(rng.ptr(), C.double(sigma)))
 }
 
 // GaussianSlice returns a slice of length N with Gaussian random
 // variates, with mean zero and standard deviation sigma.
 func GaussianSlice(rng RngState, sigma float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Gaussian(rng, sigma)
-	}
+	GaussianFill(rng, sigma, data)
 	return data
 }
 
+// GaussianFill fills dst with Gaussian random variates, with mean zero and
+// standard deviation sigma.
+func GaussianFill(rng RngState, sigma float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_gaussian_fill(rng.ptr(), C.double(sigma), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
 // GaussianPpf computes the probability density p(x) at x for a
 // Gaussian distribution with standard deviation sigma.
 func GaussianPdf(x float64, sigma float64) float64 {
@@ -42,7 +65,8 @@ func GaussianPdf(x float64, sigma float64) float64 {
 // standard deviation sigma computed via the Marsaglia-Zang ziggurat m
 // method.
 func GaussianZiggurat(rng RngState, sigma float64) float64 {
-	return float64(C.gsl_ran_gaussian_ziggurat(rng.state, C.double(sigma)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_gaussian_ziggurat(rng.ptr(), C.double(sigma)))
 }
 
 // GaussianSlice returns a slice of length N with Gaussian random
@@ -50,17 +74,28 @@ func GaussianZiggurat(rng RngState, sigma float64) float64 {
 // the Marsaglia-Zang ziggurat method.
 func GaussianZigguratSlice(rng RngState, sigma float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = GaussianZiggurat(rng, sigma)
-	}
+	GaussianZigguratFill(rng, sigma, data)
 	return data
 }
 
+// GaussianZigguratFill fills dst with Gaussian random variates, with mean
+// zero and standard deviation sigma computed via the Marsaglia-Zang ziggurat
+// method.
+func GaussianZigguratFill(rng RngState, sigma float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_gaussian_ziggurat_fill(rng.ptr(), C.double(sigma),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
 // GaussianRatioMethod returns a Gaussian random variate, with mean zero and
 // standard deviation sigma computed via the Kinderman-Monahan-Leva ratio
 // method.
 func GaussianRatioMethod(rng RngState, sigma float64) float64 {
-	return float64(C.gsl_ran_gaussian_ratio_method(rng.state, C.double(sigma)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_gaussian_ratio_method(rng.ptr(), C.double(sigma)))
 }
 
 // GaussianSlice returns a slice of length N with Gaussian random
@@ -68,33 +103,52 @@ func GaussianRatioMethod(rng RngState, sigma float64) float64 {
 // Kinderman-Monahan-Leva ratio method.
 func GaussianRatioMethodSlice(rng RngState, sigma float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = GaussianRatioMethod(rng, sigma)
-	}
+	GaussianRatioMethodFill(rng, sigma, data)
 	return data
 }
 
+// GaussianRatioMethodFill fills dst with Gaussian random variates, with mean
+// zero and standard deviation sigma computed via the Kinderman-Monahan-Leva
+// ratio method.
+func GaussianRatioMethodFill(rng RngState, sigma float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_gaussian_ratio_method_fill(rng.ptr(), C.double(sigma),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
 // Custom functions for unit Gaussian distribution
 
 // UGaussian returns a unit Gaussian random variate with mean zero.
 func Ugaussian(rng RngState) float64 {
-	return float64(C.gsl_ran_ugaussian(rng.state))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_ugaussian(rng.ptr()))
 }
 
 // UgaussianSlice returns a slice of length N with unit Gaussian random
 // variates with mean zero.
 func UgaussianSlice(rng RngState, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Ugaussian(rng)
-	}
+	UgaussianFill(rng, data)
 	return data
 }
 
+// UgaussianFill fills dst with unit Gaussian random variates with mean zero.
+func UgaussianFill(rng RngState, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_ugaussian_fill(rng.ptr(), (*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
 // UGaussian returns a unit Gaussian random variate with mean zero
 // computed with the Kinderman-Mohanan-Leva ratio method.
 func UgaussianRatioMethod(rng RngState) float64 {
-	return float64(C.gsl_ran_ugaussian_ratio_method(rng.state))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_ugaussian_ratio_method(rng.ptr()))
 }
 
 // UgaussianSlice returns a slice of length N with unit Gaussian random
@@ -102,12 +156,21 @@ func UgaussianRatioMethod(rng RngState) float64 {
 // method.
 func UgaussianRatioMethodSlice(rng RngState, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = UgaussianRatioMethod(rng)
-	}
+	UgaussianRatioMethodFill(rng, data)
 	return data
 }
 
+// UgaussianRatioMethodFill fills dst with unit Gaussian random variates with
+// mean zero computed with the Kinderman-Mohanan-Leva ratio method.
+func UgaussianRatioMethodFill(rng RngState, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_ugaussian_ratio_method_fill(rng.ptr(), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
 // UgaussianPpf computes the probability density p(x) at x for a
 // unit Gaussian distribution.
 func UgaussianPdf(x float64) float64 {
@@ -121,19 +184,28 @@ func UgaussianPdf(x float64) float64 {
 // Stat. 32, 894­899 (1961)), with this aspect explained in Knuth, v2,
 // 3rd ed, p139,586 (exercise 11).
 func GaussianTail(rng RngState, a, sigma float64) float64 {
-	return float64(C.gsl_ran_gaussian_tail(rng.state, C.double(a), C.double(sigma)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_gaussian_tail(rng.ptr(), C.double(a), C.double(sigma)))
 }
 
 // GaussianTailSlice returns a slice of length n from a Gaussian tail
 // distribution.
 func GaussianTailSlice(rng RngState, a, sigma float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = GaussianTail(rng, a, sigma)
-	}
+	GaussianTailFill(rng, a, sigma, data)
 	return data
 }
 
+// GaussianTailFill fills dst with samples from a Gaussian tail distribution.
+func GaussianTailFill(rng RngState, a, sigma float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_gaussian_tail_fill(rng.ptr(), C.double(a), C.double(sigma),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
 // GaussianTailPdf computes the probability density p(x) at x for a
 // Gaussian tail distribution with standard deviation sigma and lower
 // limit a.
@@ -145,19 +217,29 @@ func GaussianTailPdf(x, a, sigma float64) float64 {
 // UgaussianTail provides random variates from a unit Gaussian tail
 // distribution
 func UgaussianTail(rng RngState, a float64) float64 {
-	return float64(C.gsl_ran_ugaussian_tail(rng.state, C.double(a)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_ugaussian_tail(rng.ptr(), C.double(a)))
 }
 
 // UgaussianTailSlice returns a slice of length n from a unit Gaussian tail
 // distribution.
 func UgaussianTailSlice(rng RngState, a float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = UgaussianTail(rng, a)
-	}
+	UgaussianTailFill(rng, a, data)
 	return data
 }
 
+// UgaussianTailFill fills dst with samples from a unit Gaussian tail
+// distribution.
+func UgaussianTailFill(rng RngState, a float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_ugaussian_tail_fill(rng.ptr(), C.double(a), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
 // UgaussianTailPdf computes the probability density p(x) at x for a
 // unit Gaussian tail distribution with lower limit a.
 func UgaussianTailPdf(x, a float64) float64 {
@@ -170,8 +252,9 @@ func UgaussianTailPdf(x, a float64) float64 {
 // coefficient rho should lie between 1 and -1.
 func BivariateGaussian(rng RngState, sigma_x, sigma_y, rho float64) (float64,
 	float64) {
+	defer runtime.KeepAlive(rng.owner)
 	var x, y float64
-	C.gsl_ran_bivariate_gaussian(rng.state, C.double(sigma_x),
+	C.gsl_ran_bivariate_gaussian(rng.ptr(), C.double(sigma_x),
 		C.double(sigma_y), C.double(rho), (*C.double)(&x), (*C.double)(&y))
 	return x, y
 }
@@ -181,13 +264,23 @@ func BivariateGaussian(rng RngState, sigma_x, sigma_y, rho float64) (float64,
 func BivariateGaussianSlice(rng RngState, sigma_x, sigma_y, rho float64,
 	n uint64) []Pair {
 	data := make([]Pair, n)
-	for i := uint64(0); i < n; i++ {
-		x, y := BivariateGaussian(rng, sigma_x, sigma_y, rho)
-		data[i] = Pair{x, y}
-	}
+	BivariateGaussianFill(rng, sigma_x, sigma_y, rho, data)
 	return data
 }
 
+// BivariateGaussianFill fills dst with pairs of correlated Gaussian
+// variates
+func BivariateGaussianFill(rng RngState, sigma_x, sigma_y, rho float64,
+	dst []Pair) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_bivariate_gaussian_fill(rng.ptr(), C.double(sigma_x),
+		C.double(sigma_y), C.double(rho), (*C.double)(&dst[0][0]),
+		C.size_t(len(dst)))
+}
+
 // BivariateGaussianPdf computes the probability density p(x,y) at (x,y)
 // for a bivariate Gaussian distribution with standard deviations sigma x,
 // sigma y and correlation coefficient rho.
@@ -198,18 +291,27 @@ func BivariateGaussianPdf(x, y, sigma_x, sigma_y, rho float64) float64 {
 
 // Exponential returns a random variate from the exponential distribution with mean mu.
 func Exponential(rng RngState, mu float64) float64 {
-	return float64(C.gsl_ran_exponential(rng.state, C.double(mu)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_exponential(rng.ptr(), C.double(mu)))
 }
 
 // ExponentialSlice generates a slice of length n of exponentially distributed values
 func ExponentialSlice(rng RngState, mu float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Exponential(rng, mu)
-	}
+	ExponentialFill(rng, mu, data)
 	return data
 }
 
+// ExponentialFill fills dst with exponentially distributed values
+func ExponentialFill(rng RngState, mu float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_exponential_fill(rng.ptr(), C.double(mu), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
 // ExponentialPdf computes the probability density p(x) at x for an exponential
 // distribution with mean mu.
 func ExponentialPdf(x, mu float64) float64 {
@@ -218,18 +320,27 @@ func ExponentialPdf(x, mu float64) float64 {
 
 // Laplace returns a random variate from the exponential distribution with width a.
 func Laplace(rng RngState, a float64) float64 {
-	return float64(C.gsl_ran_laplace(rng.state, C.double(a)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_laplace(rng.ptr(), C.double(a)))
 }
 
 // LaplaceSlice generates a slice of length n of laplace distributed values
 func LaplaceSlice(rng RngState, a float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Laplace(rng, a)
-	}
+	LaplaceFill(rng, a, data)
 	return data
 }
 
+// LaplaceFill fills dst with laplace distributed values
+func LaplaceFill(rng RngState, a float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_laplace_fill(rng.ptr(), C.double(a), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
 // LaplacePdf computes the probability density p(x) at x for a laplace
 // distribution with width a.
 func LaplacePdf(x, a float64) float64 {
@@ -239,18 +350,27 @@ func LaplacePdf(x, a float64) float64 {
 // Exppow returns a random variate from the exponential power distribution with scale
 // parameter a and exponent b.
 func Exppow(rng RngState, a, b float64) float64 {
-	return float64(C.gsl_ran_exppow(rng.state, C.double(a), C.double(b)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_exppow(rng.ptr(), C.double(a), C.double(b)))
 }
 
 // ExppowSlice generates a slice of length n of exponential power distributes values
 func ExppowSlice(rng RngState, a, b float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Exppow(rng, a, b)
-	}
+	ExppowFill(rng, a, b, data)
 	return data
 }
 
+// ExppowFill fills dst with exponential power distributed values
+func ExppowFill(rng RngState, a, b float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_exppow_fill(rng.ptr(), C.double(a), C.double(b),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
 // ExppowPdf computes the probability density p(x) at x for a exponential power
 // distribution with scale parameter a and exponent b
 func ExppowPdf(x, a, b float64) float64 {
@@ -259,18 +379,27 @@ func ExppowPdf(x, a, b float64) float64 {
 
 // Cauchy returns a random variate from the Cauchy distribution with scale parameter a.
 func Cauchy(rng RngState, a float64) float64 {
-	return float64(C.gsl_ran_cauchy(rng.state, C.double(a)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_cauchy(rng.ptr(), C.double(a)))
 }
 
 // CauchySlice generates a slice of length n of cauchy distributes values
 func CauchySlice(rng RngState, a float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Cauchy(rng, a)
-	}
+	CauchyFill(rng, a, data)
 	return data
 }
 
+// CauchyFill fills dst with Cauchy distributed values
+func CauchyFill(rng RngState, a float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_cauchy_fill(rng.ptr(), C.double(a), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
 // CauchyPdf computes the probability density p(x) at x for a cauchy
 // distribution with scale parameter a
 func CauchyPdf(x, a float64) float64 {
@@ -280,18 +409,27 @@ func CauchyPdf(x, a float64) float64 {
 // Rayleigh returns a random variate from the Rayleigh distribution with scale
 // parameter sigma.
 func Rayleigh(rng RngState, sigma float64) float64 {
-	return float64(C.gsl_ran_rayleigh(rng.state, C.double(sigma)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_rayleigh(rng.ptr(), C.double(sigma)))
 }
 
 // RayleighSlice generates a slice of length n of rayleigh distributed values
 func RayleighSlice(rng RngState, sigma float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Rayleigh(rng, sigma)
-	}
+	RayleighFill(rng, sigma, data)
 	return data
 }
 
+// RayleighFill fills dst with rayleigh distributed values
+func RayleighFill(rng RngState, sigma float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_rayleigh_fill(rng.ptr(), C.double(sigma), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
 // RayleighPdf computes the probability density p(x) at x for a rayleigh
 // distribution with scale parameter sigma
 func RayleighPdf(x, sigma float64) float64 {
@@ -301,18 +439,27 @@ func RayleighPdf(x, sigma float64) float64 {
 // RayleighTail returns a random variate from the tail of the Rayleigh distribution
 // with scale parameter sigma and a lower limit of a.
 func RayleighTail(rng RngState, a, sigma float64) float64 {
-	return float64(C.gsl_ran_rayleigh_tail(rng.state, C.double(a), C.double(sigma)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_rayleigh_tail(rng.ptr(), C.double(a), C.double(sigma)))
 }
 
 // RayleighTailSlice generates a slice of length n of rayleigh tail distributed values
 func RayleighTailSlice(rng RngState, a, sigma float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = RayleighTail(rng, a, sigma)
-	}
+	RayleighTailFill(rng, a, sigma, data)
 	return data
 }
 
+// RayleighTailFill fills dst with rayleigh tail distributed values
+func RayleighTailFill(rng RngState, a, sigma float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_rayleigh_tail_fill(rng.ptr(), C.double(a), C.double(sigma),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
 // RayleighTailPdf computes the probability density p(x) at x for a rayleigh
 // tail distribution with scale parameter sigma and a lower limit of a
 func RayleighTailPdf(x, a, sigma float64) float64 {
@@ -322,18 +469,26 @@ func RayleighTailPdf(x, a, sigma float64) float64 {
 
 // Landay returns a random variate from the Landau distribution.
 func Landau(rng RngState) float64 {
-	return float64(C.gsl_ran_landau(rng.state))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_landau(rng.ptr()))
 }
 
 // LandauSlice generates a slice of length n of Landau distributed values
 func LandauSlice(rng RngState, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Landau(rng)
-	}
+	LandauFill(rng, data)
 	return data
 }
 
+// LandauFill fills dst with Landau distributed values
+func LandauFill(rng RngState, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_landau_fill(rng.ptr(), (*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
 // LandayPdf computes the probability density p(x) at x for a Landau
 // distribution.
 func LandauPdf(x float64) float64 {
@@ -344,58 +499,86 @@ func LandauPdf(x float64) float64 {
 // with scale c and exponent alpha.
 // XXX: The algorithm only works for 0 < alpha <= 2
 func Levy(rng RngState, c, alpha float64) float64 {
-	return float64(C.gsl_ran_levy(rng.state, C.double(c), C.double(alpha)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_levy(rng.ptr(), C.double(c), C.double(alpha)))
 }
 
 // LevySlice generates a slice of length n of Levy distributed values
 func LevySlice(rng RngState, c, alpha float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Levy(rng, c, alpha)
-	}
+	LevyFill(rng, c, alpha, data)
 	return data
 }
 
+// LevyFill fills dst with Levy distributed values
+func LevyFill(rng RngState, c, alpha float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_levy_fill(rng.ptr(), C.double(c), C.double(alpha),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
 // LevySkew returns a random variate from the Levy skew stable distribution with
 // scale c, exponent alpha and skewness parameter beta. The skewness parameter must
 // lie in the range [−1, 1].
 // XXX: The algorithm only works for 0 < alpha <= 2
 func LevySkew(rng RngState, c, alpha, beta float64) float64 {
-	return float64(C.gsl_ran_levy_skew(rng.state, C.double(c), C.double(alpha),
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_levy_skew(rng.ptr(), C.double(c), C.double(alpha),
 		C.double(beta)))
 }
 
 // LevySkewSlice generates a slice of length n of Levy skew distributed values
 func LevySkewSlice(rng RngState, c, alpha, beta float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = LevySkew(rng, c, alpha, beta)
-	}
+	LevySkewFill(rng, c, alpha, beta, data)
 	return data
 }
 
+// LevySkewFill fills dst with Levy skew distributed values
+func LevySkewFill(rng RngState, c, alpha, beta float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_levy_skew_fill(rng.ptr(), C.double(c), C.double(alpha),
+		C.double(beta), (*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
 // Gamma returns a random variate from the gamma distribution.
 // The gamma distribution with an integer parameter a is known as the Erlang
 // distribution. The variates are computed using the Marsaglia-Tsang fast gamma method.
 func Gamma(rng RngState, a, b float64) float64 {
-	return float64(C.gsl_ran_gamma(rng.state, C.double(a), C.double(b)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_gamma(rng.ptr(), C.double(a), C.double(b)))
 }
 
 // GammaKnuth returns a random variate from the gamma distribution using the
 // algorithms from Knuth.
 func GammaKnuth(rng RngState, a, b float64) float64 {
-	return float64(C.gsl_ran_gamma_knuth(rng.state, C.double(a), C.double(b)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_gamma_knuth(rng.ptr(), C.double(a), C.double(b)))
 }
 
 // GammaSlice generates a slice of length n of gamma distributed values
 func GammaSlice(rng RngState, a, b float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Gamma(rng, a, b)
-	}
+	GammaFill(rng, a, b, data)
 	return data
 }
 
+// GammaFill fills dst with gamma distributed values
+func GammaFill(rng RngState, a, b float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_gamma_fill(rng.ptr(), C.double(a), C.double(b), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
 // GammaPdf computes the probability density p(x) at x for a gamma distribution.
 func GammaPdf(x, a, b float64) float64 {
 	return float64(C.gsl_ran_gamma_pdf(C.double(x), C.double(a), C.double(b)))
@@ -403,18 +586,27 @@ func GammaPdf(x, a, b float64) float64 {
 
 // Flat returns a random variate from the flat (uniform) distribution from a to b.
 func Flat(rng RngState, a, b float64) float64 {
-	return float64(C.gsl_ran_flat(rng.state, C.double(a), C.double(b)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_flat(rng.ptr(), C.double(a), C.double(b)))
 }
 
 // FlatSlice generates a slice of length n of flat distributed values
 func FlatSlice(rng RngState, a, b float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Flat(rng, a, b)
-	}
+	FlatFill(rng, a, b, data)
 	return data
 }
 
+// FlatFill fills dst with flat distributed values
+func FlatFill(rng RngState, a, b float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_flat_fill(rng.ptr(), C.double(a), C.double(b), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
 // FlatPdf computes the probability density p(x) at x for a flat distribution.
 func FlatPdf(x, a, b float64) float64 {
 	return float64(C.gsl_ran_flat_pdf(C.double(x), C.double(a), C.double(b)))
@@ -422,19 +614,996 @@ func FlatPdf(x, a, b float64) float64 {
 
 // Lognormal returns a random variate from the lognormal distribution
 func Lognormal(rng RngState, zeta, sigma float64) float64 {
-	return float64(C.gsl_ran_lognormal(rng.state, C.double(zeta), C.double(sigma)))
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_lognormal(rng.ptr(), C.double(zeta), C.double(sigma)))
 }
 
 // LognormalSlice generates a slice of length n of lognormal distributed values
 func LognormalSlice(rng RngState, zeta, sigma float64, n uint64) []float64 {
 	data := make([]float64, n)
-	for i := uint64(0); i < n; i++ {
-		data[i] = Lognormal(rng, zeta, sigma)
-	}
+	LognormalFill(rng, zeta, sigma, data)
 	return data
 }
 
+// LognormalFill fills dst with lognormal distributed values
+func LognormalFill(rng RngState, zeta, sigma float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_lognormal_fill(rng.ptr(), C.double(zeta), C.double(sigma),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
 // LognormalPdf computes the probability density p(x) at x for a lognormal distribution.
 func LognormalPdf(x, zeta, sigma float64) float64 {
 	return float64(C.gsl_ran_lognormal_pdf(C.double(x), C.double(zeta), C.double(sigma)))
 }
+
+// Chisq returns a random variate from the chi-squared distribution with nu
+// degrees of freedom.
+func Chisq(rng RngState, nu float64) float64 {
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_chisq(rng.ptr(), C.double(nu)))
+}
+
+// ChisqSlice generates a slice of length n of chi-squared distributed values
+func ChisqSlice(rng RngState, nu float64, n uint64) []float64 {
+	data := make([]float64, n)
+	ChisqFill(rng, nu, data)
+	return data
+}
+
+// ChisqFill fills dst with chi-squared distributed values
+func ChisqFill(rng RngState, nu float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_chisq_fill(rng.ptr(), C.double(nu), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
+// ChisqPdf computes the probability density p(x) at x for a chi-squared
+// distribution with nu degrees of freedom.
+func ChisqPdf(x, nu float64) float64 {
+	return float64(C.gsl_ran_chisq_pdf(C.double(x), C.double(nu)))
+}
+
+// Fdist returns a random variate from the F-distribution with degrees of
+// freedom nu1 and nu2.
+func Fdist(rng RngState, nu1, nu2 float64) float64 {
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_fdist(rng.ptr(), C.double(nu1), C.double(nu2)))
+}
+
+// FdistSlice generates a slice of length n of F distributed values
+func FdistSlice(rng RngState, nu1, nu2 float64, n uint64) []float64 {
+	data := make([]float64, n)
+	FdistFill(rng, nu1, nu2, data)
+	return data
+}
+
+// FdistFill fills dst with F distributed values
+func FdistFill(rng RngState, nu1, nu2 float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_fdist_fill(rng.ptr(), C.double(nu1), C.double(nu2),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
+// FdistPdf computes the probability density p(x) at x for an F-distribution
+// with nu1 and nu2 degrees of freedom.
+func FdistPdf(x, nu1, nu2 float64) float64 {
+	return float64(C.gsl_ran_fdist_pdf(C.double(x), C.double(nu1), C.double(nu2)))
+}
+
+// Tdist returns a random variate from the Student t-distribution with nu
+// degrees of freedom.
+func Tdist(rng RngState, nu float64) float64 {
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_tdist(rng.ptr(), C.double(nu)))
+}
+
+// TdistSlice generates a slice of length n of t distributed values
+func TdistSlice(rng RngState, nu float64, n uint64) []float64 {
+	data := make([]float64, n)
+	TdistFill(rng, nu, data)
+	return data
+}
+
+// TdistFill fills dst with t distributed values
+func TdistFill(rng RngState, nu float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_tdist_fill(rng.ptr(), C.double(nu), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
+// TdistPdf computes the probability density p(x) at x for a t-distribution
+// with nu degrees of freedom.
+func TdistPdf(x, nu float64) float64 {
+	return float64(C.gsl_ran_tdist_pdf(C.double(x), C.double(nu)))
+}
+
+// Beta returns a random variate from the beta distribution with parameters
+// a and b.
+func Beta(rng RngState, a, b float64) float64 {
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_beta(rng.ptr(), C.double(a), C.double(b)))
+}
+
+// BetaSlice generates a slice of length n of beta distributed values
+func BetaSlice(rng RngState, a, b float64, n uint64) []float64 {
+	data := make([]float64, n)
+	BetaFill(rng, a, b, data)
+	return data
+}
+
+// BetaFill fills dst with beta distributed values
+func BetaFill(rng RngState, a, b float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_beta_fill(rng.ptr(), C.double(a), C.double(b), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
+// BetaPdf computes the probability density p(x) at x for a beta distribution
+// with parameters a and b.
+func BetaPdf(x, a, b float64) float64 {
+	return float64(C.gsl_ran_beta_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Logistic returns a random variate from the logistic distribution with
+// scale parameter a.
+func Logistic(rng RngState, a float64) float64 {
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_logistic(rng.ptr(), C.double(a)))
+}
+
+// LogisticSlice generates a slice of length n of logistic distributed values
+func LogisticSlice(rng RngState, a float64, n uint64) []float64 {
+	data := make([]float64, n)
+	LogisticFill(rng, a, data)
+	return data
+}
+
+// LogisticFill fills dst with logistic distributed values
+func LogisticFill(rng RngState, a float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_logistic_fill(rng.ptr(), C.double(a), (*C.double)(&dst[0]),
+		C.size_t(len(dst)))
+}
+
+// LogisticPdf computes the probability density p(x) at x for a logistic
+// distribution with scale parameter a.
+func LogisticPdf(x, a float64) float64 {
+	return float64(C.gsl_ran_logistic_pdf(C.double(x), C.double(a)))
+}
+
+// Pareto returns a random variate from the Pareto distribution of order a
+// and scale b.
+func Pareto(rng RngState, a, b float64) float64 {
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_pareto(rng.ptr(), C.double(a), C.double(b)))
+}
+
+// ParetoSlice generates a slice of length n of Pareto distributed values
+func ParetoSlice(rng RngState, a, b float64, n uint64) []float64 {
+	data := make([]float64, n)
+	ParetoFill(rng, a, b, data)
+	return data
+}
+
+// ParetoFill fills dst with Pareto distributed values
+func ParetoFill(rng RngState, a, b float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_pareto_fill(rng.ptr(), C.double(a), C.double(b),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
+// ParetoPdf computes the probability density p(x) at x for a Pareto
+// distribution with exponent a and scale b.
+func ParetoPdf(x, a, b float64) float64 {
+	return float64(C.gsl_ran_pareto_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Weibull returns a random variate from the Weibull distribution with scale
+// a and exponent b.
+func Weibull(rng RngState, a, b float64) float64 {
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_weibull(rng.ptr(), C.double(a), C.double(b)))
+}
+
+// WeibullSlice generates a slice of length n of Weibull distributed values
+func WeibullSlice(rng RngState, a, b float64, n uint64) []float64 {
+	data := make([]float64, n)
+	WeibullFill(rng, a, b, data)
+	return data
+}
+
+// WeibullFill fills dst with Weibull distributed values
+func WeibullFill(rng RngState, a, b float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_weibull_fill(rng.ptr(), C.double(a), C.double(b),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
+// WeibullPdf computes the probability density p(x) at x for a Weibull
+// distribution with scale a and exponent b.
+func WeibullPdf(x, a, b float64) float64 {
+	return float64(C.gsl_ran_weibull_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Gumbel1 returns a random variate from the Type-1 Gumbel distribution with
+// parameters a and b.
+func Gumbel1(rng RngState, a, b float64) float64 {
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_gumbel1(rng.ptr(), C.double(a), C.double(b)))
+}
+
+// Gumbel1Slice generates a slice of length n of Type-1 Gumbel distributed values
+func Gumbel1Slice(rng RngState, a, b float64, n uint64) []float64 {
+	data := make([]float64, n)
+	Gumbel1Fill(rng, a, b, data)
+	return data
+}
+
+// Gumbel1Fill fills dst with Type-1 Gumbel distributed values
+func Gumbel1Fill(rng RngState, a, b float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_gumbel1_fill(rng.ptr(), C.double(a), C.double(b),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
+// Gumbel1Pdf computes the probability density p(x) at x for a Type-1 Gumbel
+// distribution with parameters a and b.
+func Gumbel1Pdf(x, a, b float64) float64 {
+	return float64(C.gsl_ran_gumbel1_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Gumbel2 returns a random variate from the Type-2 Gumbel distribution with
+// parameters a and b.
+func Gumbel2(rng RngState, a, b float64) float64 {
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_gumbel2(rng.ptr(), C.double(a), C.double(b)))
+}
+
+// Gumbel2Slice generates a slice of length n of Type-2 Gumbel distributed values
+func Gumbel2Slice(rng RngState, a, b float64, n uint64) []float64 {
+	data := make([]float64, n)
+	Gumbel2Fill(rng, a, b, data)
+	return data
+}
+
+// Gumbel2Fill fills dst with Type-2 Gumbel distributed values
+func Gumbel2Fill(rng RngState, a, b float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_gumbel2_fill(rng.ptr(), C.double(a), C.double(b),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
+// Gumbel2Pdf computes the probability density p(x) at x for a Type-2 Gumbel
+// distribution with parameters a and b.
+func Gumbel2Pdf(x, a, b float64) float64 {
+	return float64(C.gsl_ran_gumbel2_pdf(C.double(x), C.double(a), C.double(b)))
+}
+
+// Erlang returns a random variate from the Erlang distribution with scale
+// a and integer order n. This is a gamma distribution with shape n.
+func Erlang(rng RngState, a, n float64) float64 {
+	defer runtime.KeepAlive(rng.owner)
+	return float64(C.gsl_ran_erlang(rng.ptr(), C.double(a), C.double(n)))
+}
+
+// ErlangSlice generates a slice of length num of Erlang distributed values
+func ErlangSlice(rng RngState, a, n float64, num uint64) []float64 {
+	data := make([]float64, num)
+	ErlangFill(rng, a, n, data)
+	return data
+}
+
+// ErlangFill fills dst with Erlang distributed values
+func ErlangFill(rng RngState, a, n float64, dst []float64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_erlang_fill(rng.ptr(), C.double(a), C.double(n),
+		(*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
+// ErlangPdf computes the probability density p(x) at x for an Erlang
+// distribution with scale a and order n.
+func ErlangPdf(x, a, n float64) float64 {
+	return float64(C.gsl_ran_erlang_pdf(C.double(x), C.double(a), C.double(n)))
+}
+
+// Spherical vector distributions
+
+// Dir2d returns a random direction vector v = (x, y) in two dimensions.
+// The vector is normalized such that |v|^2 = x^2 + y^2 = 1.
+func Dir2d(rng RngState) (float64, float64) {
+	defer runtime.KeepAlive(rng.owner)
+	var x, y float64
+	C.gsl_ran_dir_2d(rng.ptr(), (*C.double)(&x), (*C.double)(&y))
+	return x, y
+}
+
+// Dir2dSlice generates a slice of length n of two dimensional random
+// direction vectors
+func Dir2dSlice(rng RngState, n uint64) []Pair {
+	data := make([]Pair, n)
+	Dir2dFill(rng, data)
+	return data
+}
+
+// Dir2dFill fills dst with two dimensional random direction vectors
+func Dir2dFill(rng RngState, dst []Pair) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_dir_2d_fill(rng.ptr(), (*C.double)(&dst[0][0]), C.size_t(len(dst)))
+}
+
+// Dir2dTrigMethod returns a random direction vector v = (x, y) in two
+// dimensions computed via the trigonometric functions sin and cos
+// instead of von Neumann's rejection method used by Dir2d.
+func Dir2dTrigMethod(rng RngState) (float64, float64) {
+	defer runtime.KeepAlive(rng.owner)
+	var x, y float64
+	C.gsl_ran_dir_2d_trig_method(rng.ptr(), (*C.double)(&x), (*C.double)(&y))
+	return x, y
+}
+
+// Dir2dTrigMethodSlice generates a slice of length n of two dimensional
+// random direction vectors computed via the trigonometric method
+func Dir2dTrigMethodSlice(rng RngState, n uint64) []Pair {
+	data := make([]Pair, n)
+	Dir2dTrigMethodFill(rng, data)
+	return data
+}
+
+// Dir2dTrigMethodFill fills dst with two dimensional random direction
+// vectors computed via the trigonometric method
+func Dir2dTrigMethodFill(rng RngState, dst []Pair) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_dir_2d_trig_method_fill(rng.ptr(), (*C.double)(&dst[0][0]),
+		C.size_t(len(dst)))
+}
+
+// Dir3d returns a random direction vector v = (x, y, z) in three
+// dimensions. The vector is normalized such that |v|^2 = x^2 + y^2 + z^2 = 1.
+func Dir3d(rng RngState) (float64, float64, float64) {
+	defer runtime.KeepAlive(rng.owner)
+	var x, y, z float64
+	C.gsl_ran_dir_3d(rng.ptr(), (*C.double)(&x), (*C.double)(&y),
+		(*C.double)(&z))
+	return x, y, z
+}
+
+// Dir3dSlice generates a slice of length n of three dimensional random
+// direction vectors
+func Dir3dSlice(rng RngState, n uint64) []Triple {
+	data := make([]Triple, n)
+	Dir3dFill(rng, data)
+	return data
+}
+
+// Dir3dFill fills dst with three dimensional random direction vectors
+func Dir3dFill(rng RngState, dst []Triple) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_dir_3d_fill(rng.ptr(), (*C.double)(&dst[0][0]), C.size_t(len(dst)))
+}
+
+// DirNd returns a random direction vector v = (x_1, x_2, ..., x_dim) in
+// dim dimensions. The vector is normalized such that
+// |v|^2 = x_1^2 + x_2^2 + ... + x_dim^2 = 1.
+func DirNd(rng RngState, dim uint64) []float64 {
+	defer runtime.KeepAlive(rng.owner)
+	if dim == 0 {
+		return []float64{}
+	}
+	x := make([]float64, dim)
+	C.gsl_ran_dir_nd(rng.ptr(), C.size_t(dim), (*C.double)(&x[0]))
+	return x
+}
+
+// DirNdSlice generates a slice of length n of dim dimensional random
+// direction vectors
+func DirNdSlice(rng RngState, dim uint64, n uint64) [][]float64 {
+	buf := make([]float64, dim*n)
+	if dim > 0 {
+		DirNdFill(rng, dim, buf)
+	}
+	return splitFloats(buf, dim, n)
+}
+
+// DirNdFill fills dst with consecutive dim dimensional random direction
+// vectors. The length of dst has to be a multiple of dim.
+func DirNdFill(rng RngState, dim uint64, dst []float64) {
+	if dim == 0 || uint64(len(dst))%dim != 0 {
+		panic(fmt.Sprintf("Length %d of dst is not a multiple of dimension %d.",
+			len(dst), dim))
+	}
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_dir_nd_fill(rng.ptr(), C.size_t(dim), (*C.double)(&dst[0]),
+		C.size_t(uint64(len(dst))/dim))
+}
+
+// splitFloats splits buf into n slices of length dim sharing buf as
+// backing store
+func splitFloats(buf []float64, dim, n uint64) [][]float64 {
+	data := make([][]float64, n)
+	for i := uint64(0); i < n; i++ {
+		data[i] = buf[i*dim : (i+1)*dim : (i+1)*dim]
+	}
+	return data
+}
+
+// Multivariate distributions
+
+// Dirichlet returns a slice of K random variates from a Dirichlet
+// distribution of order K = len(alpha) with parameters alpha. The
+// returned values theta_i are positive and sum to 1.
+func Dirichlet(rng RngState, alpha []float64) []float64 {
+	defer runtime.KeepAlive(rng.owner)
+	if len(alpha) == 0 {
+		return []float64{}
+	}
+	theta := make([]float64, len(alpha))
+	C.gsl_ran_dirichlet(rng.ptr(), C.size_t(len(alpha)), (*C.double)(&alpha[0]),
+		(*C.double)(&theta[0]))
+	return theta
+}
+
+// DirichletSlice generates a slice of length n of Dirichlet distributed
+// variates
+func DirichletSlice(rng RngState, alpha []float64, n uint64) [][]float64 {
+	k := uint64(len(alpha))
+	buf := make([]float64, k*n)
+	if k > 0 {
+		DirichletFill(rng, alpha, buf)
+	}
+	return splitFloats(buf, k, n)
+}
+
+// DirichletFill fills dst with consecutive Dirichlet distributed variates
+// of length len(alpha). The length of dst has to be a multiple of
+// len(alpha).
+func DirichletFill(rng RngState, alpha []float64, dst []float64) {
+	k := len(alpha)
+	if k == 0 || len(dst)%k != 0 {
+		panic(fmt.Sprintf("Length %d of dst is not a multiple of order %d.",
+			len(dst), k))
+	}
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_dirichlet_fill(rng.ptr(), C.size_t(k), (*C.double)(&alpha[0]),
+		(*C.double)(&dst[0]), C.size_t(len(dst)/k))
+}
+
+// DirichletPdf computes the probability density p(theta) at theta for a
+// Dirichlet distribution with parameters alpha. The slices alpha and
+// theta need to have the same length.
+func DirichletPdf(alpha, theta []float64) float64 {
+	if len(alpha) == 0 || len(alpha) != len(theta) {
+		return 0
+	}
+	return float64(C.gsl_ran_dirichlet_pdf(C.size_t(len(alpha)),
+		(*C.double)(&alpha[0]), (*C.double)(&theta[0])))
+}
+
+// DirichletLnPdf computes the logarithm of the probability density
+// p(theta) at theta for a Dirichlet distribution with parameters alpha.
+func DirichletLnPdf(alpha, theta []float64) float64 {
+	if len(alpha) == 0 || len(alpha) != len(theta) {
+		return math.Inf(-1)
+	}
+	return float64(C.gsl_ran_dirichlet_lnpdf(C.size_t(len(alpha)),
+		(*C.double)(&alpha[0]), (*C.double)(&theta[0])))
+}
+
+// checkMultivariateGaussian makes sure that the mean mu of length k and
+// Cholesky factor L of size k x k are consistent
+func checkMultivariateGaussian(mu, L []float64) error {
+	if len(mu) == 0 {
+		return fmt.Errorf("Mean vector of multivariate Gaussian is empty.")
+	}
+	if len(L) != len(mu)*len(mu) {
+		return fmt.Errorf("Cholesky factor of size %d does not match mean "+
+			"vector of length %d.", len(L), len(mu))
+	}
+	return nil
+}
+
+// MultivariateGaussian returns a random variate from the k dimensional
+// multivariate Gaussian distribution with mean mu and covariance matrix
+// Sigma = L L^T. Instead of Sigma the caller supplies its lower triangular
+// Cholesky factor L as a k x k matrix stored in row major order. Only the
+// lower triangle of L is referenced.
+func MultivariateGaussian(rng RngState, mu, L []float64) ([]float64, error) {
+	defer runtime.KeepAlive(rng.owner)
+	if err := checkMultivariateGaussian(mu, L); err != nil {
+		return nil, err
+	}
+	x := make([]float64, len(mu))
+	status := C.ran_multivariate_gaussian(rng.ptr(), (*C.double)(&mu[0]),
+		(*C.double)(&L[0]), C.size_t(len(mu)), (*C.double)(&x[0]), 1)
+	if status != 0 {
+		return nil, fmt.Errorf("Failed to sample multivariate Gaussian.")
+	}
+	return x, nil
+}
+
+// MultivariateGaussianSlice generates a slice of length n of multivariate
+// Gaussian variates with mean mu and Cholesky factor L
+func MultivariateGaussianSlice(rng RngState, mu, L []float64,
+	n uint64) ([][]float64, error) {
+	if err := checkMultivariateGaussian(mu, L); err != nil {
+		return nil, err
+	}
+	k := uint64(len(mu))
+	buf := make([]float64, k*n)
+	if err := MultivariateGaussianFill(rng, mu, L, buf); err != nil {
+		return nil, err
+	}
+	return splitFloats(buf, k, n), nil
+}
+
+// MultivariateGaussianFill fills dst with consecutive multivariate
+// Gaussian variates with mean mu and Cholesky factor L. The length of dst
+// has to be a multiple of len(mu).
+func MultivariateGaussianFill(rng RngState, mu, L []float64,
+	dst []float64) error {
+	if err := checkMultivariateGaussian(mu, L); err != nil {
+		return err
+	}
+	k := len(mu)
+	if len(dst)%k != 0 {
+		return fmt.Errorf("Length %d of dst is not a multiple of dimension %d.",
+			len(dst), k)
+	}
+	if len(dst) == 0 {
+		return nil
+	}
+
+	defer runtime.KeepAlive(rng.owner)
+	status := C.ran_multivariate_gaussian(rng.ptr(), (*C.double)(&mu[0]),
+		(*C.double)(&L[0]), C.size_t(k), (*C.double)(&dst[0]),
+		C.size_t(len(dst)/k))
+	if status != 0 {
+		return fmt.Errorf("Failed to sample multivariate Gaussian.")
+	}
+	return nil
+}
+
+// MultivariateGaussianLogPdf computes the logarithm of the probability
+// density p(x) at x for a multivariate Gaussian distribution with mean mu
+// and Cholesky factor L of the covariance matrix.
+func MultivariateGaussianLogPdf(x, mu, L []float64) (float64, error) {
+	if err := checkMultivariateGaussian(mu, L); err != nil {
+		return 0, err
+	}
+	if len(x) != len(mu) {
+		return 0, fmt.Errorf("Length %d of x does not match mean vector of "+
+			"length %d.", len(x), len(mu))
+	}
+	var result float64
+	work := make([]float64, len(mu))
+	status := C.ran_multivariate_gaussian_log_pdf((*C.double)(&x[0]),
+		(*C.double)(&mu[0]), (*C.double)(&L[0]), C.size_t(len(mu)),
+		(*C.double)(&result), (*C.double)(&work[0]))
+	if status != 0 {
+		return 0, fmt.Errorf("Failed to compute multivariate Gaussian pdf.")
+	}
+	return result, nil
+}
+
+// MultivariateGaussianPdf computes the probability density p(x) at x for
+// a multivariate Gaussian distribution with mean mu and Cholesky factor L
+// of the covariance matrix.
+func MultivariateGaussianPdf(x, mu, L []float64) (float64, error) {
+	logPdf, err := MultivariateGaussianLogPdf(x, mu, L)
+	if err != nil {
+		return 0, err
+	}
+	return math.Exp(logPdf), nil
+}
+
+// Discrete distributions
+//
+// gsl represents the outcomes and integer parameters of the discrete
+// distributions as unsigned int. They are therefore passed as uint32 so
+// that they can't be truncated silently; the samples always fit into a
+// uint32 as well.
+
+// Poisson returns a random integer from the Poisson distribution with mean mu.
+func Poisson(rng RngState, mu float64) uint64 {
+	defer runtime.KeepAlive(rng.owner)
+	return uint64(C.gsl_ran_poisson(rng.ptr(), C.double(mu)))
+}
+
+// PoissonSlice generates a slice of length n of Poisson distributed values
+func PoissonSlice(rng RngState, mu float64, n uint64) []uint64 {
+	data := make([]uint64, n)
+	PoissonFill(rng, mu, data)
+	return data
+}
+
+// PoissonFill fills dst with Poisson distributed values
+func PoissonFill(rng RngState, mu float64, dst []uint64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_poisson_fill(rng.ptr(), C.double(mu),
+		(*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+}
+
+// PoissonPdf computes the probability p(k) of obtaining k from a Poisson
+// distribution with mean mu.
+func PoissonPdf(k uint32, mu float64) float64 {
+	return float64(C.gsl_ran_poisson_pdf(C.uint(k), C.double(mu)))
+}
+
+// Bernoulli returns either 0 or 1, the result of a Bernoulli trial with
+// probability p. The probability distribution for a Bernoulli trial is
+// p(0) = 1 − p and p(1) = p.
+func Bernoulli(rng RngState, p float64) uint64 {
+	defer runtime.KeepAlive(rng.owner)
+	return uint64(C.gsl_ran_bernoulli(rng.ptr(), C.double(p)))
+}
+
+// BernoulliSlice generates a slice of length n of Bernoulli distributed values
+func BernoulliSlice(rng RngState, p float64, n uint64) []uint64 {
+	data := make([]uint64, n)
+	BernoulliFill(rng, p, data)
+	return data
+}
+
+// BernoulliFill fills dst with Bernoulli distributed values
+func BernoulliFill(rng RngState, p float64, dst []uint64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_bernoulli_fill(rng.ptr(), C.double(p),
+		(*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+}
+
+// BernoulliPdf computes the probability p(k) of obtaining k from a Bernoulli
+// distribution with probability parameter p.
+func BernoulliPdf(k uint32, p float64) float64 {
+	return float64(C.gsl_ran_bernoulli_pdf(C.uint(k), C.double(p)))
+}
+
+// Binomial returns a random integer from the binomial distribution, the
+// number of successes in nTrials independent trials with probability p.
+func Binomial(rng RngState, p float64, nTrials uint32) uint64 {
+	defer runtime.KeepAlive(rng.owner)
+	return uint64(C.gsl_ran_binomial(rng.ptr(), C.double(p), C.uint(nTrials)))
+}
+
+// BinomialSlice generates a slice of length n of binomially distributed values
+func BinomialSlice(rng RngState, p float64, nTrials uint32, n uint64) []uint64 {
+	data := make([]uint64, n)
+	BinomialFill(rng, p, nTrials, data)
+	return data
+}
+
+// BinomialFill fills dst with binomially distributed values
+func BinomialFill(rng RngState, p float64, nTrials uint32, dst []uint64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_binomial_fill(rng.ptr(), C.double(p), C.uint(nTrials),
+		(*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+}
+
+// BinomialPdf computes the probability p(k) of obtaining k from a binomial
+// distribution with parameters p and nTrials.
+func BinomialPdf(k uint32, p float64, nTrials uint32) float64 {
+	return float64(C.gsl_ran_binomial_pdf(C.uint(k), C.double(p), C.uint(nTrials)))
+}
+
+// Multinomial returns a random sample from the multinomial distribution
+// formed by nTrials trials from an underlying distribution p. The length
+// K of p determines the number of possible outcomes and the returned
+// slice of counts has the same length. The probabilities p need not be
+// normalized.
+func Multinomial(rng RngState, nTrials uint32, p []float64) []uint64 {
+	defer runtime.KeepAlive(rng.owner)
+	if len(p) == 0 {
+		return []uint64{}
+	}
+	counts := make([]C.uint, len(p))
+	C.gsl_ran_multinomial(rng.ptr(), C.size_t(len(p)), C.uint(nTrials),
+		(*C.double)(&p[0]), &counts[0])
+
+	data := make([]uint64, len(p))
+	for i, c := range counts {
+		data[i] = uint64(c)
+	}
+	return data
+}
+
+// MultinomialSlice generates a slice of length n of multinomial samples
+func MultinomialSlice(rng RngState, nTrials uint32, p []float64,
+	n uint64) [][]uint64 {
+	k := uint64(len(p))
+	buf := make([]uint64, k*n)
+	if k > 0 {
+		MultinomialFill(rng, nTrials, p, buf)
+	}
+
+	data := make([][]uint64, n)
+	for i := uint64(0); i < n; i++ {
+		data[i] = buf[i*k : (i+1)*k : (i+1)*k]
+	}
+	return data
+}
+
+// MultinomialFill fills dst with consecutive multinomial samples of length
+// len(p). The length of dst has to be a multiple of len(p).
+func MultinomialFill(rng RngState, nTrials uint32, p []float64,
+	dst []uint64) {
+	k := len(p)
+	if k == 0 || len(dst)%k != 0 {
+		panic(fmt.Sprintf("Length %d of dst is not a multiple of %d.",
+			len(dst), k))
+	}
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	counts := make([]C.uint, k)
+	C.ran_multinomial_fill(rng.ptr(), C.size_t(k), C.uint(nTrials),
+		(*C.double)(&p[0]), &counts[0], (*C.uint64_t)(unsafe.Pointer(&dst[0])),
+		C.size_t(len(dst)/k))
+}
+
+// multinomialCounts converts a slice of counts into the representation
+// expected by gsl. ok is false if a count exceeds math.MaxUint32 and can't
+// be represented.
+func multinomialCounts(counts []uint64) (cCounts []C.uint, ok bool) {
+	cCounts = make([]C.uint, len(counts))
+	for i, c := range counts {
+		if c > math.MaxUint32 {
+			return nil, false
+		}
+		cCounts[i] = C.uint(c)
+	}
+	return cCounts, true
+}
+
+// MultinomialPdf computes the probability P(n_1, n_2, ..., n_K) of
+// sampling counts from a multinomial distribution with parameters p.
+// The slices p and counts need to have the same length. Counts above
+// math.MaxUint32, which gsl can't represent, have probability zero.
+func MultinomialPdf(p []float64, counts []uint64) float64 {
+	if len(p) == 0 || len(p) != len(counts) {
+		return 0
+	}
+	cCounts, ok := multinomialCounts(counts)
+	if !ok {
+		return 0
+	}
+	return float64(C.gsl_ran_multinomial_pdf(C.size_t(len(p)),
+		(*C.double)(&p[0]), &cCounts[0]))
+}
+
+// MultinomialLnPdf returns the logarithm of the probability for the
+// multinomial distribution P(n_1, n_2, ..., n_K) with parameters p.
+// Counts above math.MaxUint32 give -Inf like for MultinomialPdf.
+func MultinomialLnPdf(p []float64, counts []uint64) float64 {
+	if len(p) == 0 || len(p) != len(counts) {
+		return math.Inf(-1)
+	}
+	cCounts, ok := multinomialCounts(counts)
+	if !ok {
+		return math.Inf(-1)
+	}
+	return float64(C.gsl_ran_multinomial_lnpdf(C.size_t(len(p)),
+		(*C.double)(&p[0]), &cCounts[0]))
+}
+
+// NegativeBinomial returns a random integer from the negative binomial
+// distribution, the number of failures occurring before nSuccess successes
+// in independent trials with probability p of success. Note that nSuccess
+// is not required to be an integer.
+func NegativeBinomial(rng RngState, p, nSuccess float64) uint64 {
+	defer runtime.KeepAlive(rng.owner)
+	return uint64(C.gsl_ran_negative_binomial(rng.ptr(), C.double(p),
+		C.double(nSuccess)))
+}
+
+// NegativeBinomialSlice generates a slice of length n of negative binomial
+// distributed values
+func NegativeBinomialSlice(rng RngState, p, nSuccess float64,
+	n uint64) []uint64 {
+	data := make([]uint64, n)
+	NegativeBinomialFill(rng, p, nSuccess, data)
+	return data
+}
+
+// NegativeBinomialFill fills dst with negative binomial distributed values
+func NegativeBinomialFill(rng RngState, p, nSuccess float64, dst []uint64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_negative_binomial_fill(rng.ptr(), C.double(p), C.double(nSuccess),
+		(*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+}
+
+// NegativeBinomialPdf computes the probability p(k) of obtaining k from a
+// negative binomial distribution with parameters p and nSuccess.
+func NegativeBinomialPdf(k uint32, p, nSuccess float64) float64 {
+	return float64(C.gsl_ran_negative_binomial_pdf(C.uint(k), C.double(p),
+		C.double(nSuccess)))
+}
+
+// Pascal returns a random integer from the Pascal distribution. The
+// Pascal distribution is simply a negative binomial distribution with an
+// integer value of nSuccess.
+func Pascal(rng RngState, p float64, nSuccess uint32) uint64 {
+	defer runtime.KeepAlive(rng.owner)
+	return uint64(C.gsl_ran_pascal(rng.ptr(), C.double(p), C.uint(nSuccess)))
+}
+
+// PascalSlice generates a slice of length n of Pascal distributed values
+func PascalSlice(rng RngState, p float64, nSuccess uint32, n uint64) []uint64 {
+	data := make([]uint64, n)
+	PascalFill(rng, p, nSuccess, data)
+	return data
+}
+
+// PascalFill fills dst with Pascal distributed values
+func PascalFill(rng RngState, p float64, nSuccess uint32, dst []uint64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_pascal_fill(rng.ptr(), C.double(p), C.uint(nSuccess),
+		(*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+}
+
+// PascalPdf computes the probability p(k) of obtaining k from a Pascal
+// distribution with parameters p and nSuccess.
+func PascalPdf(k uint32, p float64, nSuccess uint32) float64 {
+	return float64(C.gsl_ran_pascal_pdf(C.uint(k), C.double(p),
+		C.uint(nSuccess)))
+}
+
+// Geometric returns a random integer from the geometric distribution, the
+// number of independent trials with probability p until the first success.
+// The returned value is always larger or equal to 1.
+func Geometric(rng RngState, p float64) uint64 {
+	defer runtime.KeepAlive(rng.owner)
+	return uint64(C.gsl_ran_geometric(rng.ptr(), C.double(p)))
+}
+
+// GeometricSlice generates a slice of length n of geometrically distributed
+// values
+func GeometricSlice(rng RngState, p float64, n uint64) []uint64 {
+	data := make([]uint64, n)
+	GeometricFill(rng, p, data)
+	return data
+}
+
+// GeometricFill fills dst with geometrically distributed values
+func GeometricFill(rng RngState, p float64, dst []uint64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_geometric_fill(rng.ptr(), C.double(p),
+		(*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+}
+
+// GeometricPdf computes the probability p(k) of obtaining k from a geometric
+// distribution with probability parameter p.
+func GeometricPdf(k uint32, p float64) float64 {
+	return float64(C.gsl_ran_geometric_pdf(C.uint(k), C.double(p)))
+}
+
+// Hypergeometric returns a random integer from the hypergeometric
+// distribution, the number of elements of type 1 obtained when drawing t
+// samples without replacement from a population with n1 elements of type 1
+// and n2 elements of type 2.
+func Hypergeometric(rng RngState, n1, n2, t uint32) uint64 {
+	defer runtime.KeepAlive(rng.owner)
+	return uint64(C.gsl_ran_hypergeometric(rng.ptr(), C.uint(n1), C.uint(n2),
+		C.uint(t)))
+}
+
+// HypergeometricSlice generates a slice of length n of hypergeometrically
+// distributed values
+func HypergeometricSlice(rng RngState, n1, n2, t uint32, n uint64) []uint64 {
+	data := make([]uint64, n)
+	HypergeometricFill(rng, n1, n2, t, data)
+	return data
+}
+
+// HypergeometricFill fills dst with hypergeometrically distributed values
+func HypergeometricFill(rng RngState, n1, n2, t uint32, dst []uint64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_hypergeometric_fill(rng.ptr(), C.uint(n1), C.uint(n2), C.uint(t),
+		(*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+}
+
+// HypergeometricPdf computes the probability p(k) of obtaining k from a
+// hypergeometric distribution with parameters n1, n2 and t.
+func HypergeometricPdf(k, n1, n2, t uint32) float64 {
+	return float64(C.gsl_ran_hypergeometric_pdf(C.uint(k), C.uint(n1),
+		C.uint(n2), C.uint(t)))
+}
+
+// Logarithmic returns a random integer from the logarithmic distribution
+// with parameter p. The returned value is always larger or equal to 1.
+func Logarithmic(rng RngState, p float64) uint64 {
+	defer runtime.KeepAlive(rng.owner)
+	return uint64(C.gsl_ran_logarithmic(rng.ptr(), C.double(p)))
+}
+
+// LogarithmicSlice generates a slice of length n of logarithmically
+// distributed values
+func LogarithmicSlice(rng RngState, p float64, n uint64) []uint64 {
+	data := make([]uint64, n)
+	LogarithmicFill(rng, p, data)
+	return data
+}
+
+// LogarithmicFill fills dst with logarithmically distributed values
+func LogarithmicFill(rng RngState, p float64, dst []uint64) {
+	if len(dst) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.ran_logarithmic_fill(rng.ptr(), C.double(p),
+		(*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+}
+
+// LogarithmicPdf computes the probability p(k) of obtaining k from a
+// logarithmic distribution with probability parameter p.
+func LogarithmicPdf(k uint32, p float64) float64 {
+	return float64(C.gsl_ran_logarithmic_pdf(C.uint(k), C.double(p)))
+}
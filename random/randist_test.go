@@ -6,6 +6,7 @@
 package random
 
 import (
+  "math"
   "testing"
 
   "github.com/haskelladdict/gsl/stats"
@@ -26,52 +27,52 @@ func Test_randist_1(t *testing.T) {
 
   // gaussian
   gaus := stats.FloatSlice(GaussianSlice(rng_state, 1, numSamples))
-  if gaus.Mean(1) > margin {
+  if mean(t, gaus) > margin {
     t.Error("randist: Mean of gaussian distribution is not 0.")
   }
 
-  if (gaus.Sd(1) - 1.0) > margin {
+  if (sd(t, gaus) - 1.0) > margin {
     t.Error("randist: Stdev of gaussian distribution is not 1.")
   }
 
   // gaussian ziggurat
   gaus_zig := stats.FloatSlice(GaussianZigguratSlice(rng_state, 1, numSamples))
-  if gaus_zig.Mean(1) > margin {
+  if mean(t, gaus_zig) > margin {
     t.Error("randist: Mean of gaussian_zig distribution is not 0.")
   }
 
-  if (gaus_zig.Sd(1) - 1.0) > margin {
+  if (sd(t, gaus_zig) - 1.0) > margin {
     t.Error("randist: Stdev of gaussian_zig distribution is not 1.")
   }
 
   // gaussian ratio method
   gaus_rat := stats.FloatSlice(GaussianRatioMethodSlice(rng_state, 1, numSamples))
-  if gaus_rat.Mean(1) > margin {
+  if mean(t, gaus_rat) > margin {
     t.Error("randist: Mean of gaussian_rat distribution is not 0.")
   }
 
-  if (gaus_rat.Sd(1) - 1.0) > margin {
+  if (sd(t, gaus_rat) - 1.0) > margin {
     t.Error("randist: Stdev of gaussian_rat distribution is not 1.")
   }
 
   // unit gaussian
   ugaus := stats.FloatSlice(UgaussianSlice(rng_state, numSamples))
-  if ugaus.Mean(1) > margin {
+  if mean(t, ugaus) > margin {
     t.Error("randist: Mean of ugaussian distribution is not 0.")
   }
 
-  if (ugaus.Sd(1) - 1.0) > margin {
-    t.Error("randist: Stdev of ugaussian distribution is not 1.", ugaus.Sd(1))
+  if (sd(t, ugaus) - 1.0) > margin {
+    t.Error("randist: Stdev of ugaussian distribution is not 1.", sd(t, ugaus))
   }
 
   // unit gaussian ratio method
   ugaus_rat := stats.FloatSlice(UgaussianRatioMethodSlice(rng_state, numSamples))
-  if ugaus_rat.Mean(1) > margin {
+  if mean(t, ugaus_rat) > margin {
     t.Error("randist: Mean of ugaussian_rat distribution is not 0.")
   }
 
-  if (ugaus_rat.Sd(1) - 1.0) > margin {
-    t.Error("randist: Stdev of ugaussian_rat distribution is not 1.", ugaus_rat.Sd(1))
+  if (sd(t, ugaus_rat) - 1.0) > margin {
+    t.Error("randist: Stdev of ugaussian_rat distribution is not 1.", sd(t, ugaus_rat))
   }
 }
 
@@ -121,19 +122,213 @@ func Test_randist_3(t *testing.T) {
     first[i], second[i] = v[0], v[1]
   }
 
-  if first.Mean(1) > margin {
+  if mean(t, first) > margin {
     t.Error("randist: Mean of 1st component of bivariate gaussian is not 0.")
   }
 
-  if second.Mean(1) > margin {
+  if mean(t, second) > margin {
     t.Error("randist: Mean of 2nd component of bivariate gaussian is not 0.")
   }
 
-  if (first.Sd(1) - 1) > margin {
+  if (sd(t, first) - 1) > margin {
     t.Error("randist: Std of 1st component of bivariate gaussian is not 0.")
   }
 
-  if (second.Sd(1) - 1) > margin {
+  if (sd(t, second) - 1) > margin {
     t.Error("randist: Std of 2nd component of bivariate gaussian is not 0.")
   }
 }
+
+// test set 4
+func Test_randist_4(t *testing.T) {
+
+  // test discrete distributions
+  rng_type := Ranlxd2
+  rng_state := Rng_alloc(rng_type)
+
+  // poisson
+  pois := PoissonSlice(rng_state, 3, numSamples)
+  pois_slice := make(stats.FloatSlice, numSamples)
+  for i, v := range pois {
+    pois_slice[i] = float64(v)
+  }
+  if math.Abs(mean(t, pois_slice)-3) > 1e-2 {
+    t.Error("randist: Mean of poisson distribution is not 3.")
+  }
+
+  // binomial
+  binom := BinomialSlice(rng_state, 0.3, 10, numSamples)
+  binom_slice := make(stats.FloatSlice, numSamples)
+  for i, v := range binom {
+    if v > 10 {
+      t.Error("randist: binomial variate larger than number of trials.")
+    }
+    binom_slice[i] = float64(v)
+  }
+  if math.Abs(mean(t, binom_slice)-3) > 1e-2 {
+    t.Error("randist: Mean of binomial distribution is not 3.")
+  }
+
+  // geometric and logarithmic variates are always >= 1
+  for _, v := range GeometricSlice(rng_state, 0.5, 1000) {
+    if v < 1 {
+      t.Error("randist: geometric variate smaller than 1.")
+    }
+  }
+
+  for _, v := range LogarithmicSlice(rng_state, 0.5, 1000) {
+    if v < 1 {
+      t.Error("randist: logarithmic variate smaller than 1.")
+    }
+  }
+
+  // multinomial
+  p := []float64{0.2, 0.3, 0.5}
+  for _, counts := range MultinomialSlice(rng_state, 20, p, 1000) {
+    sum := uint64(0)
+    for _, c := range counts {
+      sum += c
+    }
+    if len(counts) != len(p) || sum != 20 {
+      t.Error("randist: multinomial counts do not add up to number of trials.")
+    }
+  }
+
+  // pdfs
+  pois_sum := 0.0
+  for k := uint32(0); k < 100; k++ {
+    pois_sum += PoissonPdf(k, 3)
+  }
+  if math.Abs(pois_sum-1) > 1e-12 {
+    t.Error("randist: poisson pdf is not normalized", pois_sum)
+  }
+
+  if !util.FloatEqual(BernoulliPdf(1, 0.3), 0.3) {
+    t.Error("randist: error computing bernoulli pdf")
+  }
+
+  if !util.FloatEqual(GeometricPdf(2, 0.5), 0.25) {
+    t.Error("randist: error computing geometric pdf")
+  }
+
+  multi_pdf := MultinomialPdf([]float64{0.5, 0.5}, []uint64{1, 1})
+  if math.Abs(multi_pdf-0.5) > 1e-12 {
+    t.Error("randist: error computing multinomial pdf", multi_pdf)
+  }
+
+  // counts gsl can't represent must not be truncated to {0, 1}
+  huge := []uint64{1 << 32, 1}
+  if MultinomialPdf([]float64{0.5, 0.5}, huge) != 0 ||
+    !math.IsInf(MultinomialLnPdf([]float64{0.5, 0.5}, huge), -1) {
+    t.Error("randist: multinomial pdf accepted count above MaxUint32")
+  }
+}
+
+// test set 5
+func Test_randist_5(t *testing.T) {
+
+  // test remaining continuous distributions
+  rng_type := Ranlxd2
+  rng_state := Rng_alloc(rng_type)
+
+  // the mean of a chi-squared distribution equals nu
+  chisq := stats.FloatSlice(ChisqSlice(rng_state, 4, numSamples))
+  if math.Abs(mean(t, chisq)-4) > 2e-2 {
+    t.Error("randist: Mean of chisq distribution is not 4.")
+  }
+
+  // the mean of a beta distribution is a/(a+b)
+  beta := stats.FloatSlice(BetaSlice(rng_state, 2, 3, numSamples))
+  if math.Abs(mean(t, beta)-0.4) > 1e-2 {
+    t.Error("randist: Mean of beta distribution is not 0.4.")
+  }
+
+  // the mean of an erlang distribution is a*n
+  erlang := stats.FloatSlice(ErlangSlice(rng_state, 2, 3, numSamples))
+  if math.Abs(mean(t, erlang)-6) > 2e-2 {
+    t.Error("randist: Mean of erlang distribution is not 6.")
+  }
+
+  // pareto variates are always >= b
+  for _, v := range ParetoSlice(rng_state, 3, 2, 1000) {
+    if v < 2 {
+      t.Error("randist: pareto variate smaller than scale parameter.")
+    }
+  }
+
+  // pdfs
+  if !util.FloatEqual(ErlangPdf(1, 1, 1), ExponentialPdf(1, 1)) {
+    t.Error("randist: erlang pdf of order 1 is not exponential.")
+  }
+
+  if !util.FloatEqual(ChisqPdf(2, 2), GammaPdf(2, 1, 2)) {
+    t.Error("randist: chisq pdf does not match gamma pdf.")
+  }
+}
+
+// test set 6
+func Test_randist_6(t *testing.T) {
+
+  // test spherical vector and multivariate distributions
+  rng_type := Ranlxd2
+  rng_state := Rng_alloc(rng_type)
+
+  for _, v := range Dir2dSlice(rng_state, 1000) {
+    if math.Abs(v[0]*v[0]+v[1]*v[1]-1) > 1e-12 {
+      t.Error("randist: 2d direction vector is not normalized.")
+    }
+  }
+
+  for _, v := range Dir3dSlice(rng_state, 1000) {
+    if math.Abs(v[0]*v[0]+v[1]*v[1]+v[2]*v[2]-1) > 1e-12 {
+      t.Error("randist: 3d direction vector is not normalized.")
+    }
+  }
+
+  for _, v := range DirNdSlice(rng_state, 5, 1000) {
+    norm := 0.0
+    for _, x := range v {
+      norm += x * x
+    }
+    if len(v) != 5 || math.Abs(norm-1) > 1e-12 {
+      t.Error("randist: nd direction vector is not normalized.")
+    }
+  }
+
+  // dirichlet
+  alpha := []float64{1, 2, 3}
+  for _, theta := range DirichletSlice(rng_state, alpha, 1000) {
+    sum := 0.0
+    for _, x := range theta {
+      sum += x
+    }
+    if len(theta) != len(alpha) || math.Abs(sum-1) > 1e-12 {
+      t.Error("randist: dirichlet variates do not sum to 1.")
+    }
+  }
+
+  // multivariate gaussian with unit covariance
+  mu := []float64{1, 2}
+  L := []float64{1, 0, 0, 1}
+  samples, err := MultivariateGaussianSlice(rng_state, mu, L, numSamples)
+  if err != nil {
+    t.Fatal("randist: failed to sample multivariate gaussian", err)
+  }
+  first := make(stats.FloatSlice, numSamples)
+  second := make(stats.FloatSlice, numSamples)
+  for i, v := range samples {
+    first[i], second[i] = v[0], v[1]
+  }
+  if math.Abs(mean(t, first)-1) > 1e-2 || math.Abs(mean(t, second)-2) > 1e-2 {
+    t.Error("randist: Mean of multivariate gaussian does not match mu.")
+  }
+
+  logPdf, err := MultivariateGaussianLogPdf(mu, mu, L)
+  if err != nil || math.Abs(logPdf+math.Log(2*math.Pi)) > 1e-12 {
+    t.Error("randist: error computing multivariate gaussian log pdf", logPdf)
+  }
+
+  if _, err := MultivariateGaussian(rng_state, mu, []float64{1}); err == nil {
+    t.Error("randist: failed to detect dimension mismatch.")
+  }
+}
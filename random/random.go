@@ -8,16 +8,38 @@ package random
 // #cgo CFLAGS: -std=c99 -O2
 // #cgo pkg-config: gsl
 // #include <gsl/gsl_rng.h>
+// #include "fill.h"
 // #include "random_wrap.h"
 import "C"
 
 import (
+  "errors"
   "fmt"
+  "runtime"
   "unsafe"
+
+  "github.com/haskelladdict/gsl"
 )
 
-// RngState stores the random number generator state
+// ErrFreed is returned when using a generator after it has been freed.
+// Functions without an error result panic with ErrFreed instead. Each of
+// these methods of RngState and QrngState has a variant carrying an
+// additional E suffix, e.g. GetE, which returns ErrFreed instead of
+// panicking. A freed generator is never passed on to the C part of gsl.
+// DiscreteTable reports the use of a freed table the same way.
+var ErrFreed = errors.New("Generator has already been freed.")
+
+// RngState is a handle to a random number generator. It only contains a
+// pointer to an internal owner of the gsl generator so copying an RngState
+// is cheap and all copies refer to the same generator. The generator is
+// released once no RngState referring to it is reachable anymore or
+// explicitly via Free or Close, which affects all copies.
 type RngState struct {
+  owner *rngOwner
+}
+
+// rngOwner owns the gsl generator shared by all copies of an RngState
+type rngOwner struct {
   state *C.gsl_rng
 }
 
@@ -109,25 +131,114 @@ func DefaultSeed() uint64 {
   return uint64(C.gsl_rng_default_seed)
 }
 
-// Alloc creates a new random number generator and returs
-// it as a RngState object.
-// XXX: using SetFinalizer to release the generator doesn't work
-// properly since the go runtime destroys the object prematurely.
-func Rng_alloc(rngType RngType) RngState {
-  state := RngState{C.gsl_rng_alloc(rngType.rng)}
+// newRngState wraps the gsl generator state into an RngState whose
+// generator is freed automatically once it becomes unreachable
+func newRngState(state *C.gsl_rng) RngState {
+  owner := &rngOwner{state}
+  runtime.SetFinalizer(owner, (*rngOwner).free)
+  return RngState{owner}
+}
 
-  // make sure we get rid of any memory associated with the
-  // rng within gsl
-  //runtime.SetFinalizer(&state,
-  //  func(rng *RngState) { C.gsl_rng_free(rng.state) })
+// free releases the gsl generator. It is safe to call free more than once.
+func (o *rngOwner) free() {
+  if o.state == nil {
+    return
+  }
+  if isGoRng(o.state) {
+    releaseGoRng(unsafe.Pointer(C.gsl_rng_state(o.state)))
+  }
+  C.gsl_rng_free(o.state)
+  o.state = nil // to make sure we don't use after freeing
+}
+
+// get returns the underlying gsl generator or ErrFreed if it has been
+// freed or was never allocated
+func (s RngState) get() (*C.gsl_rng, error) {
+  if s.owner == nil || s.owner.state == nil {
+    return nil, ErrFreed
+  }
+  return s.owner.state, nil
+}
+
+// ptr returns the underlying gsl generator and panics with ErrFreed if it
+// has been freed. Callers need to keep s.owner alive until the C call
+// using the returned pointer has finished.
+func (s RngState) ptr() *C.gsl_rng {
+  state, err := s.get()
+  if err != nil {
+    panic(err)
+  }
   return state
 }
 
+// protect calls f with the underlying gsl generator and returns the
+// *gsl.Error raised by gsl during the call, if any. f is not called and
+// ErrFreed is returned if s has been freed.
+func (s RngState) protect(f func(state *C.gsl_rng)) error {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return err
+  }
+  return gsl.Protect(func() {
+    f(state)
+  })
+}
+
+// Catch calls f and returns the *gsl.Error raised by gsl while f runs or
+// ErrFreed if f panicked since it used a freed generator; other panics are
+// passed on. Catch provides an error result for functions without an E
+// variant, in particular the samplers of the random distributions, e.g.,
+//
+//   var x float64
+//   err := random.Catch(func() {
+//     x = random.Gaussian(rng, 1.0)
+//   })
+//
+// These samplers have no E variants of their own since, with the exception
+// of UniformInt, the gsl samplers never raise gsl errors and a freed
+// generator is the only failure they report.
+func Catch(f func()) (err error) {
+  defer func() {
+    if r := recover(); r != nil {
+      if r != ErrFreed {
+        panic(r)
+      }
+      err = ErrFreed
+    }
+  }()
+  return gsl.Protect(f)
+}
+
+// Rng_alloc creates a new random number generator and returns
+// it as a RngState object. The memory associated with the generator
+// within gsl is released automatically once the RngState (and all its
+// copies) are garbage collected. Use Free or Close to release it
+// right away.
+func Rng_alloc(rngType RngType) RngState {
+  return newRngState(C.gsl_rng_alloc(rngType.rng))
+}
+
 // Free releases all the memory associated with the generator
-// within the C part of gsl
-func (s *RngState) Free() {
-  C.gsl_rng_free(s.state)
-  s.state = nil // to make sure we don't use after freeing
+// within the C part of gsl. Free affects all copies of s and it is
+// safe to call Free more than once.
+func (s RngState) Free() {
+  if s.owner == nil {
+    return
+  }
+  s.owner.free()
+  runtime.SetFinalizer(s.owner, nil)
+}
+
+// Close releases the generator like Free but returns ErrFreed if the
+// generator has already been freed. It implements the io.Closer
+// interface.
+func (s RngState) Close() error {
+  if _, err := s.get(); err != nil {
+    return err
+  }
+  s.Free()
+  return nil
 }
 
 // Set initializes (or ‘seeds’) the random number generator. If the
@@ -146,8 +257,17 @@ func (s *RngState) Free() {
 // Note that the most generators only accept 32-bit seeds, with higher
 // values being reduced modulo 2^32 . For generators with smaller ranges
 // the maximum seed value will typically be lower.
-func (s *RngState) Set(seed uint64) {
-  C.gsl_rng_set(s.state, C.ulong(seed))
+func (s RngState) Set(seed uint64) {
+  defer runtime.KeepAlive(s.owner)
+  C.gsl_rng_set(s.ptr(), C.ulong(seed))
+}
+
+// SetE is like Set but returns ErrFreed instead of panicking if s has been
+// freed.
+func (s RngState) SetE(seed uint64) error {
+  return s.protect(func(state *C.gsl_rng) {
+    C.gsl_rng_set(state, C.ulong(seed))
+  })
 }
 
 // EnvSetup reads the environment variables GSL_RNG_TYPE and GSL_RNG_SEED
@@ -164,45 +284,136 @@ func EnvSetup() RngType {
 // maximum values depend on the algorithm used, but all integers in the
 // range [min,max] are equally likely. The values of min and max can be
 // determined using the auxiliary functions Max and Min.
-func (s *RngState) Get() uint64 {
-  return uint64(C.gsl_rng_get(s.state))
+func (s RngState) Get() uint64 {
+  defer runtime.KeepAlive(s.owner)
+  return uint64(C.gsl_rng_get(s.ptr()))
+}
+
+// GetE is like Get but returns ErrFreed instead of panicking if s has been
+// freed.
+func (s RngState) GetE() (uint64, error) {
+  var value C.ulong
+  err := s.protect(func(state *C.gsl_rng) {
+    value = C.gsl_rng_get(state)
+  })
+  return uint64(value), err
 }
 
 // GetSlice is a convenience function returning a slice of random
 // uint64 each between min and max of the selected random number
 // generator.
-func (s *RngState) GetSlice(n uint64) []uint64 {
+func (s RngState) GetSlice(n uint64) []uint64 {
   slice := make([]uint64, n)
-  for i := uint64(0); i < n; i++ {
-    slice[i] = s.Get()
-  }
+  s.GetFill(slice)
   return slice
 }
 
+// GetSliceE is like GetSlice but returns ErrFreed instead of panicking if
+// s has been freed.
+func (s RngState) GetSliceE(n uint64) ([]uint64, error) {
+  slice := make([]uint64, n)
+  if err := s.GetFillE(slice); err != nil {
+    return nil, err
+  }
+  return slice, nil
+}
+
+// GetFill fills dst with random integers between min and max of the
+// generator using a single call into gsl.
+func (s RngState) GetFill(dst []uint64) {
+  if len(dst) == 0 {
+    return
+  }
+  defer runtime.KeepAlive(s.owner)
+  C.rng_get_fill(s.ptr(), (*C.uint64_t)(unsafe.Pointer(&dst[0])),
+    C.size_t(len(dst)))
+}
+
+// GetFillE is like GetFill but returns ErrFreed instead of panicking if s
+// has been freed.
+func (s RngState) GetFillE(dst []uint64) error {
+  return s.protect(func(state *C.gsl_rng) {
+    if len(dst) > 0 {
+      C.rng_get_fill(state, (*C.uint64_t)(unsafe.Pointer(&dst[0])),
+        C.size_t(len(dst)))
+    }
+  })
+}
+
 // Uniform returns a double precision floating point number
 // uniformly distributed in the range [0,1). The range includes 0.0
 // but excludes 1.0.
-func (s *RngState) Uniform() float64 {
-  return float64(C.gsl_rng_uniform(s.state))
+func (s RngState) Uniform() float64 {
+  defer runtime.KeepAlive(s.owner)
+  return float64(C.gsl_rng_uniform(s.ptr()))
+}
+
+// UniformE is like Uniform but returns ErrFreed instead of panicking if s
+// has been freed.
+func (s RngState) UniformE() (float64, error) {
+  var value C.double
+  err := s.protect(func(state *C.gsl_rng) {
+    value = C.gsl_rng_uniform(state)
+  })
+  return float64(value), err
 }
 
 // UnformSlice is a convenience function returning a slice of length N
 // of uniform random floats in [0,1).
-func (s *RngState) UniformSlice(n uint64) []float64 {
+func (s RngState) UniformSlice(n uint64) []float64 {
   slice := make([]float64, n)
-  for i := uint64(0); i < n; i++ {
-    slice[i] = s.Uniform()
-  }
+  s.UniformFill(slice)
   return slice
 }
 
+// UniformSliceE is like UniformSlice but returns ErrFreed instead of
+// panicking if s has been freed.
+func (s RngState) UniformSliceE(n uint64) ([]float64, error) {
+  slice := make([]float64, n)
+  if err := s.UniformFillE(slice); err != nil {
+    return nil, err
+  }
+  return slice, nil
+}
+
+// UniformFill fills dst with uniform random floats in [0,1) using a
+// single call into gsl.
+func (s RngState) UniformFill(dst []float64) {
+  if len(dst) == 0 {
+    return
+  }
+  defer runtime.KeepAlive(s.owner)
+  C.rng_uniform_fill(s.ptr(), (*C.double)(&dst[0]), C.size_t(len(dst)))
+}
+
+// UniformFillE is like UniformFill but returns ErrFreed instead of
+// panicking if s has been freed.
+func (s RngState) UniformFillE(dst []float64) error {
+  return s.protect(func(state *C.gsl_rng) {
+    if len(dst) > 0 {
+      C.rng_uniform_fill(state, (*C.double)(&dst[0]), C.size_t(len(dst)))
+    }
+  })
+}
+
 // UniformPos function returns a positive double precision floating point
 // number uniformly distributed in the range (0,1), excluding both 0.0 and
 // 1.0. The number is obtained by sampling the generator with the algorithm
 // of Uniform until a non-zero value is obtained. You can use this function
 // if you need to avoid a singularity at 0.0.
-func (s *RngState) UniformPos() float64 {
-  return float64(C.gsl_rng_uniform_pos(s.state))
+func (s RngState) UniformPos() float64 {
+  defer runtime.KeepAlive(s.owner)
+  return float64(C.gsl_rng_uniform_pos(s.ptr()))
+}
+
+// UniformPosE is like UniformPos but returns ErrFreed instead of panicking
+// if s has been freed.
+func (s RngState) UniformPosE() (float64, error) {
+  var value C.double
+  err := s.protect(func(state *C.gsl_rng) {
+    value = C.gsl_rng_uniform_pos(state)
+  })
+  return float64(value), err
 }
 
 // UniformInt returns a random integer from 0 to n − 1 inclusive by scaling
@@ -219,26 +430,82 @@ func (s *RngState) UniformPos() float64 {
 // the maximal integer range and zero minimum value, such as gsl_rng_ranlxd1,
 // gsl_rng_mt19937 or gsl_rng_taus, and sample it directly using gsl_rng_get.
 // The range of each can be found with the help of auxiliary sections.
-func (s *RngState) UniformInt(limit uint64) uint64 {
-  return uint64(C.gsl_rng_uniform_int(s.state, C.ulong(limit)))
+func (s RngState) UniformInt(limit uint64) uint64 {
+  defer runtime.KeepAlive(s.owner)
+  return uint64(C.gsl_rng_uniform_int(s.ptr(), C.ulong(limit)))
+}
+
+// UniformIntE is like UniformInt but returns a *gsl.Error with code
+// gsl.EINVAL instead of zero if limit is zero or exceeds the range of the
+// generator and ErrFreed instead of panicking if s has been freed.
+func (s RngState) UniformIntE(limit uint64) (uint64, error) {
+  var value C.ulong
+  err := s.protect(func(state *C.gsl_rng) {
+    value = C.gsl_rng_uniform_int(state, C.ulong(limit))
+  })
+  return uint64(value), err
 }
 
 // UnformIntSlice is a convenience function returning a slice of length N
 // of uniform random integers in [0, n - 1].
-func (s *RngState) UniformIntSlice(limit uint64, n uint64) []uint64 {
+func (s RngState) UniformIntSlice(limit uint64, n uint64) []uint64 {
   slice := make([]uint64, n)
-  for i := uint64(0); i < n; i++ {
-    slice[i] = s.UniformInt(limit)
-  }
+  s.UniformIntFill(limit, slice)
   return slice
 }
 
+// UniformIntFill fills dst with uniform random integers in [0, limit - 1]
+// using a single call into gsl.
+func (s RngState) UniformIntFill(limit uint64, dst []uint64) {
+  if len(dst) == 0 {
+    return
+  }
+  defer runtime.KeepAlive(s.owner)
+  C.rng_uniform_int_fill(s.ptr(), C.ulong(limit),
+    (*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+}
+
+// UniformIntFillE is like UniformIntFill but returns an error if limit is
+// invalid for the generator or s has been freed. See UniformIntE for
+// details.
+func (s RngState) UniformIntFillE(limit uint64, dst []uint64) error {
+  return s.protect(func(state *C.gsl_rng) {
+    if len(dst) > 0 {
+      C.rng_uniform_int_fill(state, C.ulong(limit),
+        (*C.uint64_t)(unsafe.Pointer(&dst[0])), C.size_t(len(dst)))
+    }
+  })
+}
+
+// UniformIntSliceE is like UniformIntSlice but returns an error if limit
+// is invalid for the generator or s has been freed. See UniformIntE for
+// details.
+func (s RngState) UniformIntSliceE(limit uint64, n uint64) ([]uint64, error) {
+  slice := make([]uint64, n)
+  if err := s.UniformIntFillE(limit, slice); err != nil {
+    return nil, err
+  }
+  return slice, nil
+}
+
 // RNG auxiliary functions
 
 // Name returns the name of the random number generator or
 // a rng type
-func (s *RngState) Name() string {
-  return C.GoString(C.gsl_rng_name(s.state))
+func (s RngState) Name() string {
+  defer runtime.KeepAlive(s.owner)
+  return C.GoString(C.gsl_rng_name(s.ptr()))
+}
+
+// NameE is like Name but returns ErrFreed instead of panicking if s has
+// been freed.
+func (s RngState) NameE() (string, error) {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return "", err
+  }
+  return C.GoString(C.gsl_rng_name(state)), nil
 }
 
 func (t *RngType) Name() string {
@@ -247,7 +514,10 @@ func (t *RngType) Name() string {
 
 // String provides a printable string representation for
 // an RngState and type
-func (s *RngState) String() string {
+func (s RngState) String() string {
+  if _, err := s.get(); err != nil {
+    return "<freed>"
+  }
   return s.Name()
 }
 
@@ -257,24 +527,73 @@ func (t *RngType) String() string {
 
 // Max returns the largest value that the rng underlying RngState
 // can handle
-func (s *RngState) Max() uint64 {
-  return uint64(C.gsl_rng_max(s.state))
+func (s RngState) Max() uint64 {
+  defer runtime.KeepAlive(s.owner)
+  return uint64(C.gsl_rng_max(s.ptr()))
+}
+
+// MaxE is like Max but returns ErrFreed instead of panicking if s has been
+// freed.
+func (s RngState) MaxE() (uint64, error) {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return 0, err
+  }
+  return uint64(C.gsl_rng_max(state)), nil
 }
 
 // Min returns the largest value that the rng underlying RngState
 // can handle
-func (s *RngState) Min() uint64 {
-  return uint64(C.gsl_rng_min(s.state))
+func (s RngState) Min() uint64 {
+  defer runtime.KeepAlive(s.owner)
+  return uint64(C.gsl_rng_min(s.ptr()))
+}
+
+// MinE is like Min but returns ErrFreed instead of panicking if s has been
+// freed.
+func (s RngState) MinE() (uint64, error) {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return 0, err
+  }
+  return uint64(C.gsl_rng_min(state)), nil
 }
 
-// State returns a pointer to the underlying rng state from gsl
-func (s *RngState) State() StatePointer {
-  return StatePointer(C.gsl_rng_state(s.state))
+// State returns a pointer to the underlying rng state from gsl.
+// The pointer is only valid as long as s has not been freed.
+func (s RngState) State() StatePointer {
+  defer runtime.KeepAlive(s.owner)
+  return StatePointer(C.gsl_rng_state(s.ptr()))
+}
+
+// StateE is like State but returns ErrFreed instead of panicking if s has
+// been freed.
+func (s RngState) StateE() (StatePointer, error) {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return nil, err
+  }
+  return StatePointer(C.gsl_rng_state(state)), nil
 }
 
 // Size returns the size of the rng state.
-func (s *RngState) Size() uint64 {
-  return uint64(C.gsl_rng_size(s.state))
+func (s RngState) Size() uint64 {
+  defer runtime.KeepAlive(s.owner)
+  return uint64(C.gsl_rng_size(s.ptr()))
+}
+
+// SizeE is like Size but returns ErrFreed instead of panicking if s has
+// been freed.
+func (s RngState) SizeE() (uint64, error) {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return 0, err
+  }
+  return uint64(C.gsl_rng_size(state)), nil
 }
 
 // TypesSetup returns a map with available rng type names as keys and
@@ -296,28 +615,84 @@ func TypesSetup() map[string]RngType {
 
 // Memcpy copies the random number generator src into the pre-existing
 // generator dest, making dest into an exact copy of src. The two generators
-// must be of the same type.
+// must be of the same type. Returns ErrFreed if either generator has
+// been freed and a *gsl.Error with code gsl.EINVAL if the types differ.
 // NOTE: currently this ignores the return type of gsl_rng_memcpy since
 // I don't know what it does (the manual is quiet on that)
-func (s *RngState) Memcpy(dest RngState) {
-  C.gsl_rng_memcpy(dest.state, s.state)
+func (s RngState) Memcpy(dest RngState) error {
+  defer runtime.KeepAlive(s.owner)
+  defer runtime.KeepAlive(dest.owner)
+
+  src, err := s.get()
+  if err != nil {
+    return err
+  }
+  dst, err := dest.get()
+  if err != nil {
+    return err
+  }
+
+  err = gsl.Protect(func() {
+    C.gsl_rng_memcpy(dst, src)
+  })
+  if err != nil {
+    return err
+  }
+  if isGoRng(dst) {
+    syncGoRng(unsafe.Pointer(C.gsl_rng_state(dst)))
+  }
+  return nil
 }
 
 // Clone returns a newly created generator which is an exact copy
 // of the generator r.
-func (s *RngState) Clone() RngState {
-  return RngState{C.gsl_rng_clone(s.state)}
+func (s RngState) Clone() RngState {
+  defer runtime.KeepAlive(s.owner)
+  clone := C.gsl_rng_clone(s.ptr())
+  if isGoRng(clone) {
+    syncGoRng(unsafe.Pointer(C.gsl_rng_state(clone)))
+  }
+  return newRngState(clone)
+}
+
+// CloneE is like Clone but returns ErrFreed instead of panicking if s has
+// been freed and the *gsl.Error raised if the clone can not be allocated.
+func (s RngState) CloneE() (RngState, error) {
+  var clone *C.gsl_rng
+  err := s.protect(func(state *C.gsl_rng) {
+    clone = C.gsl_rng_clone(state)
+  })
+  if err != nil {
+    return RngState{}, err
+  }
+  if clone == nil {
+    return RngState{}, gsl.NewError(gsl.ENOMEM, "Failed to clone rng.")
+  }
+  if isGoRng(clone) {
+    syncGoRng(unsafe.Pointer(C.gsl_rng_state(clone)))
+  }
+  return newRngState(clone), nil
 }
 
 // Fwrite writes the random number state of the random number generator s
 // to the given file in binary format. Data is written in the
 // native binary format and may not be portable between different
 // architectures. Returns an error if there was a problem writing.
-func (s *RngState) Fwrite(s_filename string) error {
+// To write the state to an arbitrary io.Writer use WriteTo.
+func (s RngState) Fwrite(s_filename string) error {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return err
+  }
+  if isGoRng(state) {
+    return fmt.Errorf("Cannot write state of Go implemented rng.")
+  }
+
   filename := C.CString(s_filename)
   defer C.free(unsafe.Pointer(filename))
 
-  status := int(C.rng_fwrite(filename, s.state))
+  status := int(C.rng_fwrite(filename, state))
   if status != 0 {
     return fmt.Errorf("Failed to write rng state to file.")
   }
@@ -328,15 +703,27 @@ func (s *RngState) Fwrite(s_filename string) error {
 // from the given file name in binary format. The random number generator s
 // must be preinitialized with the correct random number generator type
 // since type information is not saved. The data is assumed to have been
-// written in the native binary format on the same architecture. Returns
-// an error if reading fails.
-func (s *RngState) Fread(s_filename string) (*RngState, error) {
+// written in the native binary format on the same architecture. Like all
+// other methods Fread takes s by value; since all copies of an RngState
+// share their generator the state read is visible through each of them.
+// Returns an error if reading fails. To read a state from an arbitrary
+// io.Reader use ReadFrom.
+func (s RngState) Fread(s_filename string) error {
+  defer runtime.KeepAlive(s.owner)
+  state, err := s.get()
+  if err != nil {
+    return err
+  }
+  if isGoRng(state) {
+    return fmt.Errorf("Cannot read state of Go implemented rng.")
+  }
+
   filename := C.CString(s_filename)
   defer C.free(unsafe.Pointer(filename))
 
-  status := int(C.rng_fread(filename, s.state))
+  status := int(C.rng_fread(filename, state))
   if status != 0 {
-    return s, fmt.Errorf("Failed to read rng state from file.")
+    return fmt.Errorf("Failed to read rng state from file.")
   }
-  return s, nil
+  return nil
 }
@@ -6,11 +6,42 @@
 package random
 
 import (
+  "errors"
+  "path/filepath"
   "testing"
 
+  "github.com/haskelladdict/gsl"
   "github.com/haskelladdict/gsl/stats"
 )
 
+// mean returns the mean of data and fails the test on error
+func mean(t *testing.T, data stats.FloatSlice) float64 {
+  m, err := data.Mean(1)
+  if err != nil {
+    t.Fatal("Failed to compute mean: ", err)
+  }
+  return m
+}
+
+// sd returns the standard deviation of data and fails the test on error
+func sd(t *testing.T, data stats.FloatSlice) float64 {
+  s, err := data.Sd(1)
+  if err != nil {
+    t.Fatal("Failed to compute standard deviation: ", err)
+  }
+  return s
+}
+
+// minMax returns the minimum and maximum of data and fails the test on
+// error
+func minMax(t *testing.T, data stats.FloatSlice) (float64, float64) {
+  min, max, err := data.MinMax(1)
+  if err != nil {
+    t.Fatal("Failed to compute minimum and maximum: ", err)
+  }
+  return min, max
+}
+
 // test set 1
 func Test_random_1(t *testing.T) {
 
@@ -33,7 +64,7 @@ func Test_random_1(t *testing.T) {
   for i := 0; i < 100; i++ {
     nums1_slice[i] = float64(nums1[i])
   }
-  min, max := nums1_slice.MinMax(1)
+  min, max := minMax(t, nums1_slice)
 
   if min < float64(rng_state.Min()) || max > float64(rng_state.Max()) {
     t.Error("Test 2: Generated random numbers are out of range.")
@@ -41,7 +72,7 @@ func Test_random_1(t *testing.T) {
 
   // test 3
   nums2 := stats.FloatSlice(rng_state.UniformSlice(100))
-  min, max = nums2.MinMax(1)
+  min, max = minMax(t, nums2)
 
   if min < 0 || max >= 1 {
     t.Error("Test 3: Generated uniform random numbers are out of range.")
@@ -53,7 +84,7 @@ func Test_random_1(t *testing.T) {
   for i := 0; i < 100; i++ {
     nums3_slice[i] = float64(nums3[i])
   }
-  min, max = nums3_slice.MinMax(1)
+  min, max = minMax(t, nums3_slice)
 
   if min < 0 || max >= 900 {
     t.Error("Test 4: Generated uniform int random numbers are out of range.")
@@ -71,8 +102,204 @@ func Test_random_1(t *testing.T) {
     t.Error("Test 6: Hmmm, this is very unlikely to happen.")
   }
 
-  rng_state.Memcpy(rng_state_2)
+  if err := rng_state.Memcpy(rng_state_2); err != nil {
+    t.Error("Test 6: Failed to memcpy rng state: ", err)
+  }
   if rng_state_2.Get() != rng_state.Get() {
     t.Error("Test 6: Failed to memcpy rng state.")
   }
+
+  rng_state.Free()
+  rng_state_1.Free()
+  rng_state_2.Free()
+}
+
+// test set 2
+func Test_random_2(t *testing.T) {
+
+  // test 1
+  rng_state := Rng_alloc(Ranlxd2)
+  rng_state.Set(1)
+  rng_copy := rng_state
+  if rng_copy.Get() == rng_state.Get() {
+    t.Error("Test 1: Copies of an rng state do not share the generator.")
+  }
+
+  // test 2
+  rng_state_1 := Rng_alloc(Ranlxd2)
+  rng_copy.Free()
+  if err := rng_state.Close(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed when closing freed rng, got ", err)
+  }
+  if err := rng_state.Memcpy(rng_state_1); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from Memcpy of freed rng, got ", err)
+  }
+  if err := rng_state_1.Memcpy(rng_state); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from Memcpy into freed rng, got ", err)
+  }
+  if _, err := rng_state.MarshalBinary(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed when encoding freed rng, got ", err)
+  }
+  if rng_state.String() != "<freed>" {
+    t.Error("Test 2: Unexpected string for freed rng ", rng_state.String())
+  }
+  rng_state.Free()
+
+  // test 3
+  defer func() {
+    if r := recover(); r != ErrFreed {
+      t.Error("Test 3: Expected panic with ErrFreed, got ", r)
+    }
+  }()
+  if err := rng_state_1.Close(); err != nil {
+    t.Error("Test 3: Failed to close rng: ", err)
+  }
+  rng_state_1.Uniform()
+}
+
+// test set 3
+func Test_random_3(t *testing.T) {
+
+  // test 1
+  rng_state := Rng_alloc(Ranlxd2)
+  value, err := rng_state.UniformIntE(100)
+  if err != nil || value >= 100 {
+    t.Error("Test 1: Failed to sample uniform int: ", value, err)
+  }
+
+  // test 2
+  if _, err := rng_state.UniformIntE(0); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 2: Expected EINVAL for limit 0, got ", err)
+  }
+  _, err = rng_state.UniformIntSliceE(rng_state.Max()+2, 10)
+  if !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 2: Expected EINVAL for limit exceeding rng range, got ", err)
+  }
+
+  // test 3
+  rng_state_1 := Rng_alloc(Taus)
+  if err := rng_state.Memcpy(rng_state_1); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 3: Expected EINVAL for memcpy of different types, got ",
+      err)
+  }
+
+  rng_state.Free()
+  rng_state_1.Free()
+}
+
+// test set 4
+func Test_random_4(t *testing.T) {
+
+  // test 1: E variants agree with their plain counterparts
+  rng_state := Rng_alloc(Mt19937)
+  rng_state.Set(42)
+  rng_copy := rng_state.Clone()
+  if err := rng_copy.SetE(42); err != nil {
+    t.Error("Test 1: Failed to seed rng: ", err)
+  }
+  if value, err := rng_copy.GetE(); err != nil || value != rng_state.Get() {
+    t.Error("Test 1: GetE does not match Get: ", value, err)
+  }
+  if value, err := rng_copy.UniformE(); err != nil ||
+    value != rng_state.Uniform() {
+    t.Error("Test 1: UniformE does not match Uniform: ", value, err)
+  }
+  if value, err := rng_copy.UniformPosE(); err != nil ||
+    value != rng_state.UniformPos() {
+    t.Error("Test 1: UniformPosE does not match UniformPos: ", value, err)
+  }
+  slice, err := rng_copy.GetSliceE(10)
+  for i, v := range rng_state.GetSlice(10) {
+    if err != nil || slice[i] != v {
+      t.Fatal("Test 1: GetSliceE does not match GetSlice: ", err)
+    }
+  }
+  floats, err := rng_copy.UniformSliceE(10)
+  for i, v := range rng_state.UniformSlice(10) {
+    if err != nil || floats[i] != v {
+      t.Fatal("Test 1: UniformSliceE does not match UniformSlice: ", err)
+    }
+  }
+  if name, err := rng_copy.NameE(); err != nil || name != "mt19937" {
+    t.Error("Test 1: Unexpected rng name: ", name, err)
+  }
+  if max, err := rng_copy.MaxE(); err != nil || max != rng_state.Max() {
+    t.Error("Test 1: MaxE does not match Max: ", max, err)
+  }
+  if size, err := rng_copy.SizeE(); err != nil || size != rng_state.Size() {
+    t.Error("Test 1: SizeE does not match Size: ", size, err)
+  }
+
+  // test 2: E variants return ErrFreed for freed generators
+  rng_copy.Free()
+  if err := rng_copy.SetE(1); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from SetE, got ", err)
+  }
+  if _, err := rng_copy.GetE(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from GetE, got ", err)
+  }
+  if _, err := rng_copy.UniformE(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from UniformE, got ", err)
+  }
+  if _, err := rng_copy.UniformIntE(10); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from UniformIntE, got ", err)
+  }
+  if _, err := rng_copy.UniformIntSliceE(10, 10); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from UniformIntSliceE, got ", err)
+  }
+  if err := rng_copy.UniformFillE(make([]float64, 10)); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from UniformFillE, got ", err)
+  }
+  if _, err := rng_copy.NameE(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from NameE, got ", err)
+  }
+  if _, err := rng_copy.StateE(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from StateE, got ", err)
+  }
+  if _, err := rng_copy.CloneE(); err != ErrFreed {
+    t.Error("Test 2: Expected ErrFreed from CloneE, got ", err)
+  }
+
+  // test 3: Fread updates all copies of a generator
+  filename := filepath.Join(t.TempDir(), "mt19937.state")
+  if err := rng_state.Fwrite(filename); err != nil {
+    t.Fatal("Test 3: Failed to write rng state: ", err)
+  }
+  expected := rng_state.Get()
+  rng_state_1 := Rng_alloc(Mt19937)
+  rng_state_2 := rng_state_1
+  if err := rng_state_2.Fread(filename); err != nil {
+    t.Fatal("Test 3: Failed to read rng state: ", err)
+  }
+  if value := rng_state_1.Get(); value != expected {
+    t.Error("Test 3: Expected ", expected, " after Fread, got ", value)
+  }
+  if err := rng_copy.Fread(filename); err != ErrFreed {
+    t.Error("Test 3: Expected ErrFreed from Fread, got ", err)
+  }
+
+  rng_state.Free()
+  rng_state_1.Free()
+}
+
+// test set 5
+func Test_random_5(t *testing.T) {
+
+  // test 1
+  rng_state := Rng_alloc(Mt19937)
+  if err := Catch(func() { Gaussian(rng_state, 1) }); err != nil {
+    t.Error("Test 1: Unexpected error from Catch: ", err)
+  }
+
+  // test 2
+  err := Catch(func() { rng_state.UniformInt(0) })
+  if !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 2: Expected EINVAL from Catch, got ", err)
+  }
+
+  // test 3
+  rng_state.Free()
+  if err := Catch(func() { Gaussian(rng_state, 1) }); err != ErrFreed {
+    t.Error("Test 3: Expected ErrFreed from Catch, got ", err)
+  }
 }
@@ -6,9 +6,18 @@
  * this function provides additional gsl wrappers for go-gsl
  */
 
+#include <gsl/gsl_errno.h>
+#include <gsl/gsl_integration.h>
+#include <gsl/gsl_math.h>
+#include <gsl/gsl_matrix.h>
+#include <gsl/gsl_randist.h>
 #include <gsl/gsl_rng.h>
+#include <gsl/gsl_vector.h>
 #include <stdio.h>
 
+/* size of the integration workspace used for the Landau cdf */
+#define LANDAU_LIMIT 1000
+
 
 /* rng_types_length returns the number of rng types available */
 size_t rng_types_length() {
@@ -23,10 +32,9 @@ size_t rng_types_length() {
 }
 
 
-/* rng_fwrite writes the state of the rng to a file with filename
- * NOTE: It would be much better to be able to use the gsl API
- * function gsl_rng_fwrite to write to any Go writer but I am not
- * sure how to do that or if it is even possible. */
+/* rng_fwrite writes the state of the rng to a file with filename.
+ * NOTE: To write the state to arbitrary Go writers use the WriteTo
+ * method of RngState instead. */
 int rng_fwrite(const char *fileName, const gsl_rng *r) {
 
   FILE *file = fopen(fileName, "w");
@@ -35,21 +43,19 @@ int rng_fwrite(const char *fileName, const gsl_rng *r) {
   }
 
   int status = gsl_rng_fwrite(file, r);
- 
-  // we need to flush the stream since otherwise the stream
-  // remains empty. I don't understand why.
-  if (fflush(file) != 0) {
+
+  // fclose flushes the stream which otherwise may remain empty
+  if (fclose(file) != 0) {
     return 1;
   }
-  
+
   return status;
 }
 
 
 /* rng_fread read the state of the rng from a file with filename
- * NOTE: It would be much better to be able to use the gsl API
- * function gsl_rng_fread to read into any Go reader but I am not
- * sure how to do that or if it is even possible. */
+ * NOTE: To read the state from arbitrary Go readers use the ReadFrom
+ * method of RngState instead. */
 int rng_fread(const char *fileName, gsl_rng *r) {
 
   FILE *file = fopen(fileName, "r");
@@ -57,8 +63,96 @@ int rng_fread(const char *fileName, gsl_rng *r) {
     return 1;
   }
 
-  return gsl_rng_fread(file, r);
+  int status = gsl_rng_fread(file, r);
+  fclose(file);
+
+  return status;
+}
+
+
+/* landau_pdf adapts gsl_ran_landau_pdf to the gsl_function interface */
+static double landau_pdf(double x, void *params) {
+  (void)params;
+  return gsl_ran_landau_pdf(x);
 }
 
 
+/* landau_integrate integrates the Landau pdf over (-inf, x] if lower
+ * is non-zero and over [x, inf) otherwise and stores the integral in
+ * result. GSL does not provide a cdf for the Landau distribution so we
+ * have to compute it numerically. Returns the status of the integration. */
+static int landau_integrate(double x, int lower, double *result) {
+
+  gsl_integration_workspace *w = gsl_integration_workspace_alloc(LANDAU_LIMIT);
+  if (w == NULL) {
+    return GSL_ENOMEM;
+  }
+
+  gsl_function F;
+  F.function = &landau_pdf;
+  F.params = NULL;
+
+  int status;
+  double abserr = 0;
+  if (lower) {
+    status = gsl_integration_qagil(&F, x, 1e-15, 1e-10, LANDAU_LIMIT, w,
+      result, &abserr);
+  } else {
+    status = gsl_integration_qagiu(&F, x, 1e-15, 1e-10, LANDAU_LIMIT, w,
+      result, &abserr);
+  }
+  gsl_integration_workspace_free(w);
+
+  return status;
+}
 
+
+/* landau_cdf_P computes the lower tail P(x) of the Landau distribution */
+int landau_cdf_P(double x, double *result) {
+  return landau_integrate(x, 1, result);
+}
+
+
+/* landau_cdf_Q computes the upper tail Q(x) of the Landau distribution */
+int landau_cdf_Q(double x, double *result) {
+  return landau_integrate(x, 0, result);
+}
+
+
+/* ran_multivariate_gaussian samples n k dimensional multivariate
+ * Gaussian variates with mean mu and Cholesky factor L (k x k, row major)
+ * and stores them consecutively in result. The plain arrays are wrapped
+ * into gsl vector and matrix views so Go code does not need to construct
+ * these itself. */
+int ran_multivariate_gaussian(const gsl_rng *r, const double *mu,
+  const double *L, size_t k, double *result, size_t n) {
+
+  gsl_vector_const_view mu_view = gsl_vector_const_view_array(mu, k);
+  gsl_matrix_const_view L_view = gsl_matrix_const_view_array(L, k, k);
+
+  for (size_t i = 0; i < n; i++) {
+    gsl_vector_view result_view = gsl_vector_view_array(result + i*k, k);
+    int status = gsl_ran_multivariate_gaussian(r, &mu_view.vector,
+      &L_view.matrix, &result_view.vector);
+    if (status != GSL_SUCCESS) {
+      return status;
+    }
+  }
+  return GSL_SUCCESS;
+}
+
+
+/* ran_multivariate_gaussian_log_pdf computes the log pdf at x of a k
+ * dimensional multivariate Gaussian with mean mu and Cholesky factor L.
+ * work needs to provide space for k doubles. */
+int ran_multivariate_gaussian_log_pdf(const double *x, const double *mu,
+  const double *L, size_t k, double *result, double *work) {
+
+  gsl_vector_const_view x_view = gsl_vector_const_view_array(x, k);
+  gsl_vector_const_view mu_view = gsl_vector_const_view_array(mu, k);
+  gsl_matrix_const_view L_view = gsl_matrix_const_view_array(L, k, k);
+  gsl_vector_view work_view = gsl_vector_view_array(work, k);
+
+  return gsl_ran_multivariate_gaussian_log_pdf(&x_view.vector,
+    &mu_view.vector, &L_view.matrix, result, &work_view.vector);
+}
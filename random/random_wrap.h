@@ -20,6 +20,14 @@ size_t rng_types_length();
 int rng_fwrite(const char *fileName, const gsl_rng *r);
 int rng_fread(const char *fileName, gsl_rng *r);
 
+int landau_cdf_P(double x, double *result);
+int landau_cdf_Q(double x, double *result);
+
+int ran_multivariate_gaussian(const gsl_rng *r, const double *mu,
+  const double *L, size_t k, double *result, size_t n);
+int ran_multivariate_gaussian_log_pdf(const double *x, const double *mu,
+  const double *L, size_t k, double *result, double *work);
+
 
 #ifdef __cplusplus
 }
@@ -0,0 +1,442 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// serialize provides reading and writing of rng and qrng states from and
+// to arbitrary io.Readers and io.Writers as well as the encoding
+// interfaces of the standard library.
+//
+// Each state is written in a small self describing format consisting of
+//
+//	magic     4 bytes, "GRNG" for rngs and "GQRN" for qrngs
+//	version   1 byte
+//	layout    2 bytes, byte order ('L' or 'B') and size of a C unsigned
+//	          long of the writing host (version 2 only)
+//	name      2 byte length followed by the generator name
+//	dim       4 bytes, qrngs only
+//	size      8 byte length followed by the raw generator state
+//	checksum  4 byte CRC-32 (IEEE) of all preceding bytes (version 2 only)
+//
+// All integers of the format itself are stored in little endian byte
+// order. Since the generator name is recorded, reading a state can
+// allocate a generator of the correct type automatically.
+//
+// The generator state is stored as a raw copy of gsl's internal state of
+// the generator in the native memory layout of the writing host. States
+// are therefore tied to the byte order and word size recorded in version 2
+// and can only be read on hosts with the same layout, e.g., amd64 and
+// arm64. Reading a state with a different layout fails with an error.
+// Version 1 states carry neither layout nor checksum and are read without
+// any check.
+package random
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_qrng.h>
+// #include <gsl/gsl_rng.h>
+import "C"
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"runtime"
+	"unsafe"
+)
+
+const (
+	nativeStateVersion = 1
+	taggedStateVersion = 2
+
+	// limits guarding against allocating huge buffers when reading
+	// corrupt data
+	maxNameLength = 1 << 10
+	maxStateSize  = 1 << 26
+)
+
+var (
+	rngMagic  = [4]byte{'G', 'R', 'N', 'G'}
+	qrngMagic = [4]byte{'G', 'Q', 'R', 'N'}
+
+	// wordSize is the size of a C unsigned long which determines the
+	// layout of most gsl generator states
+	wordSize = byte(unsafe.Sizeof(C.ulong(0)))
+)
+
+// hostByteOrder returns 'L' if the host stores integers in little endian
+// byte order and 'B' otherwise
+func hostByteOrder() byte {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return 'L'
+	}
+	return 'B'
+}
+
+// checkLayout makes sure a raw generator state written on a host with the
+// given byte order and word size can be used on this host
+func checkLayout(byteOrder, stateWordSize byte) error {
+	if byteOrder != hostByteOrder() || stateWordSize != wordSize {
+		return fmt.Errorf("Generator state with byte order %q and word size "+
+			"%d is incompatible with host byte order %q and word size %d.",
+			byteOrder, stateWordSize, hostByteOrder(), wordSize)
+	}
+	return nil
+}
+
+// rngTypeByName returns the RngType with the given name
+func rngTypeByName(name string) (RngType, bool) {
+	rngType, ok := TypesSetup()[name]
+	return rngType, ok
+}
+
+// qrngTypeByName returns the QrngType with the given name
+func qrngTypeByName(name string) (QrngType, bool) {
+	for _, qrngType := range []QrngType{Niederreiter_2, Sobol, Halton,
+		ReverseHalton} {
+		if qrngType.Name() == name {
+			return qrngType, true
+		}
+	}
+	return QrngType{}, false
+}
+
+// stateBytes returns a copy of size bytes of raw state memory
+func stateBytes(state unsafe.Pointer, size uint64) []byte {
+	return C.GoBytes(state, C.int(size))
+}
+
+// setStateBytes overwrites size bytes of raw state memory with data
+func setStateBytes(state unsafe.Pointer, data []byte) {
+	copy(unsafe.Slice((*byte)(state), len(data)), data)
+}
+
+// encodeState returns the encoding of a generator state. dim is only
+// written for qrngs.
+func encodeState(magic [4]byte, name string, dim uint32,
+	state []byte) []byte {
+	var buf bytes.Buffer
+	buf.Write(magic[:])
+	buf.WriteByte(taggedStateVersion)
+	buf.WriteByte(hostByteOrder())
+	buf.WriteByte(wordSize)
+	binary.Write(&buf, binary.LittleEndian, uint16(len(name)))
+	buf.WriteString(name)
+	if magic == qrngMagic {
+		binary.Write(&buf, binary.LittleEndian, dim)
+	}
+	binary.Write(&buf, binary.LittleEndian, uint64(len(state)))
+	buf.Write(state)
+	binary.Write(&buf, binary.LittleEndian, crc32.ChecksumIEEE(buf.Bytes()))
+	return buf.Bytes()
+}
+
+// decodedState holds the content of an encoded generator state
+type decodedState struct {
+	name  string
+	dim   uint32
+	state []byte
+}
+
+// checksumReader keeps track of the number of bytes read and their
+// checksum
+type checksumReader struct {
+	r    io.Reader
+	n    int64
+	hash hash.Hash32
+}
+
+func newChecksumReader(r io.Reader) *checksumReader {
+	return &checksumReader{r: r, hash: crc32.NewIEEE()}
+}
+
+func (c *checksumReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	c.hash.Write(p[:n])
+	return n, err
+}
+
+// decodeState reads an encoded generator state in either format version
+// from r
+func decodeState(r *checksumReader, magic [4]byte) (*decodedState, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(header[:4], magic[:]) {
+		return nil, fmt.Errorf("Invalid magic number in generator state.")
+	}
+
+	version := header[4]
+	switch version {
+	case nativeStateVersion:
+	case taggedStateVersion:
+		var layout [2]byte
+		if _, err := io.ReadFull(r, layout[:]); err != nil {
+			return nil, err
+		}
+		if err := checkLayout(layout[0], layout[1]); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported generator state version %d.",
+			version)
+	}
+
+	var nameLength uint16
+	if err := binary.Read(r, binary.LittleEndian, &nameLength); err != nil {
+		return nil, err
+	}
+	if nameLength > maxNameLength {
+		return nil, fmt.Errorf("Generator name of length %d is too long.",
+			nameLength)
+	}
+	name := make([]byte, nameLength)
+	if _, err := io.ReadFull(r, name); err != nil {
+		return nil, err
+	}
+
+	d := &decodedState{name: string(name)}
+	if magic == qrngMagic {
+		if err := binary.Read(r, binary.LittleEndian, &d.dim); err != nil {
+			return nil, err
+		}
+	}
+
+	var size uint64
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, err
+	}
+	if size > maxStateSize {
+		return nil, fmt.Errorf("Generator state of size %d is too large.", size)
+	}
+	d.state = make([]byte, size)
+	if _, err := io.ReadFull(r, d.state); err != nil {
+		return nil, err
+	}
+
+	if version == taggedStateVersion {
+		checksum := r.hash.Sum32()
+		var stored uint32
+		if err := binary.Read(r, binary.LittleEndian, &stored); err != nil {
+			return nil, err
+		}
+		if stored != checksum {
+			return nil, fmt.Errorf("Checksum mismatch in generator state.")
+		}
+	}
+	return d, nil
+}
+
+// restore copies a decoded state into s, allocating s if needed
+func (s *RngState) restore(d *decodedState) error {
+	if s.owner != nil {
+		state, err := s.get()
+		if err != nil {
+			return err
+		}
+		if isGoRng(state) {
+			return fmt.Errorf("Cannot read state into Go implemented rng %s.",
+				s.Name())
+		}
+	}
+
+	if s.owner == nil {
+		rngType, ok := rngTypeByName(d.name)
+		if !ok {
+			return fmt.Errorf("Unknown rng type %s.", d.name)
+		}
+		*s = Rng_alloc(rngType)
+	} else if s.Name() != d.name {
+		return fmt.Errorf("Cannot read %s state into %s rng.", d.name, s.Name())
+	}
+
+	if uint64(len(d.state)) != s.Size() {
+		return fmt.Errorf("State size %d does not match %s rng.", len(d.state),
+			d.name)
+	}
+	setStateBytes(unsafe.Pointer(s.State()), d.state)
+	runtime.KeepAlive(s.owner)
+	return nil
+}
+
+// MarshalBinary returns the encoding of the type and state of the random
+// number generator s. It implements the
+// encoding.BinaryMarshaler interface.
+func (s RngState) MarshalBinary() ([]byte, error) {
+	defer runtime.KeepAlive(s.owner)
+	state, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+	if isGoRng(state) {
+		return nil, fmt.Errorf("Cannot encode Go implemented rng %s.", s.Name())
+	}
+	return encodeState(rngMagic, s.Name(), 0,
+		stateBytes(unsafe.Pointer(s.State()), s.Size())), nil
+}
+
+// UnmarshalBinary restores a random number generator from data produced
+// by MarshalBinary. If s has not been allocated yet a generator of the
+// recorded type is allocated, otherwise the type of s has to match the
+// recorded type. It implements the encoding.BinaryUnmarshaler interface.
+func (s *RngState) UnmarshalBinary(data []byte) error {
+	r := newChecksumReader(bytes.NewReader(data))
+	d, err := decodeState(r, rngMagic)
+	if err != nil {
+		return err
+	}
+	if r.n != int64(len(data)) {
+		return fmt.Errorf("Trailing data after rng state.")
+	}
+	return s.restore(d)
+}
+
+// MarshalText returns the base64 encoded binary encoding of the random
+// number generator s. It implements the encoding.TextMarshaler interface.
+func (s RngState) MarshalText() ([]byte, error) {
+	data, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	text := make([]byte, base64.StdEncoding.EncodedLen(len(data)))
+	base64.StdEncoding.Encode(text, data)
+	return text, nil
+}
+
+// UnmarshalText restores a random number generator from text produced
+// by MarshalText. It implements the encoding.TextUnmarshaler interface.
+func (s *RngState) UnmarshalText(text []byte) error {
+	data := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, err := base64.StdEncoding.Decode(data, text)
+	if err != nil {
+		return err
+	}
+	return s.UnmarshalBinary(data[:n])
+}
+
+// MarshalJSON returns the random number generator s as a JSON string
+// containing its text encoding. It implements the json.Marshaler
+// interface.
+func (s RngState) MarshalJSON() ([]byte, error) {
+	text, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON restores a random number generator from JSON produced by
+// MarshalJSON. It implements the json.Unmarshaler interface.
+func (s *RngState) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return s.UnmarshalText([]byte(text))
+}
+
+// WriteTo writes the encoding of the type and state of the random number
+// generator s to w. It implements the io.WriterTo interface.
+func (s RngState) WriteTo(w io.Writer) (int64, error) {
+	data, err := s.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// ReadFrom reads a random number generator state previously written with
+// WriteTo from r. If s has not been allocated yet a generator of the
+// recorded type is allocated, otherwise the type of s has to match the
+// recorded type. It implements the io.ReaderFrom interface.
+func (s *RngState) ReadFrom(r io.Reader) (int64, error) {
+	cr := newChecksumReader(r)
+	d, err := decodeState(cr, rngMagic)
+	if err != nil {
+		return cr.n, err
+	}
+	return cr.n, s.restore(d)
+}
+
+// ReadRngState allocates a new random number generator with the type and
+// state read from r which was previously written with WriteTo.
+func ReadRngState(r io.Reader) (RngState, error) {
+	var s RngState
+	if _, err := s.ReadFrom(r); err != nil {
+		s.Free()
+		return RngState{}, err
+	}
+	return s, nil
+}
+
+// WriteTo writes the encoding of the type, dimension and state of the
+// quasi random number generator s to w. It implements the io.WriterTo
+// interface.
+func (s QrngState) WriteTo(w io.Writer) (int64, error) {
+	defer runtime.KeepAlive(s.owner)
+	if _, err := s.get(); err != nil {
+		return 0, err
+	}
+
+	data := encodeState(qrngMagic, s.Name(), uint32(s.Dim()),
+		stateBytes(unsafe.Pointer(s.State()), s.Size()))
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// ReadFrom reads a quasi random number generator state previously written
+// with WriteTo from r. If s has not been allocated yet a generator of the
+// recorded type and dimension is allocated, otherwise type and dimension
+// of s have to match the recorded ones. It implements the io.ReaderFrom
+// interface.
+func (s *QrngState) ReadFrom(r io.Reader) (int64, error) {
+	cr := newChecksumReader(r)
+	d, err := decodeState(cr, qrngMagic)
+	if err != nil {
+		return cr.n, err
+	}
+
+	if s.owner == nil {
+		qrngType, ok := qrngTypeByName(d.name)
+		if !ok {
+			return cr.n, fmt.Errorf("Unknown qrng type %s.", d.name)
+		}
+		qrng, err := Qrng_allocE(qrngType, uint(d.dim))
+		if err != nil {
+			return cr.n, err
+		}
+		*s = qrng
+	} else if _, err := s.get(); err != nil {
+		return cr.n, err
+	} else if s.Name() != d.name || s.Dim() != uint(d.dim) {
+		return cr.n, fmt.Errorf("Cannot read %s state of dimension %d into "+
+			"%s qrng of dimension %d.", d.name, d.dim, s.Name(), s.Dim())
+	}
+
+	if uint64(len(d.state)) != s.Size() {
+		return cr.n, fmt.Errorf("State size %d does not match %s qrng.",
+			len(d.state), d.name)
+	}
+	setStateBytes(unsafe.Pointer(s.State()), d.state)
+	runtime.KeepAlive(s.owner)
+	return cr.n, nil
+}
+
+// ReadQrngState allocates a new quasi random number generator with the
+// type, dimension and state read from r which was previously written with
+// WriteTo.
+func ReadQrngState(r io.Reader) (QrngState, error) {
+	var s QrngState
+	if _, err := s.ReadFrom(r); err != nil {
+		s.Free()
+		return QrngState{}, err
+	}
+	return s, nil
+}
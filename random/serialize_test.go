@@ -0,0 +1,206 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// serialize provides reading and writing of rng and qrng states from and
+// to arbitrary io.Readers and io.Writers as well as the encoding
+// interfaces of the standard library.
+package random
+
+import (
+  "bytes"
+  "encoding/json"
+  "testing"
+)
+
+// test set 1
+func Test_serialize_1(t *testing.T) {
+
+  // test 1: write and read back rng state
+  rng_state := Rng_alloc(Ranlxd2)
+  rng_state.Set(1234)
+  rng_state.GetSlice(10)
+
+  var buf bytes.Buffer
+  n, err := rng_state.WriteTo(&buf)
+  if err != nil || n != int64(buf.Len()) {
+    t.Fatal("Test 1: Failed to write rng state.", err)
+  }
+  data := buf.Bytes()
+
+  rng_state_1, err := ReadRngState(bytes.NewReader(data))
+  if err != nil {
+    t.Fatal("Test 1: Failed to read rng state.", err)
+  }
+  if rng_state_1.Name() != "ranlxd2" {
+    t.Error("Test 1: Read rng state has wrong type", rng_state_1.Name())
+  }
+  for i := 0; i < 100; i++ {
+    if rng_state.Get() != rng_state_1.Get() {
+      t.Fatal("Test 1: Read rng state does not match written state.")
+    }
+  }
+
+  // test 2: read into pre-allocated states
+  rng_state_2 := Rng_alloc(Ranlxd2)
+  if _, err := rng_state_2.ReadFrom(bytes.NewReader(data)); err != nil {
+    t.Error("Test 2: Failed to read into allocated rng state.", err)
+  }
+
+  rng_state_3 := Rng_alloc(Mt19937)
+  if _, err := rng_state_3.ReadFrom(bytes.NewReader(data)); err == nil {
+    t.Error("Test 2: Failed to detect mismatching rng types.")
+  }
+
+  // test 3: corrupt data is rejected
+  if _, err := ReadRngState(bytes.NewReader(data[:len(data)-1])); err == nil {
+    t.Error("Test 3: Failed to detect truncated rng state.")
+  }
+
+  if _, err := ReadRngState(bytes.NewReader([]byte("garbage"))); err == nil {
+    t.Error("Test 3: Failed to detect invalid rng state.")
+  }
+
+  rng_state.Free()
+  rng_state_1.Free()
+  rng_state_2.Free()
+  rng_state_3.Free()
+}
+
+// test set 2
+func Test_serialize_2(t *testing.T) {
+
+  // write and read back qrng state
+  qrng_state := Qrng_alloc(Sobol, 3)
+  qrng_state.GetSlice(10)
+
+  var buf bytes.Buffer
+  if _, err := qrng_state.WriteTo(&buf); err != nil {
+    t.Fatal("Failed to write qrng state.", err)
+  }
+
+  qrng_state_1, err := ReadQrngState(&buf)
+  if err != nil {
+    t.Fatal("Failed to read qrng state.", err)
+  }
+  for i := 0; i < 10; i++ {
+    p, p1 := qrng_state.Get(), qrng_state_1.Get()
+    if len(p1) != 3 {
+      t.Fatal("Read qrng state has wrong dimension.")
+    }
+    for j := range p {
+      if p[j] != p1[j] {
+        t.Fatal("Read qrng state does not match written state.")
+      }
+    }
+  }
+
+  qrng_state.Free()
+  qrng_state_1.Free()
+}
+
+// test set 3
+func Test_serialize_3(t *testing.T) {
+
+  // round trip every available rng type through all encodings
+  for name, rng_type := range TypesSetup() {
+    rng_state := Rng_alloc(rng_type)
+    rng_state.Set(4321)
+    rng_state.GetSlice(5)
+
+    data, err := rng_state.MarshalBinary()
+    if err != nil {
+      t.Fatal(name+": Failed to marshal rng state.", err)
+    }
+    text, err := rng_state.MarshalText()
+    if err != nil {
+      t.Fatal(name+": Failed to marshal rng state to text.", err)
+    }
+    js, err := json.Marshal(rng_state)
+    if err != nil {
+      t.Fatal(name+": Failed to marshal rng state to json.", err)
+    }
+
+    var bin_state, text_state, json_state RngState
+    if err := bin_state.UnmarshalBinary(data); err != nil {
+      t.Fatal(name+": Failed to unmarshal rng state.", err)
+    }
+    if err := text_state.UnmarshalText(text); err != nil {
+      t.Fatal(name+": Failed to unmarshal rng state from text.", err)
+    }
+    if err := json.Unmarshal(js, &json_state); err != nil {
+      t.Fatal(name+": Failed to unmarshal rng state from json.", err)
+    }
+
+    for _, s := range []RngState{bin_state, text_state, json_state} {
+      if s.Name() != name {
+        t.Error(name+": Unmarshaled rng has wrong type", s.Name())
+      }
+    }
+    for i := 0; i < 10; i++ {
+      v := rng_state.Get()
+      if bin_state.Get() != v || text_state.Get() != v || json_state.Get() != v {
+        t.Error(name + ": Unmarshaled rng state does not match original.")
+        break
+      }
+    }
+
+    rng_state.Free()
+    bin_state.Free()
+    text_state.Free()
+    json_state.Free()
+  }
+}
+
+// test set 4
+func Test_serialize_4(t *testing.T) {
+
+  // corrupted states are detected via their checksum
+  rng_state := Rng_alloc(Mt19937)
+  data, err := rng_state.MarshalBinary()
+  if err != nil {
+    t.Fatal("Failed to marshal rng state.", err)
+  }
+
+  corrupt := append([]byte{}, data...)
+  corrupt[len(corrupt)/2] ^= 0xff
+  var s RngState
+  if err := s.UnmarshalBinary(corrupt); err == nil {
+    t.Error("Failed to detect corrupted rng state.")
+  }
+
+  if err := s.UnmarshalBinary(append(data, 0)); err == nil {
+    t.Error("Failed to detect trailing data.")
+  }
+  rng_state.Free()
+}
+
+// test set 5
+func Test_serialize_5(t *testing.T) {
+
+  // states written on hosts with a different memory layout are rejected
+  rng_state := Rng_alloc(Mt19937)
+  data, err := rng_state.MarshalBinary()
+  if err != nil {
+    t.Fatal("Failed to marshal rng state.", err)
+  }
+  if data[5] != hostByteOrder() || data[6] != wordSize {
+    t.Fatal("Marshaled rng state does not record the host layout.")
+  }
+
+  // test 1: byte order
+  foreign := append([]byte{}, data...)
+  foreign[5] = 'B' + 'L' - foreign[5]
+  var s RngState
+  if err := s.UnmarshalBinary(foreign); err == nil {
+    t.Error("Test 1: Failed to reject rng state with foreign byte order.")
+  }
+
+  // test 2: word size
+  foreign = append([]byte{}, data...)
+  foreign[6] = 12 - foreign[6]
+  if err := s.UnmarshalBinary(foreign); err == nil {
+    t.Error("Test 2: Failed to reject rng state with foreign word size.")
+  }
+  rng_state.Free()
+}
@@ -0,0 +1,69 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// shuffle provides gsl's shuffling and sampling routines for Go slices.
+//
+// NOTE: gsl_ran_shuffle, gsl_ran_choose and gsl_ran_sample operate on
+// untyped C memory which can't hold arbitrary Go values. The functions
+// below therefore reimplement the gsl algorithms in Go on top of the same
+// rng calls (gsl_rng_uniform_int and gsl_rng_uniform). For a given
+// generator and seed they consume the random stream in exactly the same
+// way and produce the same permutations and selections as gsl.
+package random
+
+import (
+	"fmt"
+)
+
+// Shuffle randomly permutes the order of the elements in data in place.
+// Each of the n! possible permutations is equally likely.
+func Shuffle[T any](rng RngState, data []T) {
+	n := uint64(len(data))
+	if n < 2 {
+		return
+	}
+
+	for i := n - 1; i > 0; i-- {
+		j := rng.UniformInt(i + 1)
+		data[i], data[j] = data[j], data[i]
+	}
+}
+
+// Choose returns a slice with k elements taken randomly from src without
+// replacement. The elements are returned in the same relative order as
+// they appear in src. k must be less than or equal to the length of src.
+func Choose[T any](rng RngState, src []T, k uint64) ([]T, error) {
+	n := uint64(len(src))
+	if k > n {
+		return nil, fmt.Errorf("Cannot choose %d elements from a slice of "+
+			"length %d.", k, n)
+	}
+
+	dest := make([]T, k)
+	j := uint64(0)
+	for i := uint64(0); i < n && j < k; i++ {
+		if float64(n-i)*rng.Uniform() < float64(k-j) {
+			dest[j] = src[i]
+			j++
+		}
+	}
+	return dest, nil
+}
+
+// Sample returns a slice with k elements taken randomly from src with
+// replacement, i.e., the same element of src may appear more than once
+// in the result. In contrast to Choose, k may be larger than the length
+// of src.
+func Sample[T any](rng RngState, src []T, k uint64) ([]T, error) {
+	n := uint64(len(src))
+	if n == 0 && k > 0 {
+		return nil, fmt.Errorf("Cannot sample from an empty slice.")
+	}
+
+	dest := make([]T, k)
+	for i := uint64(0); i < k; i++ {
+		dest[i] = src[rng.UniformInt(n)]
+	}
+	return dest, nil
+}
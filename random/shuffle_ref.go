@@ -0,0 +1,57 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build gslref
+
+// shuffle_ref calls gsl_ran_shuffle, gsl_ran_choose and gsl_ran_sample
+// directly on int64 slices. These serve as the reference the Go
+// implementations in shuffle.go are tested against, since test files
+// can't use cgo themselves. They are only built with the gslref tag, i.e.,
+// by go test -tags gslref, and are not part of the package otherwise.
+package random
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_randist.h>
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// gslShuffle permutes data in place via gsl_ran_shuffle
+func gslShuffle(rng RngState, data []int64) {
+	if len(data) == 0 {
+		return
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.gsl_ran_shuffle(rng.ptr(), unsafe.Pointer(&data[0]), C.size_t(len(data)),
+		C.size_t(unsafe.Sizeof(data[0])))
+}
+
+// gslChoose returns k elements of src chosen via gsl_ran_choose
+func gslChoose(rng RngState, src []int64, k int) []int64 {
+	dest := make([]int64, k)
+	if k == 0 {
+		return dest
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.gsl_ran_choose(rng.ptr(), unsafe.Pointer(&dest[0]), C.size_t(k),
+		unsafe.Pointer(&src[0]), C.size_t(len(src)),
+		C.size_t(unsafe.Sizeof(src[0])))
+	return dest
+}
+
+// gslSample returns k elements of src sampled via gsl_ran_sample
+func gslSample(rng RngState, src []int64, k int) []int64 {
+	dest := make([]int64, k)
+	if k == 0 {
+		return dest
+	}
+	defer runtime.KeepAlive(rng.owner)
+	C.gsl_ran_sample(rng.ptr(), unsafe.Pointer(&dest[0]), C.size_t(k),
+		unsafe.Pointer(&src[0]), C.size_t(len(src)),
+		C.size_t(unsafe.Sizeof(src[0])))
+	return dest
+}
@@ -0,0 +1,74 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build gslref
+
+package random
+
+import (
+  "testing"
+)
+
+// equalInt64s checks that a and b contain the same elements
+func equalInt64s(a, b []int64) bool {
+  if len(a) != len(b) {
+    return false
+  }
+  for i := range a {
+    if a[i] != b[i] {
+      return false
+    }
+  }
+  return true
+}
+
+// test set 1
+func Test_shuffle_ref_1(t *testing.T) {
+
+  rng1 := Rng_alloc(Mt19937)
+  rng2 := Rng_alloc(Mt19937)
+  defer rng1.Free()
+  defer rng2.Free()
+
+  for _, n := range []int{1, 2, 7, 100, 1000} {
+    src := make([]int64, n)
+    for i := range src {
+      src[i] = int64(i)
+    }
+
+    // test 1: Shuffle agrees with gsl_ran_shuffle
+    rng1.Set(uint64(n))
+    rng2.Set(uint64(n))
+    data1 := append([]int64(nil), src...)
+    data2 := append([]int64(nil), src...)
+    Shuffle(rng1, data1)
+    gslShuffle(rng2, data2)
+    if !equalInt64s(data1, data2) {
+      t.Error("Test 1: Shuffle differs from gsl_ran_shuffle for n =", n)
+    }
+
+    // test 2: Choose agrees with gsl_ran_choose
+    for _, k := range []int{0, 1, n / 2, n} {
+      chosen, err := Choose(rng1, src, uint64(k))
+      if err != nil || !equalInt64s(chosen, gslChoose(rng2, src, k)) {
+        t.Error("Test 2: Choose differs from gsl_ran_choose for n =", n,
+          "k =", k, err)
+      }
+    }
+
+    // test 3: Sample agrees with gsl_ran_sample
+    for _, k := range []int{0, 1, n, 3 * n} {
+      sample, err := Sample(rng1, src, uint64(k))
+      if err != nil || !equalInt64s(sample, gslSample(rng2, src, k)) {
+        t.Error("Test 3: Sample differs from gsl_ran_sample for n =", n,
+          "k =", k, err)
+      }
+    }
+
+    // test 4: both generators are still in sync
+    if rng1.Get() != rng2.Get() {
+      t.Error("Test 4: Generators diverged for n =", n)
+    }
+  }
+}
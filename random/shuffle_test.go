@@ -0,0 +1,77 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package random
+
+import (
+  "testing"
+)
+
+// test set 1
+func Test_shuffle_1(t *testing.T) {
+
+  rng_state := Rng_alloc(Mt19937)
+  rng_state.Set(42)
+
+  // test 1: shuffling produces a permutation
+  data := make([]int, 100)
+  for i := range data {
+    data[i] = i
+  }
+  Shuffle(rng_state, data)
+
+  seen := make(map[int]bool)
+  for _, v := range data {
+    seen[v] = true
+  }
+  if len(seen) != len(data) {
+    t.Error("Test 1: Shuffle did not produce a permutation.")
+  }
+
+  // test 2: shuffling is reproducible for a given seed
+  data2 := make([]int, 100)
+  for i := range data2 {
+    data2[i] = i
+  }
+  rng_state.Set(42)
+  Shuffle(rng_state, data2)
+  for i := range data {
+    if data[i] != data2[i] {
+      t.Error("Test 2: Shuffle is not reproducible.")
+      break
+    }
+  }
+
+  // test 3: choose preserves order and does not repeat elements
+  src := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+  chosen, err := Choose(rng_state, src, 5)
+  if err != nil || len(chosen) != 5 {
+    t.Fatal("Test 3: Failed to choose elements.", err)
+  }
+  for i := 1; i < len(chosen); i++ {
+    if chosen[i-1] >= chosen[i] {
+      t.Error("Test 3: Choose did not preserve order.", chosen)
+    }
+  }
+
+  if _, err := Choose(rng_state, src, 9); err == nil {
+    t.Error("Test 3: Choose failed to detect k > n.")
+  }
+
+  // test 4: sample with replacement
+  sample, err := Sample(rng_state, []float64{1.5, 2.5}, 1000)
+  if err != nil || len(sample) != 1000 {
+    t.Fatal("Test 4: Failed to sample elements.", err)
+  }
+  for _, v := range sample {
+    if v != 1.5 && v != 2.5 {
+      t.Error("Test 4: Sample returned an unknown element.")
+    }
+  }
+
+  if _, err := Sample(rng_state, []int{}, 1); err == nil {
+    t.Error("Test 4: Sample failed to detect empty source.")
+  }
+  rng_state.Free()
+}
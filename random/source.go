@@ -0,0 +1,87 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// source adapts gsl random number generators to the random number source
+// interfaces of the Go standard library
+package random
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// make sure Source satisfies the standard library interfaces
+var (
+	_ rand.Source64 = (*Source)(nil)
+	_ randv2.Source = (*Source)(nil)
+)
+
+// Source wraps an RngState and implements the math/rand.Source64 and
+// math/rand/v2.Source interfaces so that gsl generators can drive standard
+// library code, e.g., via rand.New(random.NewSource(rng)).
+//
+// Most gsl generators produce fewer than 64 random bits per call to Get
+// and many have a range that is not a power of two. Source therefore
+// rejects values outside of the largest power of two range [0, 2^b)
+// covered by the generator and concatenates as many b bit draws as
+// needed to fill 64 bits. This keeps all 64 bits uniformly distributed
+// at the cost of possibly several calls to Get per Uint64.
+type Source struct {
+	rng  RngState
+	min  uint64
+	bits uint
+	mask uint64
+}
+
+// NewSource returns a Source drawing random numbers from rng. The
+// generator is not copied, i.e., using rng directly while also using
+// the Source advances the same stream.
+func NewSource(rng RngState) *Source {
+	min, max := rng.Min(), rng.Max()
+	span := max - min
+
+	// b is the largest number of bits with 2^b <= span + 1
+	b := uint(64)
+	if span != math.MaxUint64 {
+		b = uint(bits.Len64(span+1)) - 1
+	}
+
+	mask := uint64(math.MaxUint64)
+	if b < 64 {
+		mask = uint64(1)<<b - 1
+	}
+	return &Source{rng, min, b, mask}
+}
+
+// next returns b uniformly distributed random bits
+func (s *Source) next() uint64 {
+	for {
+		if v := s.rng.Get() - s.min; v <= s.mask {
+			return v
+		}
+	}
+}
+
+// Uint64 returns a uniformly distributed pseudo-random 64 bit value.
+func (s *Source) Uint64() uint64 {
+	var x uint64
+	for n := uint(0); n < 64; n += s.bits {
+		x = x<<s.bits | s.next()
+	}
+	return x
+}
+
+// Int63 returns a uniformly distributed non-negative pseudo-random 63 bit
+// integer.
+func (s *Source) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed reseeds the underlying generator via Set. See Set for how seeds
+// are interpreted by the different generators.
+func (s *Source) Seed(seed int64) {
+	s.rng.Set(uint64(seed))
+}
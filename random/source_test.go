@@ -0,0 +1,58 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// source adapts gsl random number generators to the random number source
+// interfaces of the Go standard library
+package random
+
+import (
+  "math"
+  "math/rand"
+  randv2 "math/rand/v2"
+  "testing"
+)
+
+// test set 1
+func Test_source_1(t *testing.T) {
+
+  // test 1: every bit of Uint64 is set with probability 1/2, including
+  // generators with a range smaller than 64 bits and not a power of two
+  const numDraws = 100000
+  for _, rng_type := range []RngType{Ranlxd2, Minstd, Mt19937} {
+    rng_state := Rng_alloc(rng_type)
+    source := NewSource(rng_state)
+
+    var counts [64]float64
+    for i := 0; i < numDraws; i++ {
+      x := source.Uint64()
+      for b := 0; b < 64; b++ {
+        counts[b] += float64((x >> uint(b)) & 1)
+      }
+    }
+    for b, c := range counts {
+      if math.Abs(c/numDraws-0.5) > 0.01 {
+        t.Error("Test 1:", rng_state.Name(), "bit", b, "is not uniform.")
+      }
+    }
+    rng_state.Free()
+  }
+
+  // test 2: use with math/rand and math/rand/v2
+  rng_state := Rng_alloc(Ranlxd2)
+  r := rand.New(NewSource(rng_state))
+  r.Seed(42)
+  first := r.Int63()
+  r.Seed(42)
+  if r.Int63() != first {
+    t.Error("Test 2: Seeding the source is not reproducible.")
+  }
+
+  r2 := randv2.New(NewSource(rng_state))
+  for i := 0; i < 1000; i++ {
+    if v := r2.IntN(10); v < 0 || v >= 10 {
+      t.Fatal("Test 2: IntN returned a value out of range.")
+    }
+  }
+  rng_state.Free()
+}
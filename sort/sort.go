@@ -0,0 +1,178 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// sort wraps gsl sorting routines
+//
+// Like the functions in package stats all routines take a stride and
+// operate on the elements data[0], data[stride], data[2*stride], ... of
+// the dataset, i.e., on n = ceil(len(data)/stride) elements. A *gsl.Error
+// with code gsl.EINVAL is returned for a stride smaller than one and with
+// code gsl.EBADLEN for an empty dataset. Indices returned by the index
+// functions refer to the strided elements, i.e., index i corresponds to
+// data[i*stride].
+//
+// Sort can be used to prepare data for stats.MedianFromSortedData and
+// stats.QuantileFromSortedData.
+package sort
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sort.h>
+import "C"
+
+import (
+  "github.com/haskelladdict/gsl"
+)
+
+// length returns the number of elements n = ceil(len(data)/stride) of
+// data accessed with stride stride. It returns an error if stride is
+// smaller than one or data is empty.
+func length(data []float64, stride int) (int, error) {
+  if stride < 1 {
+    return 0, gsl.NewError(gsl.EINVAL, "Invalid stride %d.", stride)
+  }
+  if len(data) == 0 {
+    return 0, gsl.NewError(gsl.EBADLEN, "Empty dataset.")
+  }
+  return (len(data) + stride - 1) / stride, nil
+}
+
+// checkK verifies that k elements can be selected from n elements
+func checkK(k, n int) error {
+  if k < 0 || k > n {
+    return gsl.NewError(gsl.EINVAL, "Cannot select %d of %d elements.", k, n)
+  }
+  return nil
+}
+
+// Sort sorts the elements of data with stride stride into ascending
+// numerical order in place.
+func Sort(data []float64, stride int) error {
+  n, err := length(data, stride)
+  if err != nil {
+    return err
+  }
+  C.gsl_sort((*C.double)(&data[0]), C.size_t(stride), C.size_t(n))
+  return nil
+}
+
+// Sort2 sorts the elements of data1 with stride stride1 into ascending
+// numerical order in place while making the same rearrangement to the
+// elements of data2 with stride stride2. A *gsl.Error with code
+// gsl.EBADLEN is returned if the number of strided elements of the two
+// datasets differs.
+func Sort2(data1 []float64, stride1 int, data2 []float64,
+  stride2 int) error {
+  n, err := length(data1, stride1)
+  if err != nil {
+    return err
+  }
+  m, err := length(data2, stride2)
+  if err != nil {
+    return err
+  }
+  if n != m {
+    return gsl.NewError(gsl.EBADLEN, "Number of elements %d and %d of "+
+      "datasets differ.", n, m)
+  }
+  C.gsl_sort2((*C.double)(&data1[0]), C.size_t(stride1),
+    (*C.double)(&data2[0]), C.size_t(stride2), C.size_t(n))
+  return nil
+}
+
+// Index returns the permutation p which sorts the elements of data with
+// stride stride into ascending order, i.e., p[0] is the index of the
+// smallest element and p[n-1] the index of the largest one. data is not
+// modified.
+func Index(data []float64, stride int) ([]uint64, error) {
+  n, err := length(data, stride)
+  if err != nil {
+    return nil, err
+  }
+  p := make([]uint64, n)
+  C.gsl_sort_index((*C.size_t)(&p[0]), (*C.double)(&data[0]),
+    C.size_t(stride), C.size_t(n))
+  return p, nil
+}
+
+// Smallest returns the k smallest elements of data with stride stride in
+// ascending order. A *gsl.Error with code gsl.EINVAL is returned if k is
+// negative or larger than the number of strided elements.
+func Smallest(k int, data []float64, stride int) ([]float64, error) {
+  n, err := length(data, stride)
+  if err != nil {
+    return nil, err
+  }
+  if err := checkK(k, n); err != nil {
+    return nil, err
+  }
+  dest := make([]float64, k)
+  if k == 0 {
+    return dest, nil
+  }
+  C.gsl_sort_smallest((*C.double)(&dest[0]), C.size_t(k),
+    (*C.double)(&data[0]), C.size_t(stride), C.size_t(n))
+  return dest, nil
+}
+
+// Largest returns the k largest elements of data with stride stride in
+// descending order. A *gsl.Error with code gsl.EINVAL is returned if k is
+// negative or larger than the number of strided elements.
+func Largest(k int, data []float64, stride int) ([]float64, error) {
+  n, err := length(data, stride)
+  if err != nil {
+    return nil, err
+  }
+  if err := checkK(k, n); err != nil {
+    return nil, err
+  }
+  dest := make([]float64, k)
+  if k == 0 {
+    return dest, nil
+  }
+  C.gsl_sort_largest((*C.double)(&dest[0]), C.size_t(k),
+    (*C.double)(&data[0]), C.size_t(stride), C.size_t(n))
+  return dest, nil
+}
+
+// SmallestIndex returns the indices of the k smallest elements of data
+// with stride stride ordered by ascending value. A *gsl.Error with code
+// gsl.EINVAL is returned if k is negative or larger than the number of
+// strided elements.
+func SmallestIndex(k int, data []float64, stride int) ([]uint64, error) {
+  n, err := length(data, stride)
+  if err != nil {
+    return nil, err
+  }
+  if err := checkK(k, n); err != nil {
+    return nil, err
+  }
+  p := make([]uint64, k)
+  if k == 0 {
+    return p, nil
+  }
+  C.gsl_sort_smallest_index((*C.size_t)(&p[0]), C.size_t(k),
+    (*C.double)(&data[0]), C.size_t(stride), C.size_t(n))
+  return p, nil
+}
+
+// LargestIndex returns the indices of the k largest elements of data
+// with stride stride ordered by descending value. A *gsl.Error with code
+// gsl.EINVAL is returned if k is negative or larger than the number of
+// strided elements.
+func LargestIndex(k int, data []float64, stride int) ([]uint64, error) {
+  n, err := length(data, stride)
+  if err != nil {
+    return nil, err
+  }
+  if err := checkK(k, n); err != nil {
+    return nil, err
+  }
+  p := make([]uint64, k)
+  if k == 0 {
+    return p, nil
+  }
+  C.gsl_sort_largest_index((*C.size_t)(&p[0]), C.size_t(k),
+    (*C.double)(&data[0]), C.size_t(stride), C.size_t(n))
+  return p, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// sort wraps gsl sorting routines
+package sort
+
+import (
+  "errors"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/stats"
+)
+
+// test set 1
+func Test_sort_1(t *testing.T) {
+
+  // test 1
+  data := []float64{5, 100, 3, 200, 9, 300, 1, 400, 7}
+  expected := []float64{1, 3, 5, 7, 9, 100, 200, 300, 400}
+  sorted := append([]float64(nil), data...)
+  if err := Sort(sorted, 1); err != nil {
+    t.Fatal(err)
+  }
+  for i := range sorted {
+    if sorted[i] != expected[i] {
+      t.Error("Test 1: Failed to sort data at", i, sorted[i], expected[i])
+    }
+  }
+
+  // test 2
+  expected = []float64{1, 100, 3, 200, 5, 300, 7, 400, 9}
+  sorted = append([]float64(nil), data...)
+  if err := Sort(sorted, 2); err != nil {
+    t.Fatal(err)
+  }
+  for i := range sorted {
+    if sorted[i] != expected[i] {
+      t.Error("Test 2: Failed to sort strided data at", i, sorted[i],
+        expected[i])
+    }
+  }
+
+  // test 3
+  median, err := stats.FloatSlice(sorted).MedianFromSortedData(2)
+  if err != nil || median != 5 {
+    t.Error("Test 3: Failed to compute median of sorted data.", median, err)
+  }
+
+  // test 4
+  data1 := []float64{3, 1, 2}
+  data2 := []float64{30, -1, 10, -2, 20}
+  if err := Sort2(data1, 1, data2, 2); err != nil {
+    t.Fatal(err)
+  }
+  if data1[0] != 1 || data1[1] != 2 || data1[2] != 3 || data2[0] != 10 ||
+    data2[1] != -1 || data2[2] != 20 || data2[3] != -2 || data2[4] != 30 {
+    t.Error("Test 4: Failed to sort two datasets.", data1, data2)
+  }
+}
+
+// test set 2
+func Test_sort_2(t *testing.T) {
+
+  data := []float64{5, 100, 3, 200, 9, 300, 1, 400, 7}
+
+  // test 1
+  p, err := Index(data, 2)
+  if err != nil {
+    t.Fatal(err)
+  }
+  expected := []uint64{3, 1, 0, 4, 2}
+  if len(p) != len(expected) {
+    t.Fatal("Test 1: Index returned wrong number of elements", len(p))
+  }
+  for i := range p {
+    if p[i] != expected[i] {
+      t.Error("Test 1: Failed to compute sort index at", i, p[i],
+        expected[i])
+    }
+  }
+  if data[0] != 5 || data[2] != 3 {
+    t.Error("Test 1: Index modified the data.")
+  }
+
+  // test 2
+  smallest, err := Smallest(2, data, 2)
+  if err != nil || len(smallest) != 2 || smallest[0] != 1 ||
+    smallest[1] != 3 {
+    t.Error("Test 2: Failed to compute smallest elements.", smallest, err)
+  }
+
+  largest, err := Largest(2, data, 2)
+  if err != nil || len(largest) != 2 || largest[0] != 9 || largest[1] != 7 {
+    t.Error("Test 2: Failed to compute largest elements.", largest, err)
+  }
+
+  largest, err = Largest(3, data, 1)
+  if err != nil || largest[0] != 400 || largest[1] != 300 ||
+    largest[2] != 200 {
+    t.Error("Test 2: Failed to compute largest elements.", largest, err)
+  }
+
+  // test 3
+  pSmall, err := SmallestIndex(2, data, 2)
+  if err != nil || len(pSmall) != 2 || pSmall[0] != 3 || pSmall[1] != 1 {
+    t.Error("Test 3: Failed to compute smallest indices.", pSmall, err)
+  }
+
+  pLarge, err := LargestIndex(2, data, 2)
+  if err != nil || len(pLarge) != 2 || pLarge[0] != 2 || pLarge[1] != 4 {
+    t.Error("Test 3: Failed to compute largest indices.", pLarge, err)
+  }
+
+  // test 4
+  if smallest, err := Smallest(0, data, 1); err != nil ||
+    len(smallest) != 0 {
+    t.Error("Test 4: Failed to select zero elements.", smallest, err)
+  }
+}
+
+// test set 3
+func Test_sort_3(t *testing.T) {
+
+  data := []float64{5, 100, 3, 200, 9}
+
+  // test 1
+  if err := Sort(data, 0); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 1: Sort accepted invalid stride 0.")
+  }
+  if _, err := Index(data, -1); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 1: Index accepted invalid stride -1.")
+  }
+  if err := Sort(nil, 1); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 1: Sort accepted empty data.")
+  }
+  if _, err := LargestIndex(1, []float64{}, 1); !errors.Is(err,
+    gsl.EBADLEN) {
+    t.Error("Test 1: LargestIndex accepted empty data.")
+  }
+
+  // test 2
+  if _, err := Smallest(4, data, 2); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 2: Smallest accepted k larger than number of elements.")
+  }
+  if _, err := SmallestIndex(-1, data, 1); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 2: SmallestIndex accepted negative k.")
+  }
+
+  // test 3
+  if err := Sort2(data, 1, data, 2); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 3: Sort2 accepted datasets of different length.")
+  }
+}
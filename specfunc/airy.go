@@ -0,0 +1,162 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// airy wraps the gsl Airy functions, their derivatives and zeros
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_airy.h>
+import "C"
+
+// AiryAi returns the Airy function Ai(x) with an accuracy specified by mode.
+func AiryAi(x float64, mode Mode) float64 {
+  return float64(C.gsl_sf_airy_Ai(C.double(x), C.gsl_mode_t(mode)))
+}
+
+// AiryAiE is the error estimating form of AiryAi.
+func AiryAiE(x float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_Ai_e(C.double(x), C.gsl_mode_t(mode), res)
+  })
+}
+
+// AiryBi returns the Airy function Bi(x) with an accuracy specified by mode.
+func AiryBi(x float64, mode Mode) float64 {
+  return float64(C.gsl_sf_airy_Bi(C.double(x), C.gsl_mode_t(mode)))
+}
+
+// AiryBiE is the error estimating form of AiryBi.
+func AiryBiE(x float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_Bi_e(C.double(x), C.gsl_mode_t(mode), res)
+  })
+}
+
+// AiryAiScaled returns the scaled Airy function S_A(x) Ai(x), where S_A(x) =
+// exp(2/3 x^(3/2)) for x > 0 and 1 otherwise.
+func AiryAiScaled(x float64, mode Mode) float64 {
+  return float64(C.gsl_sf_airy_Ai_scaled(C.double(x), C.gsl_mode_t(mode)))
+}
+
+// AiryAiScaledE is the error estimating form of AiryAiScaled.
+func AiryAiScaledE(x float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_Ai_scaled_e(C.double(x), C.gsl_mode_t(mode), res)
+  })
+}
+
+// AiryBiScaled returns the scaled Airy function S_B(x) Bi(x), where S_B(x) =
+// exp(-2/3 x^(3/2)) for x > 0 and 1 otherwise.
+func AiryBiScaled(x float64, mode Mode) float64 {
+  return float64(C.gsl_sf_airy_Bi_scaled(C.double(x), C.gsl_mode_t(mode)))
+}
+
+// AiryBiScaledE is the error estimating form of AiryBiScaled.
+func AiryBiScaledE(x float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_Bi_scaled_e(C.double(x), C.gsl_mode_t(mode), res)
+  })
+}
+
+// AiryAiDeriv returns the Airy function derivative Ai'(x) with an accuracy
+// specified by mode.
+func AiryAiDeriv(x float64, mode Mode) float64 {
+  return float64(C.gsl_sf_airy_Ai_deriv(C.double(x), C.gsl_mode_t(mode)))
+}
+
+// AiryAiDerivE is the error estimating form of AiryAiDeriv.
+func AiryAiDerivE(x float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_Ai_deriv_e(C.double(x), C.gsl_mode_t(mode), res)
+  })
+}
+
+// AiryBiDeriv returns the Airy function derivative Bi'(x) with an accuracy
+// specified by mode.
+func AiryBiDeriv(x float64, mode Mode) float64 {
+  return float64(C.gsl_sf_airy_Bi_deriv(C.double(x), C.gsl_mode_t(mode)))
+}
+
+// AiryBiDerivE is the error estimating form of AiryBiDeriv.
+func AiryBiDerivE(x float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_Bi_deriv_e(C.double(x), C.gsl_mode_t(mode), res)
+  })
+}
+
+// AiryAiDerivScaled returns the scaled Airy function derivative S_A(x) Ai'(x),
+// see AiryAiScaled.
+func AiryAiDerivScaled(x float64, mode Mode) float64 {
+  return float64(C.gsl_sf_airy_Ai_deriv_scaled(C.double(x), C.gsl_mode_t(mode)))
+}
+
+// AiryAiDerivScaledE is the error estimating form of AiryAiDerivScaled.
+func AiryAiDerivScaledE(x float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_Ai_deriv_scaled_e(C.double(x), C.gsl_mode_t(mode), res)
+  })
+}
+
+// AiryBiDerivScaled returns the scaled Airy function derivative S_B(x) Bi'(x),
+// see AiryBiScaled.
+func AiryBiDerivScaled(x float64, mode Mode) float64 {
+  return float64(C.gsl_sf_airy_Bi_deriv_scaled(C.double(x), C.gsl_mode_t(mode)))
+}
+
+// AiryBiDerivScaledE is the error estimating form of AiryBiDerivScaled.
+func AiryBiDerivScaledE(x float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_Bi_deriv_scaled_e(C.double(x), C.gsl_mode_t(mode), res)
+  })
+}
+
+// AiryZeroAi returns the location of the s-th zero of the Airy function Ai(x).
+func AiryZeroAi(s uint) float64 {
+  return float64(C.gsl_sf_airy_zero_Ai(C.uint(s)))
+}
+
+// AiryZeroAiE is the error estimating form of AiryZeroAi.
+func AiryZeroAiE(s uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_zero_Ai_e(C.uint(s), res)
+  })
+}
+
+// AiryZeroBi returns the location of the s-th zero of the Airy function Bi(x).
+func AiryZeroBi(s uint) float64 {
+  return float64(C.gsl_sf_airy_zero_Bi(C.uint(s)))
+}
+
+// AiryZeroBiE is the error estimating form of AiryZeroBi.
+func AiryZeroBiE(s uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_zero_Bi_e(C.uint(s), res)
+  })
+}
+
+// AiryZeroAiDeriv returns the location of the s-th zero of the Airy function
+// derivative Ai'(x).
+func AiryZeroAiDeriv(s uint) float64 {
+  return float64(C.gsl_sf_airy_zero_Ai_deriv(C.uint(s)))
+}
+
+// AiryZeroAiDerivE is the error estimating form of AiryZeroAiDeriv.
+func AiryZeroAiDerivE(s uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_zero_Ai_deriv_e(C.uint(s), res)
+  })
+}
+
+// AiryZeroBiDeriv returns the location of the s-th zero of the Airy function
+// derivative Bi'(x).
+func AiryZeroBiDeriv(s uint) float64 {
+  return float64(C.gsl_sf_airy_zero_Bi_deriv(C.uint(s)))
+}
+
+// AiryZeroBiDerivE is the error estimating form of AiryZeroBiDeriv.
+func AiryZeroBiDerivE(s uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_airy_zero_Bi_deriv_e(C.uint(s), res)
+  })
+}
@@ -0,0 +1,46 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// airy wraps the gsl Airy functions, their derivatives and zeros
+package specfunc
+
+import (
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_airy_1(t *testing.T) {
+
+  // test 1
+  if !util.FloatEqual(AiryAi(0.0, PrecDouble), 0.3550280538878172) {
+    t.Error("Test 1: Wrong value for Ai(0)", AiryAi(0.0, PrecDouble))
+  }
+  if !util.FloatEqual(AiryBi(0.0, PrecDouble), 0.6149266274460007) {
+    t.Error("Test 1: Wrong value for Bi(0)", AiryBi(0.0, PrecDouble))
+  }
+
+  // test 2
+  x := 2.0
+  scale := math.Exp(2.0 / 3.0 * math.Pow(x, 1.5))
+  if !util.FloatEqual(AiryAiScaled(x, PrecDouble),
+    scale*AiryAi(x, PrecDouble)) {
+    t.Error("Test 2: Inconsistent scaled Ai at", x)
+  }
+
+  // test 3
+  if !util.FloatEqual(AiryZeroAi(1), -2.338107410459767) {
+    t.Error("Test 3: Wrong first zero of Ai", AiryZeroAi(1))
+  }
+  if math.Abs(AiryAi(AiryZeroAi(3), PrecDouble)) > 1e-14 {
+    t.Error("Test 3: Ai does not vanish at its zero")
+  }
+
+  // test 4
+  if math.Abs(AiryAi(0.5, PrecApprox)-AiryAi(0.5, PrecDouble)) > 1e-3 {
+    t.Error("Test 4: Approximate Ai too inaccurate")
+  }
+}
@@ -0,0 +1,452 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// bessel wraps the gsl regular and irregular, modified and spherical
+// Bessel functions and their zeros
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_bessel.h>
+import "C"
+
+// BesselJ0 returns the regular cylindrical Bessel function of zeroth order,
+// J_0(x).
+func BesselJ0(x float64) float64 {
+  return float64(C.gsl_sf_bessel_J0(C.double(x)))
+}
+
+// BesselJ0E is the error estimating form of BesselJ0.
+func BesselJ0E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_J0_e(C.double(x), res)
+  })
+}
+
+// BesselJ1 returns the regular cylindrical Bessel function of first order,
+// J_1(x).
+func BesselJ1(x float64) float64 {
+  return float64(C.gsl_sf_bessel_J1(C.double(x)))
+}
+
+// BesselJ1E is the error estimating form of BesselJ1.
+func BesselJ1E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_J1_e(C.double(x), res)
+  })
+}
+
+// BesselJn returns the regular cylindrical Bessel function of order n, J_n(x).
+func BesselJn(n int, x float64) float64 {
+  return float64(C.gsl_sf_bessel_Jn(C.int(n), C.double(x)))
+}
+
+// BesselJnE is the error estimating form of BesselJn.
+func BesselJnE(n int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_Jn_e(C.int(n), C.double(x), res)
+  })
+}
+
+// BesselY0 returns the irregular cylindrical Bessel function of zeroth order,
+// Y_0(x), for x > 0.
+func BesselY0(x float64) float64 {
+  return float64(C.gsl_sf_bessel_Y0(C.double(x)))
+}
+
+// BesselY0E is the error estimating form of BesselY0.
+func BesselY0E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_Y0_e(C.double(x), res)
+  })
+}
+
+// BesselY1 returns the irregular cylindrical Bessel function of first order,
+// Y_1(x), for x > 0.
+func BesselY1(x float64) float64 {
+  return float64(C.gsl_sf_bessel_Y1(C.double(x)))
+}
+
+// BesselY1E is the error estimating form of BesselY1.
+func BesselY1E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_Y1_e(C.double(x), res)
+  })
+}
+
+// BesselYn returns the irregular cylindrical Bessel function of order n,
+// Y_n(x), for x > 0.
+func BesselYn(n int, x float64) float64 {
+  return float64(C.gsl_sf_bessel_Yn(C.int(n), C.double(x)))
+}
+
+// BesselYnE is the error estimating form of BesselYn.
+func BesselYnE(n int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_Yn_e(C.int(n), C.double(x), res)
+  })
+}
+
+// BesselI0 returns the regular modified cylindrical Bessel function of zeroth
+// order, I_0(x).
+func BesselI0(x float64) float64 {
+  return float64(C.gsl_sf_bessel_I0(C.double(x)))
+}
+
+// BesselI0E is the error estimating form of BesselI0.
+func BesselI0E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_I0_e(C.double(x), res)
+  })
+}
+
+// BesselI1 returns the regular modified cylindrical Bessel function of first
+// order, I_1(x).
+func BesselI1(x float64) float64 {
+  return float64(C.gsl_sf_bessel_I1(C.double(x)))
+}
+
+// BesselI1E is the error estimating form of BesselI1.
+func BesselI1E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_I1_e(C.double(x), res)
+  })
+}
+
+// BesselIn returns the regular modified cylindrical Bessel function of order n,
+// I_n(x).
+func BesselIn(n int, x float64) float64 {
+  return float64(C.gsl_sf_bessel_In(C.int(n), C.double(x)))
+}
+
+// BesselInE is the error estimating form of BesselIn.
+func BesselInE(n int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_In_e(C.int(n), C.double(x), res)
+  })
+}
+
+// BesselI0Scaled returns the scaled regular modified cylindrical Bessel
+// function of zeroth order, exp(-|x|) I_0(x).
+func BesselI0Scaled(x float64) float64 {
+  return float64(C.gsl_sf_bessel_I0_scaled(C.double(x)))
+}
+
+// BesselI0ScaledE is the error estimating form of BesselI0Scaled.
+func BesselI0ScaledE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_I0_scaled_e(C.double(x), res)
+  })
+}
+
+// BesselI1Scaled returns the scaled regular modified cylindrical Bessel
+// function of first order, exp(-|x|) I_1(x).
+func BesselI1Scaled(x float64) float64 {
+  return float64(C.gsl_sf_bessel_I1_scaled(C.double(x)))
+}
+
+// BesselI1ScaledE is the error estimating form of BesselI1Scaled.
+func BesselI1ScaledE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_I1_scaled_e(C.double(x), res)
+  })
+}
+
+// BesselInScaled returns the scaled regular modified cylindrical Bessel
+// function of order n, exp(-|x|) I_n(x).
+func BesselInScaled(n int, x float64) float64 {
+  return float64(C.gsl_sf_bessel_In_scaled(C.int(n), C.double(x)))
+}
+
+// BesselInScaledE is the error estimating form of BesselInScaled.
+func BesselInScaledE(n int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_In_scaled_e(C.int(n), C.double(x), res)
+  })
+}
+
+// BesselK0 returns the irregular modified cylindrical Bessel function of zeroth
+// order, K_0(x), for x > 0.
+func BesselK0(x float64) float64 {
+  return float64(C.gsl_sf_bessel_K0(C.double(x)))
+}
+
+// BesselK0E is the error estimating form of BesselK0.
+func BesselK0E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_K0_e(C.double(x), res)
+  })
+}
+
+// BesselK1 returns the irregular modified cylindrical Bessel function of first
+// order, K_1(x), for x > 0.
+func BesselK1(x float64) float64 {
+  return float64(C.gsl_sf_bessel_K1(C.double(x)))
+}
+
+// BesselK1E is the error estimating form of BesselK1.
+func BesselK1E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_K1_e(C.double(x), res)
+  })
+}
+
+// BesselKn returns the irregular modified cylindrical Bessel function of order
+// n, K_n(x), for x > 0.
+func BesselKn(n int, x float64) float64 {
+  return float64(C.gsl_sf_bessel_Kn(C.int(n), C.double(x)))
+}
+
+// BesselKnE is the error estimating form of BesselKn.
+func BesselKnE(n int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_Kn_e(C.int(n), C.double(x), res)
+  })
+}
+
+// BesselK0Scaled returns the scaled irregular modified cylindrical Bessel
+// function of zeroth order, exp(x) K_0(x), for x > 0.
+func BesselK0Scaled(x float64) float64 {
+  return float64(C.gsl_sf_bessel_K0_scaled(C.double(x)))
+}
+
+// BesselK0ScaledE is the error estimating form of BesselK0Scaled.
+func BesselK0ScaledE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_K0_scaled_e(C.double(x), res)
+  })
+}
+
+// BesselK1Scaled returns the scaled irregular modified cylindrical Bessel
+// function of first order, exp(x) K_1(x), for x > 0.
+func BesselK1Scaled(x float64) float64 {
+  return float64(C.gsl_sf_bessel_K1_scaled(C.double(x)))
+}
+
+// BesselK1ScaledE is the error estimating form of BesselK1Scaled.
+func BesselK1ScaledE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_K1_scaled_e(C.double(x), res)
+  })
+}
+
+// BesselKnScaled returns the scaled irregular modified cylindrical Bessel
+// function of order n, exp(x) K_n(x), for x > 0.
+func BesselKnScaled(n int, x float64) float64 {
+  return float64(C.gsl_sf_bessel_Kn_scaled(C.int(n), C.double(x)))
+}
+
+// BesselKnScaledE is the error estimating form of BesselKnScaled.
+func BesselKnScaledE(n int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_Kn_scaled_e(C.int(n), C.double(x), res)
+  })
+}
+
+// BesselSphJ0 returns the regular spherical Bessel function of zeroth order,
+// j_0(x) = sin(x)/x.
+func BesselSphJ0(x float64) float64 {
+  return float64(C.gsl_sf_bessel_j0(C.double(x)))
+}
+
+// BesselSphJ0E is the error estimating form of BesselSphJ0.
+func BesselSphJ0E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_j0_e(C.double(x), res)
+  })
+}
+
+// BesselSphJ1 returns the regular spherical Bessel function of first order,
+// j_1(x) = (sin(x)/x - cos(x))/x.
+func BesselSphJ1(x float64) float64 {
+  return float64(C.gsl_sf_bessel_j1(C.double(x)))
+}
+
+// BesselSphJ1E is the error estimating form of BesselSphJ1.
+func BesselSphJ1E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_j1_e(C.double(x), res)
+  })
+}
+
+// BesselSphJ2 returns the regular spherical Bessel function of second order,
+// j_2(x) = ((3/x^2 - 1)sin(x) - 3cos(x)/x)/x.
+func BesselSphJ2(x float64) float64 {
+  return float64(C.gsl_sf_bessel_j2(C.double(x)))
+}
+
+// BesselSphJ2E is the error estimating form of BesselSphJ2.
+func BesselSphJ2E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_j2_e(C.double(x), res)
+  })
+}
+
+// BesselSphJl returns the regular spherical Bessel function of order l, j_l(x),
+// for l >= 0 and x >= 0.
+func BesselSphJl(l int, x float64) float64 {
+  return float64(C.gsl_sf_bessel_jl(C.int(l), C.double(x)))
+}
+
+// BesselSphJlE is the error estimating form of BesselSphJl.
+func BesselSphJlE(l int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_jl_e(C.int(l), C.double(x), res)
+  })
+}
+
+// BesselSphY0 returns the irregular spherical Bessel function of zeroth order,
+// y_0(x) = -cos(x)/x.
+func BesselSphY0(x float64) float64 {
+  return float64(C.gsl_sf_bessel_y0(C.double(x)))
+}
+
+// BesselSphY0E is the error estimating form of BesselSphY0.
+func BesselSphY0E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_y0_e(C.double(x), res)
+  })
+}
+
+// BesselSphY1 returns the irregular spherical Bessel function of first order,
+// y_1(x) = -(cos(x)/x + sin(x))/x.
+func BesselSphY1(x float64) float64 {
+  return float64(C.gsl_sf_bessel_y1(C.double(x)))
+}
+
+// BesselSphY1E is the error estimating form of BesselSphY1.
+func BesselSphY1E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_y1_e(C.double(x), res)
+  })
+}
+
+// BesselSphY2 returns the irregular spherical Bessel function of second order,
+// y_2(x) = (-3/x^3 + 1/x)cos(x) - (3/x^2)sin(x).
+func BesselSphY2(x float64) float64 {
+  return float64(C.gsl_sf_bessel_y2(C.double(x)))
+}
+
+// BesselSphY2E is the error estimating form of BesselSphY2.
+func BesselSphY2E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_y2_e(C.double(x), res)
+  })
+}
+
+// BesselSphYl returns the irregular spherical Bessel function of order l,
+// y_l(x), for l >= 0.
+func BesselSphYl(l int, x float64) float64 {
+  return float64(C.gsl_sf_bessel_yl(C.int(l), C.double(x)))
+}
+
+// BesselSphYlE is the error estimating form of BesselSphYl.
+func BesselSphYlE(l int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_yl_e(C.int(l), C.double(x), res)
+  })
+}
+
+// BesselJnu returns the regular cylindrical Bessel function of fractional order
+// nu, J_nu(x).
+func BesselJnu(nu, x float64) float64 {
+  return float64(C.gsl_sf_bessel_Jnu(C.double(nu), C.double(x)))
+}
+
+// BesselJnuE is the error estimating form of BesselJnu.
+func BesselJnuE(nu, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_Jnu_e(C.double(nu), C.double(x), res)
+  })
+}
+
+// BesselYnu returns the irregular cylindrical Bessel function of fractional
+// order nu, Y_nu(x).
+func BesselYnu(nu, x float64) float64 {
+  return float64(C.gsl_sf_bessel_Ynu(C.double(nu), C.double(x)))
+}
+
+// BesselYnuE is the error estimating form of BesselYnu.
+func BesselYnuE(nu, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_Ynu_e(C.double(nu), C.double(x), res)
+  })
+}
+
+// BesselInu returns the regular modified Bessel function of fractional order
+// nu, I_nu(x), for x > 0 and nu > 0.
+func BesselInu(nu, x float64) float64 {
+  return float64(C.gsl_sf_bessel_Inu(C.double(nu), C.double(x)))
+}
+
+// BesselInuE is the error estimating form of BesselInu.
+func BesselInuE(nu, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_Inu_e(C.double(nu), C.double(x), res)
+  })
+}
+
+// BesselKnu returns the irregular modified Bessel function of fractional order
+// nu, K_nu(x), for x > 0 and nu > 0.
+func BesselKnu(nu, x float64) float64 {
+  return float64(C.gsl_sf_bessel_Knu(C.double(nu), C.double(x)))
+}
+
+// BesselKnuE is the error estimating form of BesselKnu.
+func BesselKnuE(nu, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_Knu_e(C.double(nu), C.double(x), res)
+  })
+}
+
+// BesselLnKnu returns the logarithm of the irregular modified Bessel function
+// of fractional order nu, ln(K_nu(x)), for x > 0 and nu > 0.
+func BesselLnKnu(nu, x float64) float64 {
+  return float64(C.gsl_sf_bessel_lnKnu(C.double(nu), C.double(x)))
+}
+
+// BesselLnKnuE is the error estimating form of BesselLnKnu.
+func BesselLnKnuE(nu, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_lnKnu_e(C.double(nu), C.double(x), res)
+  })
+}
+
+// BesselZeroJ0 returns the location of the s-th positive zero of the Bessel
+// function J_0(x).
+func BesselZeroJ0(s uint) float64 {
+  return float64(C.gsl_sf_bessel_zero_J0(C.uint(s)))
+}
+
+// BesselZeroJ0E is the error estimating form of BesselZeroJ0.
+func BesselZeroJ0E(s uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_zero_J0_e(C.uint(s), res)
+  })
+}
+
+// BesselZeroJ1 returns the location of the s-th positive zero of the Bessel
+// function J_1(x).
+func BesselZeroJ1(s uint) float64 {
+  return float64(C.gsl_sf_bessel_zero_J1(C.uint(s)))
+}
+
+// BesselZeroJ1E is the error estimating form of BesselZeroJ1.
+func BesselZeroJ1E(s uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_zero_J1_e(C.uint(s), res)
+  })
+}
+
+// BesselZeroJnu returns the location of the s-th positive zero of the Bessel
+// function J_nu(x) for nu >= 0.
+func BesselZeroJnu(nu float64, s uint) float64 {
+  return float64(C.gsl_sf_bessel_zero_Jnu(C.double(nu), C.uint(s)))
+}
+
+// BesselZeroJnuE is the error estimating form of BesselZeroJnu.
+func BesselZeroJnuE(nu float64, s uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_bessel_zero_Jnu_e(C.double(nu), C.uint(s), res)
+  })
+}
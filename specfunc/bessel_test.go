@@ -0,0 +1,67 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// bessel wraps the gsl regular and irregular, modified and spherical
+// Bessel functions and their zeros
+package specfunc
+
+import (
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_bessel_1(t *testing.T) {
+
+  // test 1
+  for _, c := range []struct {
+    name     string
+    val, exp float64
+  }{
+    {"J0", BesselJ0(1.0), 0.7651976865579666},
+    {"J1", BesselJ1(1.0), 0.44005058574493355},
+    {"Jn", BesselJn(1, 1.0), 0.44005058574493355},
+    {"Y0", BesselY0(1.0), 0.08825696421567696},
+    {"I0", BesselI0(1.0), 1.2660658777520082},
+    {"I0Scaled", BesselI0Scaled(1.0), 1.2660658777520082 * math.Exp(-1.0)},
+    {"K0", BesselK0(1.0), 0.42102443824070834},
+    {"Jnu", BesselJnu(0.0, 1.0), 0.7651976865579666},
+    {"Knu", BesselKnu(0.0, 1.0), 0.42102443824070834},
+    {"LnKnu", BesselLnKnu(0.0, 1.0), math.Log(0.42102443824070834)},
+  } {
+    if !util.FloatEqual(c.val, c.exp) {
+      t.Error("Test 1: Wrong value for", c.name, c.val, c.exp)
+    }
+  }
+
+  // test 2
+  for _, x := range []float64{0.5, 1.0, 3.0} {
+    if !util.FloatEqual(BesselSphJ0(x), math.Sin(x)/x) {
+      t.Error("Test 2: Wrong value for j0 at", x)
+    }
+    if !util.FloatEqual(BesselSphJl(0, x), math.Sin(x)/x) {
+      t.Error("Test 2: Wrong value for jl at", x)
+    }
+    if !util.FloatEqual(BesselSphY0(x), -math.Cos(x)/x) {
+      t.Error("Test 2: Wrong value for y0 at", x)
+    }
+  }
+
+  // test 3
+  if !util.FloatEqual(BesselZeroJ0(1), 2.404825557695773) {
+    t.Error("Test 3: Wrong first zero of J0", BesselZeroJ0(1))
+  }
+  if math.Abs(BesselJ1(BesselZeroJ1(2))) > 1e-14 {
+    t.Error("Test 3: J1 does not vanish at its zero")
+  }
+
+  // test 4
+  val, est, err := BesselJ0E(1.0)
+  if err != nil || !util.FloatEqual(val, 0.7651976865579666) || est <= 0 ||
+    est > 1e-14 {
+    t.Error("Test 4: Unexpected result for J0E", val, est, err)
+  }
+}
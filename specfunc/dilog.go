@@ -0,0 +1,22 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// dilog wraps the gsl dilogarithm
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_dilog.h>
+import "C"
+
+// Dilog returns the dilogarithm Li_2(x) = -int_0^x ln(1-t)/t dt for real x.
+func Dilog(x float64) float64 {
+  return float64(C.gsl_sf_dilog(C.double(x)))
+}
+
+// DilogE is the error estimating form of Dilog.
+func DilogE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_dilog_e(C.double(x), res)
+  })
+}
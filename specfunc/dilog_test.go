@@ -0,0 +1,34 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// dilog wraps the gsl dilogarithm
+package specfunc
+
+import (
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_dilog_1(t *testing.T) {
+
+  pi2 := math.Pi * math.Pi
+
+  // test 1
+  for _, c := range []struct{ x, exp float64 }{
+    {1.0, pi2 / 6.0},
+    {-1.0, -pi2 / 12.0},
+    {0.5, pi2/12.0 - math.Ln2*math.Ln2/2.0},
+  } {
+    val, _, err := DilogE(c.x)
+    if err != nil || !util.FloatEqual(val, c.exp) {
+      t.Error("Test 1: Wrong value for dilog at", c.x, val, c.exp, err)
+    }
+    if Dilog(c.x) != val {
+      t.Error("Test 1: Plain and error estimating form differ at", c.x)
+    }
+  }
+}
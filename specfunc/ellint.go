@@ -0,0 +1,173 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// ellint wraps the gsl elliptic integrals in Legendre and Carlson form
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_ellint.h>
+import "C"
+
+// EllintKcomp returns the complete elliptic integral of the first kind K(k)
+// with an accuracy specified by mode. Note that the argument is the modulus k
+// and not the parameter m = k^2.
+func EllintKcomp(k float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_Kcomp(C.double(k), C.gsl_mode_t(mode)))
+}
+
+// EllintKcompE is the error estimating form of EllintKcomp.
+func EllintKcompE(k float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_Kcomp_e(C.double(k), C.gsl_mode_t(mode), res)
+  })
+}
+
+// EllintEcomp returns the complete elliptic integral of the second kind E(k)
+// with an accuracy specified by mode.
+func EllintEcomp(k float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_Ecomp(C.double(k), C.gsl_mode_t(mode)))
+}
+
+// EllintEcompE is the error estimating form of EllintEcomp.
+func EllintEcompE(k float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_Ecomp_e(C.double(k), C.gsl_mode_t(mode), res)
+  })
+}
+
+// EllintPcomp returns the complete elliptic integral of the third kind Pi(k,n)
+// with an accuracy specified by mode. Note the sign convention of n which
+// corresponds to the GSL and not to Abramowitz and Stegun.
+func EllintPcomp(k, n float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_Pcomp(C.double(k), C.double(n),
+    C.gsl_mode_t(mode)))
+}
+
+// EllintPcompE is the error estimating form of EllintPcomp.
+func EllintPcompE(k, n float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_Pcomp_e(C.double(k), C.double(n), C.gsl_mode_t(mode),
+      res)
+  })
+}
+
+// EllintF returns the incomplete elliptic integral of the first kind F(phi,k)
+// with an accuracy specified by mode.
+func EllintF(phi, k float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_F(C.double(phi), C.double(k),
+    C.gsl_mode_t(mode)))
+}
+
+// EllintFE is the error estimating form of EllintF.
+func EllintFE(phi, k float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_F_e(C.double(phi), C.double(k), C.gsl_mode_t(mode),
+      res)
+  })
+}
+
+// EllintE returns the incomplete elliptic integral of the second kind E(phi,k)
+// with an accuracy specified by mode.
+func EllintE(phi, k float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_E(C.double(phi), C.double(k),
+    C.gsl_mode_t(mode)))
+}
+
+// EllintEE is the error estimating form of EllintE.
+func EllintEE(phi, k float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_E_e(C.double(phi), C.double(k), C.gsl_mode_t(mode),
+      res)
+  })
+}
+
+// EllintP returns the incomplete elliptic integral of the third kind
+// Pi(phi,k,n) with an accuracy specified by mode.
+func EllintP(phi, k, n float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_P(C.double(phi), C.double(k), C.double(n),
+    C.gsl_mode_t(mode)))
+}
+
+// EllintPE is the error estimating form of EllintP.
+func EllintPE(phi, k, n float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_P_e(C.double(phi), C.double(k), C.double(n),
+      C.gsl_mode_t(mode), res)
+  })
+}
+
+// EllintD returns the incomplete elliptic integral D(phi,k) = (F(phi,k) -
+// E(phi,k))/k^2 with an accuracy specified by mode.
+func EllintD(phi, k float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_D(C.double(phi), C.double(k),
+    C.gsl_mode_t(mode)))
+}
+
+// EllintDE is the error estimating form of EllintD.
+func EllintDE(phi, k float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_D_e(C.double(phi), C.double(k), C.gsl_mode_t(mode),
+      res)
+  })
+}
+
+// EllintRC returns the Carlson form of the degenerate elliptic integral RC(x,y)
+// with an accuracy specified by mode.
+func EllintRC(x, y float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_RC(C.double(x), C.double(y),
+    C.gsl_mode_t(mode)))
+}
+
+// EllintRCE is the error estimating form of EllintRC.
+func EllintRCE(x, y float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_RC_e(C.double(x), C.double(y), C.gsl_mode_t(mode),
+      res)
+  })
+}
+
+// EllintRD returns the Carlson form of the incomplete elliptic integral
+// RD(x,y,z) with an accuracy specified by mode.
+func EllintRD(x, y, z float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_RD(C.double(x), C.double(y), C.double(z),
+    C.gsl_mode_t(mode)))
+}
+
+// EllintRDE is the error estimating form of EllintRD.
+func EllintRDE(x, y, z float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_RD_e(C.double(x), C.double(y), C.double(z),
+      C.gsl_mode_t(mode), res)
+  })
+}
+
+// EllintRF returns the Carlson form of the incomplete elliptic integral
+// RF(x,y,z) with an accuracy specified by mode.
+func EllintRF(x, y, z float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_RF(C.double(x), C.double(y), C.double(z),
+    C.gsl_mode_t(mode)))
+}
+
+// EllintRFE is the error estimating form of EllintRF.
+func EllintRFE(x, y, z float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_RF_e(C.double(x), C.double(y), C.double(z),
+      C.gsl_mode_t(mode), res)
+  })
+}
+
+// EllintRJ returns the Carlson form of the incomplete elliptic integral
+// RJ(x,y,z,p) with an accuracy specified by mode.
+func EllintRJ(x, y, z, p float64, mode Mode) float64 {
+  return float64(C.gsl_sf_ellint_RJ(C.double(x), C.double(y), C.double(z),
+    C.double(p), C.gsl_mode_t(mode)))
+}
+
+// EllintRJE is the error estimating form of EllintRJ.
+func EllintRJE(x, y, z, p float64, mode Mode) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_ellint_RJ_e(C.double(x), C.double(y), C.double(z),
+      C.double(p), C.gsl_mode_t(mode), res)
+  })
+}
@@ -0,0 +1,44 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// ellint wraps the gsl elliptic integrals in Legendre and Carlson form
+package specfunc
+
+import (
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_ellint_1(t *testing.T) {
+
+  // test 1
+  if !util.FloatEqual(EllintKcomp(0.0, PrecDouble), math.Pi/2.0) {
+    t.Error("Test 1: Wrong value for K(0)", EllintKcomp(0.0, PrecDouble))
+  }
+  if !util.FloatEqual(EllintEcomp(0.0, PrecDouble), math.Pi/2.0) {
+    t.Error("Test 1: Wrong value for E(0)", EllintEcomp(0.0, PrecDouble))
+  }
+
+  // test 2
+  phi := 0.7
+  if !util.FloatEqual(EllintF(phi, 0.0, PrecDouble), phi) {
+    t.Error("Test 2: Wrong value for F(phi,0)", EllintF(phi, 0.0, PrecDouble))
+  }
+  if !util.FloatEqual(EllintF(math.Pi/2.0, 0.5, PrecDouble),
+    EllintKcomp(0.5, PrecDouble)) {
+    t.Error("Test 2: F(pi/2,k) differs from K(k)")
+  }
+
+  // test 3
+  if !util.FloatEqual(EllintRC(4.0, 4.0, PrecDouble), 0.5) {
+    t.Error("Test 3: Wrong value for RC(4,4)", EllintRC(4.0, 4.0, PrecDouble))
+  }
+  if !util.FloatEqual(EllintRF(4.0, 4.0, 4.0, PrecDouble), 0.5) {
+    t.Error("Test 3: Wrong value for RF(4,4,4)",
+      EllintRF(4.0, 4.0, 4.0, PrecDouble))
+  }
+}
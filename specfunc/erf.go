@@ -0,0 +1,86 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// erf wraps the gsl error functions
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_erf.h>
+import "C"
+
+// Erf returns the error function erf(x) = (2/sqrt(pi)) int_0^x exp(-t^2) dt.
+func Erf(x float64) float64 {
+  return float64(C.gsl_sf_erf(C.double(x)))
+}
+
+// ErfE is the error estimating form of Erf.
+func ErfE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_erf_e(C.double(x), res)
+  })
+}
+
+// Erfc returns the complementary error function erfc(x) = 1 - erf(x).
+func Erfc(x float64) float64 {
+  return float64(C.gsl_sf_erfc(C.double(x)))
+}
+
+// ErfcE is the error estimating form of Erfc.
+func ErfcE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_erfc_e(C.double(x), res)
+  })
+}
+
+// LogErfc returns the logarithm of the complementary error function,
+// ln(erfc(x)).
+func LogErfc(x float64) float64 {
+  return float64(C.gsl_sf_log_erfc(C.double(x)))
+}
+
+// LogErfcE is the error estimating form of LogErfc.
+func LogErfcE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_log_erfc_e(C.double(x), res)
+  })
+}
+
+// ErfZ returns the Gaussian probability density function Z(x) = (1/sqrt(2 pi))
+// exp(-x^2/2).
+func ErfZ(x float64) float64 {
+  return float64(C.gsl_sf_erf_Z(C.double(x)))
+}
+
+// ErfZE is the error estimating form of ErfZ.
+func ErfZE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_erf_Z_e(C.double(x), res)
+  })
+}
+
+// ErfQ returns the upper tail of the Gaussian probability function Q(x) =
+// (1/sqrt(2 pi)) int_x^inf exp(-t^2/2) dt.
+func ErfQ(x float64) float64 {
+  return float64(C.gsl_sf_erf_Q(C.double(x)))
+}
+
+// ErfQE is the error estimating form of ErfQ.
+func ErfQE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_erf_Q_e(C.double(x), res)
+  })
+}
+
+// Hazard returns the hazard function for the normal distribution, h(x) =
+// Z(x)/Q(x).
+func Hazard(x float64) float64 {
+  return float64(C.gsl_sf_hazard(C.double(x)))
+}
+
+// HazardE is the error estimating form of Hazard.
+func HazardE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_hazard_e(C.double(x), res)
+  })
+}
@@ -0,0 +1,47 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// erf wraps the gsl error functions
+package specfunc
+
+import (
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_erf_1(t *testing.T) {
+
+  // test 1
+  if !util.FloatEqual(Erf(1.0), 0.8427007929497149) {
+    t.Error("Test 1: Wrong value for erf(1)", Erf(1.0))
+  }
+  if !util.FloatEqual(Erfc(1.0), 0.15729920705028513) {
+    t.Error("Test 1: Wrong value for erfc(1)", Erfc(1.0))
+  }
+  if !util.FloatEqual(LogErfc(1.0), math.Log(0.15729920705028513)) {
+    t.Error("Test 1: Wrong value for log(erfc(1))", LogErfc(1.0))
+  }
+
+  // test 2
+  if !util.FloatEqual(ErfZ(0.0), 1.0/math.Sqrt(2.0*math.Pi)) {
+    t.Error("Test 2: Wrong value for Z(0)", ErfZ(0.0))
+  }
+  if !util.FloatEqual(ErfQ(0.0), 0.5) {
+    t.Error("Test 2: Wrong value for Q(0)", ErfQ(0.0))
+  }
+  if !util.FloatEqual(Hazard(0.0), 2.0/math.Sqrt(2.0*math.Pi)) {
+    t.Error("Test 2: Wrong value for hazard(0)", Hazard(0.0))
+  }
+
+  // test 3
+  for _, x := range []float64{-2.0, 0.3, 1.7} {
+    val, _, err := ErfE(x)
+    if err != nil || !util.FloatEqual(val, math.Erf(x)) {
+      t.Error("Test 3: Wrong value for erf at", x, val, err)
+    }
+  }
+}
@@ -0,0 +1,136 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// expint wraps the gsl exponential, hyperbolic and trigonometric
+// integrals
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_expint.h>
+import "C"
+
+// ExpintE1 returns the exponential integral E_1(x) = int_1^inf exp(-xt)/t dt.
+func ExpintE1(x float64) float64 {
+  return float64(C.gsl_sf_expint_E1(C.double(x)))
+}
+
+// ExpintE1E is the error estimating form of ExpintE1.
+func ExpintE1E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_expint_E1_e(C.double(x), res)
+  })
+}
+
+// ExpintE2 returns the second order exponential integral E_2(x) = int_1^inf
+// exp(-xt)/t^2 dt.
+func ExpintE2(x float64) float64 {
+  return float64(C.gsl_sf_expint_E2(C.double(x)))
+}
+
+// ExpintE2E is the error estimating form of ExpintE2.
+func ExpintE2E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_expint_E2_e(C.double(x), res)
+  })
+}
+
+// ExpintEn returns the exponential integral of order n, E_n(x) = int_1^inf
+// exp(-xt)/t^n dt.
+func ExpintEn(n int, x float64) float64 {
+  return float64(C.gsl_sf_expint_En(C.int(n), C.double(x)))
+}
+
+// ExpintEnE is the error estimating form of ExpintEn.
+func ExpintEnE(n int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_expint_En_e(C.int(n), C.double(x), res)
+  })
+}
+
+// ExpintEi returns the exponential integral Ei(x) = -PV(int_-x^inf exp(-t)/t
+// dt).
+func ExpintEi(x float64) float64 {
+  return float64(C.gsl_sf_expint_Ei(C.double(x)))
+}
+
+// ExpintEiE is the error estimating form of ExpintEi.
+func ExpintEiE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_expint_Ei_e(C.double(x), res)
+  })
+}
+
+// Shi returns the hyperbolic sine integral Shi(x) = int_0^x sinh(t)/t dt.
+func Shi(x float64) float64 {
+  return float64(C.gsl_sf_Shi(C.double(x)))
+}
+
+// ShiE is the error estimating form of Shi.
+func ShiE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_Shi_e(C.double(x), res)
+  })
+}
+
+// Chi returns the hyperbolic cosine integral Chi(x) = gamma_E + ln(x) + int_0^x
+// (cosh(t) - 1)/t dt, where gamma_E is the Euler constant.
+func Chi(x float64) float64 {
+  return float64(C.gsl_sf_Chi(C.double(x)))
+}
+
+// ChiE is the error estimating form of Chi.
+func ChiE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_Chi_e(C.double(x), res)
+  })
+}
+
+// Expint3 returns the third order exponential integral Ei_3(x) = int_0^x
+// exp(-t^3) dt for x >= 0.
+func Expint3(x float64) float64 {
+  return float64(C.gsl_sf_expint_3(C.double(x)))
+}
+
+// Expint3E is the error estimating form of Expint3.
+func Expint3E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_expint_3_e(C.double(x), res)
+  })
+}
+
+// Si returns the sine integral Si(x) = int_0^x sin(t)/t dt.
+func Si(x float64) float64 {
+  return float64(C.gsl_sf_Si(C.double(x)))
+}
+
+// SiE is the error estimating form of Si.
+func SiE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_Si_e(C.double(x), res)
+  })
+}
+
+// Ci returns the cosine integral Ci(x) = -int_x^inf cos(t)/t dt for x > 0.
+func Ci(x float64) float64 {
+  return float64(C.gsl_sf_Ci(C.double(x)))
+}
+
+// CiE is the error estimating form of Ci.
+func CiE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_Ci_e(C.double(x), res)
+  })
+}
+
+// Atanint returns the arctangent integral AtanInt(x) = int_0^x arctan(t)/t dt.
+func Atanint(x float64) float64 {
+  return float64(C.gsl_sf_atanint(C.double(x)))
+}
+
+// AtanintE is the error estimating form of Atanint.
+func AtanintE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_atanint_e(C.double(x), res)
+  })
+}
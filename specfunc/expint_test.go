@@ -0,0 +1,50 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// expint wraps the gsl exponential, hyperbolic and trigonometric
+// integrals
+package specfunc
+
+import (
+  "errors"
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_expint_1(t *testing.T) {
+
+  // test 1
+  for _, c := range []struct {
+    name     string
+    val, exp float64
+  }{
+    {"E1", ExpintE1(1.0), 0.21938393439552029},
+    {"En", ExpintEn(1, 1.0), 0.21938393439552029},
+    {"E2", ExpintE2(1.0), math.Exp(-1.0) - 0.21938393439552029},
+    {"Ei", ExpintEi(1.0), 1.8951178163559368},
+    {"Shi", Shi(1.0), (1.8951178163559368 + 0.21938393439552029) / 2.0},
+    {"Chi", Chi(1.0), (1.8951178163559368 - 0.21938393439552029) / 2.0},
+  } {
+    if !util.FloatEqual(c.val, c.exp) {
+      t.Error("Test 1: Wrong value for", c.name, c.val, c.exp)
+    }
+  }
+
+  // test 2
+  if Si(0.0) != 0.0 || Atanint(0.0) != 0.0 {
+    t.Error("Test 2: Si(0) and AtanInt(0) should vanish.")
+  }
+  if math.Abs(Si(1e3)-math.Pi/2.0) > 1e-3 {
+    t.Error("Test 2: Wrong asymptotic value for Si", Si(1e3))
+  }
+
+  // test 3
+  if _, _, err := ExpintE1E(0.0); !errors.Is(err, gsl.EDOM) {
+    t.Error("Test 3: Expected EDOM for E1(0), got", err)
+  }
+}
@@ -0,0 +1,274 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// gamma wraps the gsl Gamma and Beta functions including factorials,
+// Pochhammer symbols and the incomplete Gamma and Beta functions
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_gamma.h>
+import "C"
+
+// Gamma returns the Gamma function Gamma(x), for x not a negative integer or
+// zero.
+func Gamma(x float64) float64 {
+  return float64(C.gsl_sf_gamma(C.double(x)))
+}
+
+// GammaE is the error estimating form of Gamma.
+func GammaE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_gamma_e(C.double(x), res)
+  })
+}
+
+// LnGamma returns the logarithm of the absolute value of the Gamma function,
+// ln|Gamma(x)|, for x not a negative integer or zero.
+func LnGamma(x float64) float64 {
+  return float64(C.gsl_sf_lngamma(C.double(x)))
+}
+
+// LnGammaE is the error estimating form of LnGamma.
+func LnGammaE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_lngamma_e(C.double(x), res)
+  })
+}
+
+// Gammastar returns the regulated Gamma function Gamma*(x) = Gamma(x)/(sqrt(2
+// pi) x^(x-1/2) exp(-x)) for x > 0.
+func Gammastar(x float64) float64 {
+  return float64(C.gsl_sf_gammastar(C.double(x)))
+}
+
+// GammastarE is the error estimating form of Gammastar.
+func GammastarE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_gammastar_e(C.double(x), res)
+  })
+}
+
+// Gammainv returns the reciprocal of the Gamma function, 1/Gamma(x), using the
+// real Lanczos method.
+func Gammainv(x float64) float64 {
+  return float64(C.gsl_sf_gammainv(C.double(x)))
+}
+
+// GammainvE is the error estimating form of Gammainv.
+func GammainvE(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_gammainv_e(C.double(x), res)
+  })
+}
+
+// Fact returns the factorial n!.
+func Fact(n uint) float64 {
+  return float64(C.gsl_sf_fact(C.uint(n)))
+}
+
+// FactE is the error estimating form of Fact.
+func FactE(n uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_fact_e(C.uint(n), res)
+  })
+}
+
+// DoubleFact returns the double factorial n!! = n(n-2)(n-4)...
+func DoubleFact(n uint) float64 {
+  return float64(C.gsl_sf_doublefact(C.uint(n)))
+}
+
+// DoubleFactE is the error estimating form of DoubleFact.
+func DoubleFactE(n uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_doublefact_e(C.uint(n), res)
+  })
+}
+
+// LnFact returns the logarithm of the factorial, ln(n!).
+func LnFact(n uint) float64 {
+  return float64(C.gsl_sf_lnfact(C.uint(n)))
+}
+
+// LnFactE is the error estimating form of LnFact.
+func LnFactE(n uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_lnfact_e(C.uint(n), res)
+  })
+}
+
+// LnDoubleFact returns the logarithm of the double factorial, ln(n!!).
+func LnDoubleFact(n uint) float64 {
+  return float64(C.gsl_sf_lndoublefact(C.uint(n)))
+}
+
+// LnDoubleFactE is the error estimating form of LnDoubleFact.
+func LnDoubleFactE(n uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_lndoublefact_e(C.uint(n), res)
+  })
+}
+
+// Choose returns the binomial coefficient n choose m = n!/(m!(n-m)!) for m <=
+// n.
+func Choose(n, m uint) float64 {
+  return float64(C.gsl_sf_choose(C.uint(n), C.uint(m)))
+}
+
+// ChooseE is the error estimating form of Choose.
+func ChooseE(n, m uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_choose_e(C.uint(n), C.uint(m), res)
+  })
+}
+
+// LnChoose returns the logarithm of the binomial coefficient n choose m for m
+// <= n.
+func LnChoose(n, m uint) float64 {
+  return float64(C.gsl_sf_lnchoose(C.uint(n), C.uint(m)))
+}
+
+// LnChooseE is the error estimating form of LnChoose.
+func LnChooseE(n, m uint) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_lnchoose_e(C.uint(n), C.uint(m), res)
+  })
+}
+
+// Taylorcoeff returns the Taylor coefficient x^n/n! for x >= 0 and n >= 0.
+func Taylorcoeff(n int, x float64) float64 {
+  return float64(C.gsl_sf_taylorcoeff(C.int(n), C.double(x)))
+}
+
+// TaylorcoeffE is the error estimating form of Taylorcoeff.
+func TaylorcoeffE(n int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_taylorcoeff_e(C.int(n), C.double(x), res)
+  })
+}
+
+// Poch returns the Pochhammer symbol (a)_x = Gamma(a + x)/Gamma(a).
+func Poch(a, x float64) float64 {
+  return float64(C.gsl_sf_poch(C.double(a), C.double(x)))
+}
+
+// PochE is the error estimating form of Poch.
+func PochE(a, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_poch_e(C.double(a), C.double(x), res)
+  })
+}
+
+// LnPoch returns the logarithm of the Pochhammer symbol, ln((a)_x).
+func LnPoch(a, x float64) float64 {
+  return float64(C.gsl_sf_lnpoch(C.double(a), C.double(x)))
+}
+
+// LnPochE is the error estimating form of LnPoch.
+func LnPochE(a, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_lnpoch_e(C.double(a), C.double(x), res)
+  })
+}
+
+// Pochrel returns the relative Pochhammer symbol ((a)_x - 1)/x.
+func Pochrel(a, x float64) float64 {
+  return float64(C.gsl_sf_pochrel(C.double(a), C.double(x)))
+}
+
+// PochrelE is the error estimating form of Pochrel.
+func PochrelE(a, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_pochrel_e(C.double(a), C.double(x), res)
+  })
+}
+
+// GammaInc returns the unnormalized upper incomplete Gamma function Gamma(a,x)
+// = int_x^inf t^(a-1) exp(-t) dt for real a and x >= 0.
+func GammaInc(a, x float64) float64 {
+  return float64(C.gsl_sf_gamma_inc(C.double(a), C.double(x)))
+}
+
+// GammaIncE is the error estimating form of GammaInc.
+func GammaIncE(a, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_gamma_inc_e(C.double(a), C.double(x), res)
+  })
+}
+
+// GammaIncQ returns the normalized upper incomplete Gamma function Q(a,x) =
+// Gamma(a,x)/Gamma(a) for a > 0 and x >= 0.
+func GammaIncQ(a, x float64) float64 {
+  return float64(C.gsl_sf_gamma_inc_Q(C.double(a), C.double(x)))
+}
+
+// GammaIncQE is the error estimating form of GammaIncQ.
+func GammaIncQE(a, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_gamma_inc_Q_e(C.double(a), C.double(x), res)
+  })
+}
+
+// GammaIncP returns the complementary normalized lower incomplete Gamma
+// function P(a,x) = 1 - Q(a,x) for a > 0 and x >= 0.
+func GammaIncP(a, x float64) float64 {
+  return float64(C.gsl_sf_gamma_inc_P(C.double(a), C.double(x)))
+}
+
+// GammaIncPE is the error estimating form of GammaIncP.
+func GammaIncPE(a, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_gamma_inc_P_e(C.double(a), C.double(x), res)
+  })
+}
+
+// Beta returns the Beta function B(a,b) = Gamma(a)Gamma(b)/Gamma(a+b) for a and
+// b not negative integers.
+func Beta(a, b float64) float64 {
+  return float64(C.gsl_sf_beta(C.double(a), C.double(b)))
+}
+
+// BetaE is the error estimating form of Beta.
+func BetaE(a, b float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_beta_e(C.double(a), C.double(b), res)
+  })
+}
+
+// LnBeta returns the logarithm of the Beta function, ln(B(a,b)), for a > 0 and
+// b > 0.
+func LnBeta(a, b float64) float64 {
+  return float64(C.gsl_sf_lnbeta(C.double(a), C.double(b)))
+}
+
+// LnBetaE is the error estimating form of LnBeta.
+func LnBetaE(a, b float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_lnbeta_e(C.double(a), C.double(b), res)
+  })
+}
+
+// BetaInc returns the normalized incomplete Beta function I_x(a,b) =
+// B_x(a,b)/B(a,b) for 0 <= x <= 1.
+func BetaInc(a, b, x float64) float64 {
+  return float64(C.gsl_sf_beta_inc(C.double(a), C.double(b), C.double(x)))
+}
+
+// BetaIncE is the error estimating form of BetaInc.
+func BetaIncE(a, b, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_beta_inc_e(C.double(a), C.double(b), C.double(x), res)
+  })
+}
+
+// LnGammaSgnE computes the sign of the Gamma function and the logarithm of
+// its magnitude, such that Gamma(x) = sgn exp(lg). It returns lg, its error
+// estimate, sgn and an error.
+func LnGammaSgnE(x float64) (float64, float64, float64, error) {
+  var sgn C.double
+  lg, lgErr, err := eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_lngamma_sgn_e(C.double(x), res, &sgn)
+  })
+  return lg, lgErr, float64(sgn), err
+}
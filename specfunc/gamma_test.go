@@ -0,0 +1,65 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// gamma wraps the gsl Gamma and Beta functions including factorials,
+// Pochhammer symbols and the incomplete Gamma and Beta functions
+package specfunc
+
+import (
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_gamma_1(t *testing.T) {
+
+  // test 1
+  for _, c := range []struct {
+    name     string
+    val, exp float64
+  }{
+    {"Gamma", Gamma(5.0), 24.0},
+    {"Gamma", Gamma(0.5), math.Sqrt(math.Pi)},
+    {"LnGamma", LnGamma(10.0), 12.801827480081469},
+    {"Gammainv", Gammainv(5.0), 1.0 / 24.0},
+    {"Fact", Fact(5), 120.0},
+    {"DoubleFact", DoubleFact(7), 105.0},
+    {"LnFact", LnFact(9), 12.801827480081469},
+    {"Choose", Choose(5, 2), 10.0},
+    {"Poch", Poch(2.0, 3.0), 24.0},
+    {"Beta", Beta(2.0, 3.0), 1.0 / 12.0},
+    {"LnBeta", LnBeta(2.0, 3.0), -math.Log(12.0)},
+  } {
+    if !util.FloatEqual(c.val, c.exp) {
+      t.Error("Test 1: Wrong value for", c.name, c.val, c.exp)
+    }
+  }
+
+  // test 2
+  for _, x := range []float64{0.1, 1.0, 2.5} {
+    if !util.FloatEqual(GammaIncP(1.0, x), 1.0-math.Exp(-x)) {
+      t.Error("Test 2: Wrong value for P(1,x) at", x)
+    }
+    if !util.FloatEqual(GammaIncQ(1.0, x), math.Exp(-x)) {
+      t.Error("Test 2: Wrong value for Q(1,x) at", x)
+    }
+    if !util.FloatEqual(GammaInc(1.0, x), math.Exp(-x)) {
+      t.Error("Test 2: Wrong value for Gamma(1,x) at", x)
+    }
+  }
+  if !util.FloatEqual(BetaInc(1.0, 1.0, 0.3), 0.3) {
+    t.Error("Test 2: Wrong value for I_x(1,1)", BetaInc(1.0, 1.0, 0.3))
+  }
+
+  // test 3
+  lg, _, sgn, err := LnGammaSgnE(-0.5)
+  if err != nil {
+    t.Error("Test 3: Unexpected error", err)
+  }
+  if sgn != -1.0 || !util.FloatEqual(lg, math.Log(2.0*math.Sqrt(math.Pi))) {
+    t.Error("Test 3: Wrong value for LnGammaSgn(-0.5)", lg, sgn)
+  }
+}
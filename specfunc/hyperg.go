@@ -0,0 +1,101 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// hyperg wraps the gsl hypergeometric functions
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_hyperg.h>
+import "C"
+
+// Hyperg0F1 returns the hypergeometric function 0F1(c,x).
+func Hyperg0F1(c, x float64) float64 {
+  return float64(C.gsl_sf_hyperg_0F1(C.double(c), C.double(x)))
+}
+
+// Hyperg0F1E is the error estimating form of Hyperg0F1.
+func Hyperg0F1E(c, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_hyperg_0F1_e(C.double(c), C.double(x), res)
+  })
+}
+
+// Hyperg1F1Int returns the confluent hypergeometric function 1F1(m,n,x) =
+// M(m,n,x) for integer parameters m and n.
+func Hyperg1F1Int(m, n int, x float64) float64 {
+  return float64(C.gsl_sf_hyperg_1F1_int(C.int(m), C.int(n), C.double(x)))
+}
+
+// Hyperg1F1IntE is the error estimating form of Hyperg1F1Int.
+func Hyperg1F1IntE(m, n int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_hyperg_1F1_int_e(C.int(m), C.int(n), C.double(x), res)
+  })
+}
+
+// Hyperg1F1 returns the confluent hypergeometric function 1F1(a,b,x) = M(a,b,x)
+// for general parameters a and b.
+func Hyperg1F1(a, b, x float64) float64 {
+  return float64(C.gsl_sf_hyperg_1F1(C.double(a), C.double(b), C.double(x)))
+}
+
+// Hyperg1F1E is the error estimating form of Hyperg1F1.
+func Hyperg1F1E(a, b, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_hyperg_1F1_e(C.double(a), C.double(b), C.double(x), res)
+  })
+}
+
+// HypergUInt returns the confluent hypergeometric function U(m,n,x) for integer
+// parameters m and n.
+func HypergUInt(m, n int, x float64) float64 {
+  return float64(C.gsl_sf_hyperg_U_int(C.int(m), C.int(n), C.double(x)))
+}
+
+// HypergUIntE is the error estimating form of HypergUInt.
+func HypergUIntE(m, n int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_hyperg_U_int_e(C.int(m), C.int(n), C.double(x), res)
+  })
+}
+
+// HypergU returns the confluent hypergeometric function U(a,b,x).
+func HypergU(a, b, x float64) float64 {
+  return float64(C.gsl_sf_hyperg_U(C.double(a), C.double(b), C.double(x)))
+}
+
+// HypergUE is the error estimating form of HypergU.
+func HypergUE(a, b, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_hyperg_U_e(C.double(a), C.double(b), C.double(x), res)
+  })
+}
+
+// Hyperg2F1 returns the Gauss hypergeometric function 2F1(a,b,c,x) for |x| < 1.
+// If a or b is a negative integer the series terminates and x may be arbitrary.
+func Hyperg2F1(a, b, c, x float64) float64 {
+  return float64(C.gsl_sf_hyperg_2F1(C.double(a), C.double(b), C.double(c),
+    C.double(x)))
+}
+
+// Hyperg2F1E is the error estimating form of Hyperg2F1.
+func Hyperg2F1E(a, b, c, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_hyperg_2F1_e(C.double(a), C.double(b), C.double(c),
+      C.double(x), res)
+  })
+}
+
+// Hyperg2F0 returns the hypergeometric function 2F0(a,b,x) for x < 0, defined
+// via U(a, 1+a-b, -1/x).
+func Hyperg2F0(a, b, x float64) float64 {
+  return float64(C.gsl_sf_hyperg_2F0(C.double(a), C.double(b), C.double(x)))
+}
+
+// Hyperg2F0E is the error estimating form of Hyperg2F0.
+func Hyperg2F0E(a, b, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_hyperg_2F0_e(C.double(a), C.double(b), C.double(x), res)
+  })
+}
@@ -0,0 +1,43 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// hyperg wraps the gsl hypergeometric functions
+package specfunc
+
+import (
+  "math"
+  "testing"
+)
+
+// test set 1
+func Test_hyperg_1(t *testing.T) {
+
+  near := func(a, b float64) bool {
+    return math.Abs(a-b) <= 1e-12*math.Abs(b)
+  }
+
+  // test 1
+  for _, x := range []float64{-1.5, 0.5, 2.0} {
+    if !near(Hyperg1F1(1.0, 1.0, x), math.Exp(x)) {
+      t.Error("Test 1: Wrong value for 1F1(1,1,x) at", x)
+    }
+    if !near(Hyperg1F1Int(1, 1, x), math.Exp(x)) {
+      t.Error("Test 1: Wrong value for 1F1(1,1,x) at", x)
+    }
+  }
+
+  // test 2
+  x := 0.5
+  if !near(Hyperg2F1(1.0, 1.0, 2.0, x), -math.Log(1.0-x)/x) {
+    t.Error("Test 2: Wrong value for 2F1(1,1,2,x)", Hyperg2F1(1.0, 1.0, 2.0, x))
+  }
+  if !near(Hyperg0F1(0.5, x*x/4.0), math.Cosh(x)) {
+    t.Error("Test 2: Wrong value for 0F1(1/2,x^2/4)", Hyperg0F1(0.5, x*x/4.0))
+  }
+
+  // test 3
+  if !near(HypergU(1.0, 1.0, 1.0), math.E*0.21938393439552029) {
+    t.Error("Test 3: Wrong value for U(1,1,1)", HypergU(1.0, 1.0, 1.0))
+  }
+}
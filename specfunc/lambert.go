@@ -0,0 +1,36 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// lambert wraps the gsl Lambert W functions
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_lambert.h>
+import "C"
+
+// LambertW0 returns the principal branch W_0(x) of the Lambert W function,
+// i.e., the solution of W exp(W) = x with W >= -1, for x >= -1/e.
+func LambertW0(x float64) float64 {
+  return float64(C.gsl_sf_lambert_W0(C.double(x)))
+}
+
+// LambertW0E is the error estimating form of LambertW0.
+func LambertW0E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_lambert_W0_e(C.double(x), res)
+  })
+}
+
+// LambertWm1 returns the secondary real branch W_{-1}(x) of the Lambert W
+// function with W <= -1 for -1/e <= x < 0. For x >= 0 it returns W_0(x).
+func LambertWm1(x float64) float64 {
+  return float64(C.gsl_sf_lambert_Wm1(C.double(x)))
+}
+
+// LambertWm1E is the error estimating form of LambertWm1.
+func LambertWm1E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_lambert_Wm1_e(C.double(x), res)
+  })
+}
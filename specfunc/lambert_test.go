@@ -0,0 +1,39 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// lambert wraps the gsl Lambert W functions
+package specfunc
+
+import (
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_lambert_1(t *testing.T) {
+
+  // test 1
+  if !util.FloatEqual(LambertW0(1.0), 0.5671432904097838) {
+    t.Error("Test 1: Wrong value for W0(1)", LambertW0(1.0))
+  }
+  if !util.FloatEqual(LambertW0(math.E), 1.0) {
+    t.Error("Test 1: Wrong value for W0(e)", LambertW0(math.E))
+  }
+
+  // test 2
+  x := -2.0 * math.Exp(-2.0)
+  if !util.FloatEqual(LambertWm1(x), -2.0) {
+    t.Error("Test 2: Wrong value for W-1", LambertWm1(x))
+  }
+  if w := LambertW0(x); !util.FloatEqual(w*math.Exp(w), x) || w < -1.0 {
+    t.Error("Test 2: Wrong branch for W0", w)
+  }
+
+  // test 3
+  if _, _, err := LambertW0E(-1.0); err == nil {
+    t.Error("Test 3: Expected error for argument below -1/e.")
+  }
+}
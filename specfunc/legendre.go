@@ -0,0 +1,162 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// legendre wraps the gsl Legendre polynomials and functions and provides
+// spherical harmonics
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_legendre.h>
+import "C"
+
+import (
+  "math"
+  "math/cmplx"
+
+  "github.com/haskelladdict/gsl"
+)
+
+// LegendreP1 returns the Legendre polynomial P_1(x) = x.
+func LegendreP1(x float64) float64 {
+  return float64(C.gsl_sf_legendre_P1(C.double(x)))
+}
+
+// LegendreP1E is the error estimating form of LegendreP1.
+func LegendreP1E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_legendre_P1_e(C.double(x), res)
+  })
+}
+
+// LegendreP2 returns the Legendre polynomial P_2(x) = (3x^2 - 1)/2.
+func LegendreP2(x float64) float64 {
+  return float64(C.gsl_sf_legendre_P2(C.double(x)))
+}
+
+// LegendreP2E is the error estimating form of LegendreP2.
+func LegendreP2E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_legendre_P2_e(C.double(x), res)
+  })
+}
+
+// LegendreP3 returns the Legendre polynomial P_3(x) = (5x^3 - 3x)/2.
+func LegendreP3(x float64) float64 {
+  return float64(C.gsl_sf_legendre_P3(C.double(x)))
+}
+
+// LegendreP3E is the error estimating form of LegendreP3.
+func LegendreP3E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_legendre_P3_e(C.double(x), res)
+  })
+}
+
+// LegendrePl returns the Legendre polynomial P_l(x) for l >= 0 and |x| <= 1.
+func LegendrePl(l int, x float64) float64 {
+  return float64(C.gsl_sf_legendre_Pl(C.int(l), C.double(x)))
+}
+
+// LegendrePlE is the error estimating form of LegendrePl.
+func LegendrePlE(l int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_legendre_Pl_e(C.int(l), C.double(x), res)
+  })
+}
+
+// LegendreQ0 returns the Legendre function of the second kind Q_0(x) for x > -1
+// and x != 1.
+func LegendreQ0(x float64) float64 {
+  return float64(C.gsl_sf_legendre_Q0(C.double(x)))
+}
+
+// LegendreQ0E is the error estimating form of LegendreQ0.
+func LegendreQ0E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_legendre_Q0_e(C.double(x), res)
+  })
+}
+
+// LegendreQ1 returns the Legendre function of the second kind Q_1(x) for x > -1
+// and x != 1.
+func LegendreQ1(x float64) float64 {
+  return float64(C.gsl_sf_legendre_Q1(C.double(x)))
+}
+
+// LegendreQ1E is the error estimating form of LegendreQ1.
+func LegendreQ1E(x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_legendre_Q1_e(C.double(x), res)
+  })
+}
+
+// LegendreQl returns the Legendre function of the second kind Q_l(x) for x >
+// -1, x != 1 and l >= 0.
+func LegendreQl(l int, x float64) float64 {
+  return float64(C.gsl_sf_legendre_Ql(C.int(l), C.double(x)))
+}
+
+// LegendreQlE is the error estimating form of LegendreQl.
+func LegendreQlE(l int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_legendre_Ql_e(C.int(l), C.double(x), res)
+  })
+}
+
+// LegendrePlm returns the associated Legendre polynomial P_l^m(x) for m >= 0, l
+// >= m and |x| <= 1.
+func LegendrePlm(l, m int, x float64) float64 {
+  return float64(C.gsl_sf_legendre_Plm(C.int(l), C.int(m), C.double(x)))
+}
+
+// LegendrePlmE is the error estimating form of LegendrePlm.
+func LegendrePlmE(l, m int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_legendre_Plm_e(C.int(l), C.int(m), C.double(x), res)
+  })
+}
+
+// LegendreSphPlm returns the normalized associated Legendre polynomial
+// sqrt((2l+1)/(4 pi)) sqrt((l-m)!/(l+m)!) P_l^m(x) suitable for use in
+// spherical harmonics, for m >= 0, l >= m and |x| <= 1. It includes the
+// Condon-Shortley phase (-1)^m.
+func LegendreSphPlm(l, m int, x float64) float64 {
+  return float64(C.gsl_sf_legendre_sphPlm(C.int(l), C.int(m), C.double(x)))
+}
+
+// LegendreSphPlmE is the error estimating form of LegendreSphPlm.
+func LegendreSphPlmE(l, m int, x float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_legendre_sphPlm_e(C.int(l), C.int(m), C.double(x), res)
+  })
+}
+
+// SphericalHarmonic returns the spherical harmonic Y_l^m(theta, phi) for
+// polar angle theta and azimuthal angle phi. The harmonics are orthonormal
+// on the unit sphere and include the Condon-Shortley phase (-1)^m, negative
+// m are supported via Y_l^-m = (-1)^m conj(Y_l^m). NaN is returned unless
+// |m| <= l.
+func SphericalHarmonic(l, m int, theta, phi float64) complex128 {
+  y, _, _ := SphericalHarmonicE(l, m, theta, phi)
+  return y
+}
+
+// SphericalHarmonicE is the error estimating form of SphericalHarmonic. The
+// error estimate refers to the magnitude of the harmonic.
+func SphericalHarmonicE(l, m int, theta, phi float64) (complex128, float64,
+  error) {
+  am := m
+  if am < 0 {
+    am = -am
+  }
+  if l < 0 || am > l {
+    return cmplx.NaN(), math.NaN(), gsl.NewError(gsl.EDOM,
+      "Invalid degree %d and order %d.", l, m)
+  }
+  p, pErr, err := LegendreSphPlmE(l, am, math.Cos(theta))
+  if m < 0 && am%2 == 1 {
+    p = -p
+  }
+  return complex(p, 0) * cmplx.Exp(complex(0, float64(m)*phi)), pErr, err
+}
@@ -0,0 +1,78 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// legendre wraps the gsl Legendre polynomials and functions and provides
+// spherical harmonics
+package specfunc
+
+import (
+  "math"
+  "math/cmplx"
+  "testing"
+
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_legendre_1(t *testing.T) {
+
+  // test 1
+  x := 0.3
+  if !util.FloatEqual(LegendreP2(x), (3.0*x*x-1.0)/2.0) {
+    t.Error("Test 1: Wrong value for P2", LegendreP2(x))
+  }
+  if !util.FloatEqual(LegendrePl(3, x), LegendreP3(x)) {
+    t.Error("Test 1: Pl(3,x) differs from P3(x)")
+  }
+  if !util.FloatEqual(LegendreQ0(x), math.Atanh(x)) {
+    t.Error("Test 1: Wrong value for Q0", LegendreQ0(x))
+  }
+
+  // test 2
+  if !util.FloatEqual(LegendrePlm(1, 1, x), -math.Sqrt(1.0-x*x)) {
+    t.Error("Test 2: Wrong value for P_1^1", LegendrePlm(1, 1, x))
+  }
+  if !util.FloatEqual(LegendreSphPlm(0, 0, x), 0.5/math.Sqrt(math.Pi)) {
+    t.Error("Test 2: Wrong value for normalized P_0^0",
+      LegendreSphPlm(0, 0, x))
+  }
+}
+
+// test set 2
+func Test_legendre_2(t *testing.T) {
+
+  theta := 0.7
+  phi := 1.3
+  ylm := func(m int) complex128 {
+    return complex(math.Sqrt(3.0/(8.0*math.Pi))*math.Sin(theta), 0) *
+      cmplx.Exp(complex(0, float64(m)*phi))
+  }
+
+  // test 1
+  y := SphericalHarmonic(0, 0, theta, phi)
+  if !util.FloatEqual(real(y), 0.5/math.Sqrt(math.Pi)) || imag(y) != 0 {
+    t.Error("Test 1: Wrong value for Y_0^0", y)
+  }
+
+  // test 2
+  for _, c := range []struct {
+    m   int
+    exp complex128
+  }{
+    {1, -ylm(1)}, {-1, ylm(-1)},
+  } {
+    y := SphericalHarmonic(1, c.m, theta, phi)
+    if cmplx.Abs(y-c.exp) > 1e-14 {
+      t.Error("Test 2: Wrong value for Y_1^m with m =", c.m, y, c.exp)
+    }
+  }
+
+  // test 3
+  if _, _, err := SphericalHarmonicE(1, 2, theta, phi); err == nil {
+    t.Error("Test 3: Expected error for order larger than degree.")
+  }
+  if !cmplx.IsNaN(SphericalHarmonic(1, -2, theta, phi)) {
+    t.Error("Test 3: Expected NaN for order larger than degree.")
+  }
+}
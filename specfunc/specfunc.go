@@ -0,0 +1,52 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// Package specfunc wraps the gsl special functions (gsl_sf).
+//
+// Each function is available in two forms. The plain form, e.g. BesselJ0,
+// simply returns the function value and returns NaN (or whatever gsl
+// returns) if the arguments are outside the domain of the function or the
+// computation fails. The error estimating form carries an additional E
+// suffix, e.g. BesselJ0E, and returns the function value, an estimate of
+// its absolute error and a *gsl.Error in case gsl signaled a problem, e.g.
+// gsl.EDOM for arguments outside the domain, gsl.EUNDRFLW or gsl.EOVRFLW.
+//
+// Functions whose accuracy can be traded for speed take an additional Mode
+// argument.
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_errno.h>
+// #include <gsl/gsl_mode.h>
+// #include <gsl/gsl_sf_result.h>
+import "C"
+
+import (
+  "github.com/haskelladdict/gsl"
+)
+
+// Mode selects the accuracy of the special functions which support it
+type Mode uint
+
+// available accuracy modes
+const (
+  PrecDouble Mode = C.GSL_PREC_DOUBLE // double precision, about 2e-16
+  PrecSingle Mode = C.GSL_PREC_SINGLE // single precision, about 1e-7
+  PrecApprox Mode = C.GSL_PREC_APPROX // approximate, about 5e-4
+)
+
+// eval calls the error estimating gsl function f and converts its result
+// into the value, error estimate and error returned by the E forms
+func eval(f func(res *C.gsl_sf_result) C.int) (float64, float64, error) {
+  var res C.gsl_sf_result
+  var status C.int
+  err := gsl.Protect(func() {
+    status = f(&res)
+  })
+  if err == nil && status != C.GSL_SUCCESS {
+    err = gsl.NewError(gsl.Errno(status), "Special function evaluation "+
+      "failed.")
+  }
+  return float64(res.val), float64(res.err), err
+}
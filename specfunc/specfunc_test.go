@@ -0,0 +1,65 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// specfunc wraps the gsl special functions
+package specfunc
+
+import (
+  "errors"
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+)
+
+// test set 1
+func Test_specfunc_1(t *testing.T) {
+
+  // test 1
+  val, est, err := GammaE(-1.0)
+  if !errors.Is(err, gsl.EDOM) {
+    t.Error("Test 1: Expected EDOM for Gamma(-1), got", err)
+  }
+  if !math.IsNaN(val) || !math.IsNaN(est) {
+    t.Error("Test 1: Expected NaN result for Gamma(-1)", val, est)
+  }
+  if !math.IsNaN(Gamma(-1.0)) {
+    t.Error("Test 1: Expected NaN for Gamma(-1)")
+  }
+
+  // test 2
+  if _, _, err := ExpintE1E(0.0); !errors.Is(err, gsl.EDOM) {
+    t.Error("Test 2: Expected EDOM for E1(0), got", err)
+  }
+
+  // test 3
+  if _, _, err := BesselJ0E(1.0); err != nil {
+    t.Error("Test 3: Unexpected error", err)
+  }
+}
+
+// test set 2
+func Test_specfunc_2(t *testing.T) {
+
+  // test 1
+  exact := 0.3550280538878172
+  for _, mode := range []Mode{PrecDouble, PrecSingle, PrecApprox} {
+    val, est, err := AiryAiE(0.0, mode)
+    if err != nil {
+      t.Error("Test 1: Unexpected error", err)
+    }
+    if math.Abs(val-exact) > est+1e-15 {
+      t.Error("Test 1: Error estimate", est, "too small for mode", mode,
+        val, exact)
+    }
+  }
+
+  // test 2
+  _, estDouble, _ := EllintKcompE(0.5, PrecDouble)
+  _, estApprox, _ := EllintKcompE(0.5, PrecApprox)
+  if estApprox < estDouble {
+    t.Error("Test 2: Approximate mode is more accurate than double mode",
+      estApprox, estDouble)
+  }
+}
@@ -0,0 +1,96 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// zeta wraps the gsl Riemann, Hurwitz and Dirichlet eta zeta functions
+package specfunc
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_sf_zeta.h>
+import "C"
+
+// ZetaInt returns the Riemann zeta function zeta(n) for integer n != 1.
+func ZetaInt(n int) float64 {
+  return float64(C.gsl_sf_zeta_int(C.int(n)))
+}
+
+// ZetaIntE is the error estimating form of ZetaInt.
+func ZetaIntE(n int) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_zeta_int_e(C.int(n), res)
+  })
+}
+
+// Zeta returns the Riemann zeta function zeta(s) = sum_k k^(-s) for s != 1.
+func Zeta(s float64) float64 {
+  return float64(C.gsl_sf_zeta(C.double(s)))
+}
+
+// ZetaE is the error estimating form of Zeta.
+func ZetaE(s float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_zeta_e(C.double(s), res)
+  })
+}
+
+// Zetam1Int returns zeta(n) - 1 for integer n != 1.
+func Zetam1Int(n int) float64 {
+  return float64(C.gsl_sf_zetam1_int(C.int(n)))
+}
+
+// Zetam1IntE is the error estimating form of Zetam1Int.
+func Zetam1IntE(n int) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_zetam1_int_e(C.int(n), res)
+  })
+}
+
+// Zetam1 returns zeta(s) - 1 for s != 1, which is accurate for large s.
+func Zetam1(s float64) float64 {
+  return float64(C.gsl_sf_zetam1(C.double(s)))
+}
+
+// Zetam1E is the error estimating form of Zetam1.
+func Zetam1E(s float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_zetam1_e(C.double(s), res)
+  })
+}
+
+// Hzeta returns the Hurwitz zeta function zeta(s,q) = sum_k (k+q)^(-s) for s >
+// 1 and q > 0.
+func Hzeta(s, q float64) float64 {
+  return float64(C.gsl_sf_hzeta(C.double(s), C.double(q)))
+}
+
+// HzetaE is the error estimating form of Hzeta.
+func HzetaE(s, q float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_hzeta_e(C.double(s), C.double(q), res)
+  })
+}
+
+// EtaInt returns the Dirichlet eta function eta(n) = (1 - 2^(1-n)) zeta(n) for
+// integer n.
+func EtaInt(n int) float64 {
+  return float64(C.gsl_sf_eta_int(C.int(n)))
+}
+
+// EtaIntE is the error estimating form of EtaInt.
+func EtaIntE(n int) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_eta_int_e(C.int(n), res)
+  })
+}
+
+// Eta returns the Dirichlet eta function eta(s) = (1 - 2^(1-s)) zeta(s).
+func Eta(s float64) float64 {
+  return float64(C.gsl_sf_eta(C.double(s)))
+}
+
+// EtaE is the error estimating form of Eta.
+func EtaE(s float64) (float64, float64, error) {
+  return eval(func(res *C.gsl_sf_result) C.int {
+    return C.gsl_sf_eta_e(C.double(s), res)
+  })
+}
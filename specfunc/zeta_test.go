@@ -0,0 +1,41 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// zeta wraps the gsl Riemann, Hurwitz and Dirichlet eta zeta functions
+package specfunc
+
+import (
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl/util"
+)
+
+// test set 1
+func Test_zeta_1(t *testing.T) {
+
+  zeta2 := math.Pi * math.Pi / 6.0
+
+  // test 1
+  for _, c := range []struct {
+    name     string
+    val, exp float64
+  }{
+    {"Zeta", Zeta(2.0), zeta2},
+    {"ZetaInt", ZetaInt(2), zeta2},
+    {"Zetam1", Zetam1(2.0), zeta2 - 1.0},
+    {"Hzeta", Hzeta(2.0, 1.0), zeta2},
+    {"Eta", Eta(2.0), zeta2 / 2.0},
+    {"EtaInt", EtaInt(1), math.Ln2},
+  } {
+    if !util.FloatEqual(c.val, c.exp) {
+      t.Error("Test 1: Wrong value for", c.name, c.val, c.exp)
+    }
+  }
+
+  // test 2
+  if _, _, err := ZetaE(1.0); err == nil {
+    t.Error("Test 2: Expected error for pole of zeta at 1.")
+  }
+}
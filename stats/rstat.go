@@ -0,0 +1,262 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// rstat wraps the gsl running statistics routines
+package stats
+
+// #cgo pkg-config: gsl
+// #include <gsl/gsl_rstat.h>
+import "C"
+
+import (
+  "errors"
+  "runtime"
+
+  "github.com/haskelladdict/gsl"
+)
+
+// ErrFreed is returned when using an accumulator after it has been freed.
+// Functions without an error result panic with ErrFreed instead.
+var ErrFreed = errors.New("Accumulator has already been freed.")
+
+// RStat accumulates statistics of a stream of data one element at a time
+// in constant memory. Besides the moments, minimum and maximum it keeps
+// P² estimates of the median and of any quantiles requested at allocation.
+//
+// An RStat must not be used by several goroutines concurrently. Instead,
+// each goroutine should feed its own accumulator and the results can be
+// combined afterwards via Merge.
+//
+// The gsl workspaces are released by a finalizer once an RStat becomes
+// unreachable; Free releases them right away.
+type RStat struct {
+  w         *C.gsl_rstat_workspace
+  quantiles []*C.gsl_rstat_quantile_workspace
+  probs     []float64
+  merged    bool
+}
+
+// NewRStat allocates a running statistics accumulator. For each of the
+// probabilities in quantiles, which have to be in [0, 1], a P² quantile
+// estimate is maintained and can be queried via Quantile.
+func NewRStat(quantiles ...float64) (*RStat, error) {
+  for _, p := range quantiles {
+    if !(p >= 0 && p <= 1) {
+      return nil, gsl.NewError(gsl.EINVAL, "Quantile %g not in [0, 1].", p)
+    }
+  }
+
+  r := &RStat{probs: append([]float64(nil), quantiles...)}
+  runtime.SetFinalizer(r, (*RStat).Free)
+  r.w = C.gsl_rstat_alloc()
+  if r.w == nil {
+    return nil, gsl.NewError(gsl.ENOMEM, "Failed to allocate workspace.")
+  }
+  for _, p := range quantiles {
+    q := C.gsl_rstat_quantile_alloc(C.double(p))
+    if q == nil {
+      r.Free()
+      return nil, gsl.NewError(gsl.ENOMEM, "Failed to allocate quantile "+
+        "workspace.")
+    }
+    r.quantiles = append(r.quantiles, q)
+  }
+  return r, nil
+}
+
+// ptr returns the gsl workspace and panics with ErrFreed if it has been
+// freed. Callers need to keep r alive until the C call using the returned
+// pointer has finished.
+func (r *RStat) ptr() *C.gsl_rstat_workspace {
+  if r.w == nil {
+    panic(ErrFreed)
+  }
+  return r.w
+}
+
+// Free releases the gsl workspaces. It is safe to call Free more than
+// once.
+func (r *RStat) Free() {
+  if r.w != nil {
+    C.gsl_rstat_free(r.w)
+    r.w = nil
+  }
+  for _, q := range r.quantiles {
+    C.gsl_rstat_quantile_free(q)
+  }
+  r.quantiles = nil
+  runtime.SetFinalizer(r, nil)
+}
+
+// Add adds the data point x to the accumulator
+func (r *RStat) Add(x float64) {
+  defer runtime.KeepAlive(r)
+  C.gsl_rstat_add(C.double(x), r.ptr())
+  for _, q := range r.quantiles {
+    C.gsl_rstat_quantile_add(C.double(x), q)
+  }
+}
+
+// Reset discards all data added so far including merged data
+func (r *RStat) Reset() {
+  defer runtime.KeepAlive(r)
+  C.gsl_rstat_reset(r.ptr())
+  for _, q := range r.quantiles {
+    C.gsl_rstat_quantile_reset(q)
+  }
+  r.merged = false
+}
+
+// N returns the number of data points added so far
+func (r *RStat) N() uint64 {
+  defer runtime.KeepAlive(r)
+  return uint64(C.gsl_rstat_n(r.ptr()))
+}
+
+// Mean returns the mean of the data points added so far
+func (r *RStat) Mean() float64 {
+  defer runtime.KeepAlive(r)
+  return float64(C.gsl_rstat_mean(r.ptr()))
+}
+
+// Variance returns the unbiased variance estimate of the data points added
+// so far
+func (r *RStat) Variance() float64 {
+  defer runtime.KeepAlive(r)
+  return float64(C.gsl_rstat_variance(r.ptr()))
+}
+
+// Sd returns the standard deviation of the data points added so far
+func (r *RStat) Sd() float64 {
+  defer runtime.KeepAlive(r)
+  return float64(C.gsl_rstat_sd(r.ptr()))
+}
+
+// SdMean returns the standard deviation of the mean, i.e., Sd()/sqrt(N())
+func (r *RStat) SdMean() float64 {
+  defer runtime.KeepAlive(r)
+  return float64(C.gsl_rstat_sd_mean(r.ptr()))
+}
+
+// Rms returns the root mean square of the data points added so far
+func (r *RStat) Rms() float64 {
+  defer runtime.KeepAlive(r)
+  return float64(C.gsl_rstat_rms(r.ptr()))
+}
+
+// Skew returns the skewness of the data points added so far
+func (r *RStat) Skew() float64 {
+  defer runtime.KeepAlive(r)
+  return float64(C.gsl_rstat_skew(r.ptr()))
+}
+
+// Kurtosis returns the kurtosis of the data points added so far
+func (r *RStat) Kurtosis() float64 {
+  defer runtime.KeepAlive(r)
+  return float64(C.gsl_rstat_kurtosis(r.ptr()))
+}
+
+// Min returns the minimum of the data points added so far
+func (r *RStat) Min() float64 {
+  defer runtime.KeepAlive(r)
+  return float64(C.gsl_rstat_min(r.ptr()))
+}
+
+// Max returns the maximum of the data points added so far
+func (r *RStat) Max() float64 {
+  defer runtime.KeepAlive(r)
+  return float64(C.gsl_rstat_max(r.ptr()))
+}
+
+// Median returns the P² estimate of the median of the data points added
+// so far. Since P² estimates cannot be combined, a *gsl.Error with code
+// gsl.EUNSUP is returned if data have been merged into the accumulator
+// since it was allocated or last reset.
+func (r *RStat) Median() (float64, error) {
+  defer runtime.KeepAlive(r)
+  w := r.ptr()
+  if r.merged {
+    return 0, gsl.NewError(gsl.EUNSUP, "Median of merged accumulator is "+
+      "not available.")
+  }
+  return float64(C.gsl_rstat_median(w)), nil
+}
+
+// Quantile returns the P² estimate of the quantile p of the data points
+// added so far. p has to be one of the quantiles passed to NewRStat,
+// otherwise a *gsl.Error with code gsl.EINVAL is returned. As for Median,
+// gsl.EUNSUP is returned if data have been merged into the accumulator.
+func (r *RStat) Quantile(p float64) (float64, error) {
+  defer runtime.KeepAlive(r)
+  r.ptr()
+  if r.merged {
+    return 0, gsl.NewError(gsl.EUNSUP, "Quantiles of merged accumulator "+
+      "are not available.")
+  }
+  for i, prob := range r.probs {
+    if prob == p {
+      return float64(C.gsl_rstat_quantile_get(r.quantiles[i])), nil
+    }
+  }
+  return 0, gsl.NewError(gsl.EINVAL, "Quantile %g is not tracked.", p)
+}
+
+// Merge adds all data points accumulated by other to r as if they had
+// been added to r directly. The count, mean, variance, skewness, kurtosis,
+// minimum and maximum of the combined data are computed exactly via the
+// pairwise update formulas for central moments (Chan et al. and Pébay).
+// The P² median and quantile estimates cannot be combined and are not
+// available anymore after merging a non-empty accumulator, see Median.
+// other is not modified.
+func (r *RStat) Merge(other *RStat) error {
+  defer runtime.KeepAlive(r)
+  defer runtime.KeepAlive(other)
+  if r.w == nil || other.w == nil {
+    return ErrFreed
+  }
+
+  // gsl has no function for merging accumulators, hence the fields n,
+  // mean, M2, M3, M4, min and max of gsl_rstat_workspace are written
+  // directly. This depends on the layout of the struct and on the meaning
+  // gsl_rstat_add gives these fields (M2, M3 and M4 being sums of powers
+  // of deviations from the mean), which are implementation details of the
+  // gsl version compiled against (checked for gsl 2.x). Test set 4 of
+  // rstat_test.go verifies that gsl_rstat_add continues correctly after a
+  // merge.
+  a, b := r.w, other.w
+  if b.n == 0 {
+    return nil
+  }
+  if a.n == 0 {
+    a.min, a.max, a.mean = b.min, b.max, b.mean
+    a.M2, a.M3, a.M4, a.n = b.M2, b.M3, b.M4, b.n
+    r.merged = true
+    return nil
+  }
+
+  na, nb := float64(a.n), float64(b.n)
+  n := na + nb
+  delta := float64(b.mean - a.mean)
+  delta2 := delta * delta
+  M2a, M3a := float64(a.M2), float64(a.M3)
+  M2b, M3b := float64(b.M2), float64(b.M3)
+
+  M4 := float64(a.M4+b.M4) + delta2*delta2*na*nb*(na*na-na*nb+nb*nb)/(n*n*n) +
+    6*delta2*(na*na*M2b+nb*nb*M2a)/(n*n) + 4*delta*(na*M3b-nb*M3a)/n
+  M3 := M3a + M3b + delta2*delta*na*nb*(na-nb)/(n*n) +
+    3*delta*(na*M2b-nb*M2a)/n
+  M2 := M2a + M2b + delta2*na*nb/n
+
+  a.mean += C.double(delta * nb / n)
+  a.M2, a.M3, a.M4 = C.double(M2), C.double(M3), C.double(M4)
+  a.n += b.n
+  if b.min < a.min {
+    a.min = b.min
+  }
+  if b.max > a.max {
+    a.max = b.max
+  }
+  r.merged = true
+  return nil
+}
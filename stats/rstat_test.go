@@ -0,0 +1,255 @@
+// Copyright 2014 Markus Dittrich. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+// rstat wraps the gsl running statistics routines
+package stats
+
+import (
+  "errors"
+  "math"
+  "testing"
+
+  "github.com/haskelladdict/gsl"
+)
+
+// closeTo checks if a and b agree to within a relative tolerance of tol
+func closeTo(a, b, tol float64) bool {
+  return math.Abs(a-b) <= tol*math.Abs(b)
+}
+
+// test set 1
+func Test_rstat_1(t *testing.T) {
+
+  data := FloatSlice{16.0, 99.0, 26.0, 85.0, 76.0, 50.0, 46.0, 11.0, 79.0,
+    97.0, 24.0, 20.0, 100.0, 68.0, 22.0, 15.0, 5.0, 89.0, 45.0, 2.0, 57.0}
+
+  rstat, err := NewRStat()
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer rstat.Free()
+  for _, x := range data {
+    rstat.Add(x)
+  }
+
+  // test 1
+  if rstat.N() != uint64(len(data)) {
+    t.Error("Test 1: Wrong number of data points", rstat.N())
+  }
+
+  // test 2
+  mean, _ := data.Mean(1)
+  variance, _ := data.Variance(1)
+  sd, _ := data.Sd(1)
+  skew, _ := data.Skew(1)
+  kurtosis, _ := data.Kurtosis(1)
+  min, max, _ := data.MinMax(1)
+  sumSq := 0.0
+  for _, x := range data {
+    sumSq += x * x
+  }
+  rms := math.Sqrt(sumSq / float64(len(data)))
+
+  for _, c := range []struct {
+    name      string
+    got, want float64
+  }{
+    {"Mean", rstat.Mean(), mean},
+    {"Variance", rstat.Variance(), variance},
+    {"Sd", rstat.Sd(), sd},
+    {"SdMean", rstat.SdMean(), sd / math.Sqrt(float64(len(data)))},
+    {"Rms", rstat.Rms(), rms},
+    {"Skew", rstat.Skew(), skew},
+    {"Kurtosis", rstat.Kurtosis(), kurtosis},
+    {"Min", rstat.Min(), min},
+    {"Max", rstat.Max(), max},
+  } {
+    if !closeTo(c.got, c.want, 1e-10) {
+      t.Error("Test 2: Running", c.name, "differs:", c.got, c.want)
+    }
+  }
+
+  // test 3
+  rstat.Reset()
+  if rstat.N() != 0 {
+    t.Error("Test 3: Failed to reset accumulator.")
+  }
+  rstat.Add(3.0)
+  if rstat.Mean() != 3.0 || rstat.Min() != 3.0 || rstat.Max() != 3.0 {
+    t.Error("Test 3: Reset accumulator retained old data.")
+  }
+}
+
+// test set 2
+func Test_rstat_2(t *testing.T) {
+
+  // test 1: the P² estimates of a long stream are close to the exact
+  // quantiles. The stream is a permutation of 0, ..., numData-1
+  numData := 10000
+  rstat, err := NewRStat(0.1, 0.9)
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer rstat.Free()
+  for i := 0; i < numData; i++ {
+    rstat.Add(float64((i * 7919) % numData))
+  }
+
+  median, err := rstat.Median()
+  if err != nil || !closeTo(median, 5000.0, 0.01) {
+    t.Error("Test 1: Inaccurate running median", median, err)
+  }
+  q1, err := rstat.Quantile(0.1)
+  if err != nil || !closeTo(q1, 1000.0, 0.02) {
+    t.Error("Test 1: Inaccurate running quantile 0.1", q1, err)
+  }
+  q9, err := rstat.Quantile(0.9)
+  if err != nil || !closeTo(q9, 9000.0, 0.01) {
+    t.Error("Test 1: Inaccurate running quantile 0.9", q9, err)
+  }
+
+  // test 2
+  if _, err := rstat.Quantile(0.5); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 2: Quantile returned untracked quantile.")
+  }
+  if _, err := NewRStat(1.5); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 2: NewRStat accepted invalid quantile.")
+  }
+}
+
+// test set 3
+func Test_rstat_3(t *testing.T) {
+
+  data := FloatSlice{16.0, 99.0, 26.0, 85.0, 76.0, 50.0, 46.0, 11.0, 79.0,
+    97.0, 24.0, 20.0, 100.0, 68.0, 22.0, 15.0, 5.0, 89.0, 45.0, 2.0, 57.0}
+
+  // test 1: accumulate in several parts and merge
+  full, err := NewRStat()
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer full.Free()
+  for _, x := range data {
+    full.Add(x)
+  }
+
+  var parts []*RStat
+  for _, bounds := range [][2]int{{0, 0}, {0, 5}, {5, 6}, {6, 21}} {
+    part, err := NewRStat()
+    if err != nil {
+      t.Fatal(err)
+    }
+    defer part.Free()
+    for _, x := range data[bounds[0]:bounds[1]] {
+      part.Add(x)
+    }
+    parts = append(parts, part)
+  }
+  merged, err := NewRStat()
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer merged.Free()
+  for _, part := range parts {
+    if err := merged.Merge(part); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  if merged.N() != full.N() || merged.Min() != full.Min() ||
+    merged.Max() != full.Max() {
+    t.Error("Test 1: Merged count or range differs.")
+  }
+  for _, c := range []struct {
+    name      string
+    got, want float64
+  }{
+    {"Mean", merged.Mean(), full.Mean()},
+    {"Variance", merged.Variance(), full.Variance()},
+    {"Rms", merged.Rms(), full.Rms()},
+    {"Skew", merged.Skew(), full.Skew()},
+    {"Kurtosis", merged.Kurtosis(), full.Kurtosis()},
+  } {
+    if !closeTo(c.got, c.want, 1e-10) {
+      t.Error("Test 1: Merged", c.name, "differs:", c.got, c.want)
+    }
+  }
+
+  // test 2
+  if _, err := merged.Median(); !errors.Is(err, gsl.EUNSUP) {
+    t.Error("Test 2: Merged accumulator returned a median.")
+  }
+  merged.Reset()
+  if _, err := merged.Median(); err != nil {
+    t.Error("Test 2: Reset accumulator did not return a median.", err)
+  }
+
+  // test 3
+  parts[0].Free()
+  if err := merged.Merge(parts[0]); err != ErrFreed {
+    t.Error("Test 3: Merge accepted freed accumulator.")
+  }
+}
+
+// test set 4
+func Test_rstat_4(t *testing.T) {
+
+  data := FloatSlice{16.0, 99.0, 26.0, 85.0, 76.0, 50.0, 46.0, 11.0, 79.0,
+    97.0, 24.0, 20.0, 100.0, 68.0, 22.0, 15.0, 5.0, 89.0, 45.0, 2.0, 57.0}
+
+  full, err := NewRStat()
+  if err != nil {
+    t.Fatal(err)
+  }
+  defer full.Free()
+  for _, x := range data {
+    full.Add(x)
+  }
+
+  // test 1: Add continues correctly after merging into a non-empty and
+  // into an empty accumulator
+  for _, split := range []int{0, 8} {
+    first, err := NewRStat()
+    if err != nil {
+      t.Fatal(err)
+    }
+    defer first.Free()
+    second, err := NewRStat()
+    if err != nil {
+      t.Fatal(err)
+    }
+    defer second.Free()
+    for _, x := range data[:split] {
+      first.Add(x)
+    }
+    for _, x := range data[split:15] {
+      second.Add(x)
+    }
+    if err := first.Merge(second); err != nil {
+      t.Fatal(err)
+    }
+    for _, x := range data[15:] {
+      first.Add(x)
+    }
+
+    if first.N() != full.N() || first.Min() != full.Min() ||
+      first.Max() != full.Max() {
+      t.Error("Test 1: Count or range differs for split", split)
+    }
+    for _, c := range []struct {
+      name      string
+      got, want float64
+    }{
+      {"Mean", first.Mean(), full.Mean()},
+      {"Variance", first.Variance(), full.Variance()},
+      {"Skew", first.Skew(), full.Skew()},
+      {"Kurtosis", first.Kurtosis(), full.Kurtosis()},
+    } {
+      if !closeTo(c.got, c.want, 1e-10) {
+        t.Error("Test 1:", c.name, "differs for split", split, c.got,
+          c.want)
+      }
+    }
+  }
+}
@@ -4,181 +4,307 @@
 //
 // stat wraps gsl statistics routines
 //
-// XXX: None of the statistics function check for the lenght of the
-//      data string and will trigger a runtime exception if called
-//      with an empty slice.
+// All functions take a stride and operate on the elements d[0],
+// d[stride], d[2*stride], ... of the dataset, i.e., on
+// n = ceil(len(d)/stride) elements. A *gsl.Error with code gsl.EINVAL is
+// returned for a stride smaller than one and with code gsl.EBADLEN for an
+// empty dataset. Functions operating on two datasets (covariances,
+// correlations and all weighted statistics) also return gsl.EBADLEN if
+// the number of strided elements of the datasets differs.
 package stats
 
 // #cgo pkg-config: gsl
 // #include <gsl/gsl_statistics.h>
 import "C"
 
+import (
+  "github.com/haskelladdict/gsl"
+)
+
 // type definitions
 type FloatSlice []float64
 
+// length returns the number of elements n = ceil(len(d)/stride) of d
+// accessed with stride stride. It returns an error if stride is smaller
+// than one or d is empty.
+func (d FloatSlice) length(stride int) (int, error) {
+  if stride < 1 {
+    return 0, gsl.NewError(gsl.EINVAL, "Invalid stride %d.", stride)
+  }
+  if len(d) == 0 {
+    return 0, gsl.NewError(gsl.EBADLEN, "Empty dataset.")
+  }
+  return (len(d) + stride - 1) / stride, nil
+}
+
+// pairedLength returns the common number of elements of the datasets d
+// and other accessed with strides stride and otherStride, respectively
+func pairedLength(d FloatSlice, stride int, other FloatSlice,
+  otherStride int) (int, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  m, err := other.length(otherStride)
+  if err != nil {
+    return 0, err
+  }
+  if n != m {
+    return 0, gsl.NewError(gsl.EBADLEN, "Number of elements %d and %d of "+
+      "datasets differ.", n, m)
+  }
+  return n, nil
+}
+
 // Mean returns the arithmetic mean of data with stride stride.
-func (d FloatSlice) Mean(stride int) float64 {
+func (d FloatSlice) Mean(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   mean := C.gsl_stats_mean((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)))
-  return float64(mean)
+    C.size_t(n))
+  return float64(mean), nil
 }
 
 // Variance returns the variance of data with stride stride.
-func (d FloatSlice) Variance(stride int) float64 {
+func (d FloatSlice) Variance(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   variance := C.gsl_stats_variance((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)))
-  return float64(variance)
+    C.size_t(n))
+  return float64(variance), nil
 }
 
 // Variance_m returns the variance of data with stride stride and a user
 // supplied value for the mean
-func (d FloatSlice) Variance_m(stride int, mean float64) float64 {
+func (d FloatSlice) Variance_m(stride int, mean float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   variance_m := C.gsl_stats_variance_m((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)), C.double(mean))
-  return float64(variance_m)
+    C.size_t(n), C.double(mean))
+  return float64(variance_m), nil
 }
 
 // Sd returns the standard deviation of data with stride stride.
 // NOTE: The value of this function equals the square root of the variance
-func (d FloatSlice) Sd(stride int) float64 {
-  sd := C.gsl_stats_sd((*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)))
-  return float64(sd)
+func (d FloatSlice) Sd(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  sd := C.gsl_stats_sd((*C.double)(&d[0]), C.size_t(stride), C.size_t(n))
+  return float64(sd), nil
 }
 
 // Sd_m returns the standard deviation of data with stride stride and a user
 // supplied value for the mean.
 // NOTE: The value of this function equals the square root of the variance
-func (d FloatSlice) Sd_m(stride int, mean float64) float64 {
+func (d FloatSlice) Sd_m(stride int, mean float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   sd_m := C.gsl_stats_sd_m((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)), C.double(mean))
-  return float64(sd_m)
+    C.size_t(n), C.double(mean))
+  return float64(sd_m), nil
 }
 
 // Tss returns the total sum of squares (TSS) of data about the mean with
 // stride stride.
-func (d FloatSlice) Tss(stride int) float64 {
+func (d FloatSlice) Tss(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   tss := C.gsl_stats_tss((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)))
-  return float64(tss)
+    C.size_t(n))
+  return float64(tss), nil
 }
 
 // Tss_m returns the total sum of squares (TSS) of data about the mean with
 // stride stride and a user supplied value for the mean.
-func (d FloatSlice) Tss_m(stride int, mean float64) float64 {
+func (d FloatSlice) Tss_m(stride int, mean float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   tss_m := C.gsl_stats_tss_m((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)), C.double(mean))
-  return float64(tss_m)
+    C.size_t(n), C.double(mean))
+  return float64(tss_m), nil
 }
 
 // Variance_with_fixed_mean computes an unbiased estimate of the variance
 // of data when the population mean mean of the underlying distribution is
 // known a priori.
-func (d FloatSlice) Variance_with_fixed_mean(stride int, mean float64) float64 {
+func (d FloatSlice) Variance_with_fixed_mean(stride int,
+  mean float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   var_fixed := C.gsl_stats_variance_with_fixed_mean((*C.double)(&d[0]),
-    C.size_t(stride), C.size_t(len(d)), C.double(mean))
-  return float64(var_fixed)
+    C.size_t(stride), C.size_t(n), C.double(mean))
+  return float64(var_fixed), nil
 }
 
 // Sd_with_fixed_mean computes an unbiased estimate of the standard deviation
 // of data when the population mean mean of the underlying distribution is
 // known a priori.
 // NOTE: This is equal to the square root of the Variance_with_fixed_mean
-func (d FloatSlice) Sd_with_fixed_mean(stride int, mean float64) float64 {
+func (d FloatSlice) Sd_with_fixed_mean(stride int,
+  mean float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   var_fixed := C.gsl_stats_sd_with_fixed_mean((*C.double)(&d[0]),
-    C.size_t(stride), C.size_t(len(d)), C.double(mean))
-  return float64(var_fixed)
+    C.size_t(stride), C.size_t(n), C.double(mean))
+  return float64(var_fixed), nil
 }
 
 // Absdev computes the absolute deviation from the mean of data with
 // stride stride
-func (d FloatSlice) Absdev(stride int) float64 {
+func (d FloatSlice) Absdev(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   absdev := C.gsl_stats_absdev((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)))
-  return float64(absdev)
+    C.size_t(n))
+  return float64(absdev), nil
 }
 
 // Absdev_m computes the absolute deviation from the mean of data with
 // stride stride and given mean
-func (d FloatSlice) Absdev_m(stride int, mean float64) float64 {
+func (d FloatSlice) Absdev_m(stride int, mean float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   absdev_m := C.gsl_stats_absdev_m((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)), C.double(mean))
-  return float64(absdev_m)
+    C.size_t(n), C.double(mean))
+  return float64(absdev_m), nil
 }
 
 // Skew computes the skewness of data with stride stride.
-func (d FloatSlice) Skew(stride int) float64 {
+func (d FloatSlice) Skew(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   skew := C.gsl_stats_skew((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)))
-  return float64(skew)
+    C.size_t(n))
+  return float64(skew), nil
 }
 
 // Skew_m_sd computes the skewness of the dataset data using stride stride
 // and the given values of the mean mean and standard deviation sd.
-func (d FloatSlice) Skew_m_sd(stride int, mean float64, sd float64) float64 {
+func (d FloatSlice) Skew_m_sd(stride int,
+  mean float64, sd float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   skew_m_sd := C.gsl_stats_skew_m_sd((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)), C.double(mean), C.double(sd))
-  return float64(skew_m_sd)
+    C.size_t(n), C.double(mean), C.double(sd))
+  return float64(skew_m_sd), nil
 }
 
 // Kurtosis computes the kurtosis of data with stride stride.
-func (d FloatSlice) Kurtosis(stride int) float64 {
+func (d FloatSlice) Kurtosis(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   kurt := C.gsl_stats_kurtosis((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)))
-  return float64(kurt)
+    C.size_t(n))
+  return float64(kurt), nil
 }
 
 // Kurtosis computes the kurtosis of data with stride stride and the given
 // values of mean and sd.
-func (d FloatSlice) Kurtosis_m_sd(stride int, mean float64, sd float64) float64 {
+func (d FloatSlice) Kurtosis_m_sd(stride int, mean float64,
+  sd float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   kurt_m_sd := C.gsl_stats_kurtosis_m_sd((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)), C.double(mean), C.double(sd))
-  return float64(kurt_m_sd)
+    C.size_t(n), C.double(mean), C.double(sd))
+  return float64(kurt_m_sd), nil
 }
 
 // Lag1_autocorrelation computes the lag-1 autocorrelation of the dataset
 // data with stride stride.
-func (d FloatSlice) Lag1_autocorrelation(stride int) float64 {
+func (d FloatSlice) Lag1_autocorrelation(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   corr := C.gsl_stats_lag1_autocorrelation((*C.double)(&d[0]),
-    C.size_t(stride), C.size_t(len(d)))
-  return float64(corr)
+    C.size_t(stride), C.size_t(n))
+  return float64(corr), nil
 }
 
 // Lag1_autocorrelation_m computes the lag-1 autocorrelation of the dataset
 // data with stride stride and the given values of mean and sd.
-func (d FloatSlice) Lag1_autocorrelation_m_sd(stride int, mean float64) float64 {
+func (d FloatSlice) Lag1_autocorrelation_m_sd(stride int,
+  mean float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   corr_m_sd := C.gsl_stats_lag1_autocorrelation_m((*C.double)(&d[0]),
-    C.size_t(stride), C.size_t(len(d)), C.double(mean))
-  return float64(corr_m_sd)
+    C.size_t(stride), C.size_t(n), C.double(mean))
+  return float64(corr_m_sd), nil
 }
 
 // Covariance computes the covariance of the dataset d with
 // dataset data1 which must both be of the same length n using strides
-// stride1 and stride2.
-// NOTE: This function currently does not enforce that the datasets
-//       have the same length.
-func (d FloatSlice) Covariance(stride1 int, data1 FloatSlice, stride2 int) float64 {
+// stride1 and stride2. A *gsl.Error with code gsl.EBADLEN is returned
+// if the lengths of the datasets differ.
+func (d FloatSlice) Covariance(stride1 int, data1 FloatSlice,
+  stride2 int) (float64, error) {
+  n, err := pairedLength(d, stride1, data1, stride2)
+  if err != nil {
+    return 0, err
+  }
   cov := C.gsl_stats_covariance((*C.double)(&d[0]), C.size_t(stride1),
-    (*C.double)(&data1[0]), C.size_t(stride2), C.size_t(len(d)))
-  return float64(cov)
+    (*C.double)(&data1[0]), C.size_t(stride2), C.size_t(n))
+  return float64(cov), nil
 }
 
 // Covariance_m computes the covariance of the datasets d with dataset
 // data1 using the given values of the means, mean1 and mean2.
 func (d FloatSlice) Covariance_m(stride1 int, data1 FloatSlice, stride2 int,
-  mean1 float64, mean2 float64) float64 {
+  mean1 float64, mean2 float64) (float64, error) {
+  n, err := pairedLength(d, stride1, data1, stride2)
+  if err != nil {
+    return 0, err
+  }
   cov_m := C.gsl_stats_covariance_m((*C.double)(&d[0]), C.size_t(stride1),
-    (*C.double)(&data1[0]), C.size_t(stride2), C.size_t(len(d)),
-    C.double(mean1), C.double(mean1))
-  return float64(cov_m)
+    (*C.double)(&data1[0]), C.size_t(stride2), C.size_t(n),
+    C.double(mean1), C.double(mean2))
+  return float64(cov_m), nil
 }
 
 // Correlation computes the Pearson correlation coefficient between the
 // dataset d and dataset data1 which must both be of the same length n
 // using strides stride1 and stride2.
-func (d FloatSlice) Correlation(stride1 int, data1 FloatSlice, stride2 int) float64 {
+func (d FloatSlice) Correlation(stride1 int, data1 FloatSlice,
+  stride2 int) (float64, error) {
+  n, err := pairedLength(d, stride1, data1, stride2)
+  if err != nil {
+    return 0, err
+  }
   corr := C.gsl_stats_correlation((*C.double)(&d[0]), C.size_t(stride1),
-    (*C.double)(&data1[0]), C.size_t(stride2), C.size_t(len(d)))
-  return float64(corr)
+    (*C.double)(&data1[0]), C.size_t(stride2), C.size_t(n))
+  return float64(corr), nil
 }
 
 // Spearman computes the Spearman rank correlation coefficient between the
@@ -188,57 +314,85 @@ func (d FloatSlice) Correlation(stride1 int, data1 FloatSlice, stride2 int) floa
 // ranked vectors x_R and y_R, where ranks are defined to be the average
 // of the positions of an element in the ascending order of the values.
 // NOTE: Additional workspace of size 2*n is required in work.
-func (d FloatSlice) Spearman(stride1 int, data1 FloatSlice, stride2 int) float64 {
-  work := make([]float64, len(d)*2)
+func (d FloatSlice) Spearman(stride1 int, data1 FloatSlice,
+  stride2 int) (float64, error) {
+  n, err := pairedLength(d, stride1, data1, stride2)
+  if err != nil {
+    return 0, err
+  }
+  work := make([]float64, 2*n)
   spear := C.gsl_stats_spearman((*C.double)(&d[0]), C.size_t(stride1),
-    (*C.double)(&data1[0]), C.size_t(stride2), C.size_t(len(d)),
+    (*C.double)(&data1[0]), C.size_t(stride2), C.size_t(n),
     (*C.double)(&work[0]))
-  return float64(spear)
+  return float64(spear), nil
 }
 
 // Wmean computes the weighted mean of the dataset data with stride
 // stride and length n, using the set of weights w with stride wstride and
 // length n.
-func (d FloatSlice) Wmean(stride int, weights FloatSlice, wstride int) float64 {
+func (d FloatSlice) Wmean(stride int, weights FloatSlice,
+  wstride int) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wmean := C.gsl_stats_wmean((*C.double)(&weights[0]), C.size_t(wstride),
-    (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)))
-  return float64(wmean)
+    (*C.double)(&d[0]), C.size_t(stride), C.size_t(n))
+  return float64(wmean), nil
 }
 
 // Wvariance computes the estimated variance of the dataset data with stride
 // stride and length n, using the set of weights w with stride wstride and
 // length n.
-func (d FloatSlice) Wvariance(stride int, weights FloatSlice, wstride int) float64 {
+func (d FloatSlice) Wvariance(stride int, weights FloatSlice,
+  wstride int) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wvar := C.gsl_stats_wvariance((*C.double)(&weights[0]), C.size_t(wstride),
-    (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)))
-  return float64(wvar)
+    (*C.double)(&d[0]), C.size_t(stride), C.size_t(n))
+  return float64(wvar), nil
 }
 
 // Wvariance_m computes the estimated variance of the dataset data with
 // stride stride and length n, using the set of weights w with stride
 // wstride and length n and weighted mean mean.
 func (d FloatSlice) Wvariance_m(stride int, weights FloatSlice, wstride int,
-  mean float64) float64 {
+  mean float64) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wvar_m := C.gsl_stats_wvariance_m((*C.double)(&weights[0]),
-    C.size_t(wstride), (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)),
+    C.size_t(wstride), (*C.double)(&d[0]), C.size_t(stride), C.size_t(n),
     C.double(mean))
-  return float64(wvar_m)
+  return float64(wvar_m), nil
 }
 
 // Wsd computes the standard deviation as the square root of the variance.
-func (d FloatSlice) Wsd(stride int, weights FloatSlice, wstride int) float64 {
+func (d FloatSlice) Wsd(stride int, weights FloatSlice,
+  wstride int) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wsd := C.gsl_stats_wsd((*C.double)(&weights[0]), C.size_t(wstride),
-    (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)))
-  return float64(wsd)
+    (*C.double)(&d[0]), C.size_t(stride), C.size_t(n))
+  return float64(wsd), nil
 }
 
 // Wsd computes the standard deviation as the square root of the variance
 // with given weighted mean.
 func (d FloatSlice) Wsd_m(stride int, weights FloatSlice, wstride int,
-  mean float64) float64 {
+  mean float64) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wsd_m := C.gsl_stats_wsd_m((*C.double)(&weights[0]), C.size_t(wstride),
-    (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)), C.double(mean))
-  return float64(wsd_m)
+    (*C.double)(&d[0]), C.size_t(stride), C.size_t(n), C.double(mean))
+  return float64(wsd_m), nil
 }
 
 // Wvariance_with fixed mean computes an unbiased estimate of the variance
@@ -246,163 +400,479 @@ func (d FloatSlice) Wsd_m(stride int, weights FloatSlice, wstride int,
 // distribution is known a priori. In this case the estimator for the
 // variance replaces the sample mean μ_hat by the known population mean μ.
 func (d FloatSlice) Wvariance_with_fixed_mean(stride int, weights FloatSlice,
-  wstride int, mean float64) float64 {
+  wstride int, mean float64) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wvar_fixed_m := C.gsl_stats_wvariance_with_fixed_mean(
     (*C.double)(&weights[0]), C.size_t(wstride), (*C.double)(&d[0]),
-    C.size_t(stride), C.size_t(len(d)), C.double(mean))
-  return float64(wvar_fixed_m)
+    C.size_t(stride), C.size_t(n), C.double(mean))
+  return float64(wvar_fixed_m), nil
 }
 
 // Wsd_with_fixed_mean computes the standard deviation of data d with fixed
 // mean and is defined as the square root of Wvariance_with_fixed_mean.
 func (d FloatSlice) Wsd_with_fixed_mean(stride int, weights FloatSlice,
-  wstride int, mean float64) float64 {
+  wstride int, mean float64) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wsd_fixed_m := C.gsl_stats_wsd_with_fixed_mean((*C.double)(&weights[0]),
-    C.size_t(wstride), (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)),
+    C.size_t(wstride), (*C.double)(&d[0]), C.size_t(stride), C.size_t(n),
     C.double(mean))
-  return float64(wsd_fixed_m)
+  return float64(wsd_fixed_m), nil
 }
 
 // Wtss computes the weighted total sum of squares (TSS) of data about the
 // weighted mean.
-func (d FloatSlice) Wtss(stride int, weights FloatSlice, wstride int) float64 {
+func (d FloatSlice) Wtss(stride int, weights FloatSlice,
+  wstride int) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wtss := C.gsl_stats_wtss((*C.double)(&weights[0]), C.size_t(wstride),
-    (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)))
-  return float64(wtss)
+    (*C.double)(&d[0]), C.size_t(stride), C.size_t(n))
+  return float64(wtss), nil
 }
 
 // Wtss_m computes the weighted total sum of squares (TSS) of data about the
 // weighted mean supplied by the caller.
 func (d FloatSlice) Wtss_m(stride int, weights FloatSlice, wstride int,
-  mean float64) float64 {
+  mean float64) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wtss_m := C.gsl_stats_wtss_m((*C.double)(&weights[0]), C.size_t(wstride),
-    (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)), C.double(mean))
-  return float64(wtss_m)
+    (*C.double)(&d[0]), C.size_t(stride), C.size_t(n), C.double(mean))
+  return float64(wtss_m), nil
 }
 
 // Wabsdev computes the weighted absolute deviation from the weighted mean
 // of data with stride stride.
-func (d FloatSlice) Wabsdev(stride int, weights FloatSlice, wstride int) float64 {
+func (d FloatSlice) Wabsdev(stride int, weights FloatSlice,
+  wstride int) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wabsdev := C.gsl_stats_wabsdev((*C.double)(&weights[0]), C.size_t(wstride),
-    (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)))
-  return float64(wabsdev)
+    (*C.double)(&d[0]), C.size_t(stride), C.size_t(n))
+  return float64(wabsdev), nil
 }
 
 // Wabsdev_m computes the weighted absolute deviation from the weighted mean
 // of data with stride stride and user supplied mean.
 func (d FloatSlice) Wabsdev_m(stride int, weights FloatSlice, wstride int,
-  mean float64) float64 {
+  mean float64) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wabsdev_m := C.gsl_stats_wabsdev_m((*C.double)(&weights[0]),
     C.size_t(wstride), (*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)), C.double(mean))
-  return float64(wabsdev_m)
+    C.size_t(n), C.double(mean))
+  return float64(wabsdev_m), nil
 }
 
 // Wskew computes the weighted skewness of the dataset d with stride stride.
-func (d FloatSlice) Wskew(stride int, weights FloatSlice, wstride int) float64 {
+func (d FloatSlice) Wskew(stride int, weights FloatSlice,
+  wstride int) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wskew := C.gsl_stats_wskew((*C.double)(&weights[0]), C.size_t(wstride),
-    (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)))
-  return float64(wskew)
+    (*C.double)(&d[0]), C.size_t(stride), C.size_t(n))
+  return float64(wskew), nil
 }
 
 // Wskew_m_sd computes the weighted skewness of the dataset d with stride
 // stride with user supplied mean and standard deviation sd.
 func (d FloatSlice) Wskew_m_sd(stride int, weights FloatSlice, wstride int,
-  mean float64, sd float64) float64 {
+  mean float64, sd float64) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wskew_m_sd := C.gsl_stats_wskew_m_sd((*C.double)(&weights[0]),
     C.size_t(wstride), (*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)), C.double(mean), C.double(sd))
-  return float64(wskew_m_sd)
+    C.size_t(n), C.double(mean), C.double(sd))
+  return float64(wskew_m_sd), nil
 }
 
 // Wkurtosis computes the weighted kurtosis of the dataset d with stride
 // stride.
-func (d FloatSlice) Wkurtosis(stride int, weights FloatSlice, wstride int) float64 {
+func (d FloatSlice) Wkurtosis(stride int, weights FloatSlice,
+  wstride int) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wkurtosis := C.gsl_stats_wkurtosis((*C.double)(&weights[0]),
-    C.size_t(wstride), (*C.double)(&d[0]), C.size_t(stride), C.size_t(len(d)))
-  return float64(wkurtosis)
+    C.size_t(wstride), (*C.double)(&d[0]), C.size_t(stride), C.size_t(n))
+  return float64(wkurtosis), nil
 }
 
 // Wkurtosis_m_sd computes the weighted kurtosis of the dataset d with stride
 // stride with user supplied mean and standard deviation sd.
 func (d FloatSlice) Wkurtosis_m_sd(stride int, weights FloatSlice,
-  wstride int, mean float64, sd float64) float64 {
+  wstride int, mean float64, sd float64) (float64, error) {
+  n, err := pairedLength(d, stride, weights, wstride)
+  if err != nil {
+    return 0, err
+  }
   wkurtosis_m_sd := C.gsl_stats_wkurtosis_m_sd((*C.double)(&weights[0]),
     C.size_t(wstride), (*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)), C.double(mean), C.double(sd))
-  return float64(wkurtosis_m_sd)
+    C.size_t(n), C.double(mean), C.double(sd))
+  return float64(wkurtosis_m_sd), nil
 }
 
 // Max returns the maximum value in dataset d with stride stride.
-func (d FloatSlice) Max(stride int) float64 {
+func (d FloatSlice) Max(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   max := C.gsl_stats_max((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)))
-  return float64(max)
+    C.size_t(n))
+  return float64(max), nil
 }
 
 // Min returns the minumum value in dataset d with stride stride.
-func (d FloatSlice) Min(stride int) float64 {
+func (d FloatSlice) Min(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   min := C.gsl_stats_min((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)))
-  return float64(min)
+    C.size_t(n))
+  return float64(min), nil
 }
 
 // Minmax returns the minumum and maximum values in dataset d in a
 // single pass
-func (d FloatSlice) MinMax(stride int) (float64, float64) {
+func (d FloatSlice) MinMax(stride int) (float64, float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, 0, err
+  }
   var min, max float64
   C.gsl_stats_minmax((*C.double)(&min), (*C.double)(&max), (*C.double)(&d[0]),
-    C.size_t(stride), C.size_t(len(d)))
-  return float64(min), float64(max)
+    C.size_t(stride), C.size_t(n))
+  return float64(min), float64(max), nil
 }
 
 // Maxindex computes the index of the maximum value in dataset d with stride
 // stride. The maximum value is defined as the value of the element x_i
 // which satisfies x_i ≥ x_j for all j. When there are several equal maximum
-// elements then the first one is chosen.
-func (d FloatSlice) MaxIndex(stride int) uint64 {
+// elements then the first one is chosen. The index i refers to the strided
+// elements, i.e., the maximum is d[i*stride].
+func (d FloatSlice) MaxIndex(stride int) (uint64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   maxindex := C.gsl_stats_max_index((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)))
-  return uint64(maxindex)
+    C.size_t(n))
+  return uint64(maxindex), nil
 }
 
 // Minindex computes the index of the minumum value in dataset d with stride
 // stride. The minimum value is defined as the value of the element x_i
 // which satisfies x_i < x_j for all j. When there are several equal minimum
-// elements then the first one is chosen.
-func (d FloatSlice) MinIndex(stride int) uint64 {
+// elements then the first one is chosen. The index i refers to the strided
+// elements, i.e., the minimum is d[i*stride].
+func (d FloatSlice) MinIndex(stride int) (uint64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   minindex := C.gsl_stats_min_index((*C.double)(&d[0]), C.size_t(stride),
-    C.size_t(len(d)))
-  return uint64(minindex)
+    C.size_t(n))
+  return uint64(minindex), nil
 }
 
 // Minmaxindex computes the index of the minumum and maximum values in
 // dataset d with stride stride in a single pass.
-func (d FloatSlice) MinMaxIndex(stride int) (uint64, uint64) {
+func (d FloatSlice) MinMaxIndex(stride int) (uint64, uint64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, 0, err
+  }
   var minindex, maxindex uint64
   C.gsl_stats_minmax_index((*C.size_t)(&minindex),
     (*C.size_t)(&maxindex), (*C.double)(&d[0]),
-    C.size_t(stride), C.size_t(len(d)))
-  return minindex, maxindex
+    C.size_t(stride), C.size_t(n))
+  return minindex, maxindex, nil
 }
 
 // MedianFromSortedData computes the median value of sorted data d
 // with stride stride. The elements of the array must be in ascending
 // numerical order. There are no checks to see whether the data are sorted,
-// so the function gsl_sort should always be used firs
-func (d FloatSlice) MedianFromSortedData(stride int) float64 {
+// so sort.Sort should always be used first.
+func (d FloatSlice) MedianFromSortedData(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   median := C.gsl_stats_median_from_sorted_data((*C.double)(&d[0]),
-    C.size_t(stride), C.size_t(len(d)))
-  return float64(median)
+    C.size_t(stride), C.size_t(n))
+  return float64(median), nil
 }
 
 // QuantileFromSortedData computed a quantile value of sorted data with
 // stride stride. The elements of the array must be in ascending numerical
-// order. The quantile is determined by f, a fraction between 0 and 1.
-// For example, to compute the value of the 75th percentile f should have the
-// value 0.75
-func (d FloatSlice) QuantileFromSortedData(stride int, quant float64) float64 {
+// order, see sort.Sort. The quantile is determined by f, a fraction between
+// 0 and 1. For example, to compute the value of the 75th percentile f should
+// have the value 0.75
+func (d FloatSlice) QuantileFromSortedData(stride int,
+  quant float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
   quantile := C.gsl_stats_quantile_from_sorted_data((*C.double)(&d[0]),
-    C.size_t(stride), C.size_t(len(d)), C.double(quant))
-  return float64(quantile)
+    C.size_t(stride), C.size_t(n), C.double(quant))
+  return float64(quantile), nil
+}
+
+// compact returns a contiguous copy of the n elements of d with stride
+// stride
+func (d FloatSlice) compact(stride, n int) FloatSlice {
+  c := make(FloatSlice, n)
+  for i := range c {
+    c[i] = d[i*stride]
+  }
+  return c
+}
+
+// Median returns the median of the unsorted dataset d with stride stride.
+// The median is found in linear time via a selection algorithm on a copy
+// of the strided elements, d is not modified. Use MedianInPlace to avoid
+// the copy. Requires GSL 2.5 or newer.
+func (d FloatSlice) Median(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  return d.compact(stride, n).MedianInPlace(1)
+}
+
+// MedianInPlace returns the median of the unsorted dataset d with stride
+// stride like Median but rearranges the strided elements of d in the
+// process.
+func (d FloatSlice) MedianInPlace(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  median := C.gsl_stats_median((*C.double)(&d[0]), C.size_t(stride),
+    C.size_t(n))
+  return float64(median), nil
+}
+
+// Select returns the k-th smallest element (counting from zero) of the
+// unsorted dataset d with stride stride, i.e., Select(stride, 0) returns
+// the minimum and Select(stride, n-1) the maximum of the n strided
+// elements. d is not modified. A *gsl.Error with code gsl.EINVAL is
+// returned if k is not in [0, n). Requires GSL 2.5 or newer.
+func (d FloatSlice) Select(stride int, k int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  return d.compact(stride, n).SelectInPlace(1, k)
+}
+
+// SelectInPlace returns the k-th smallest element of the unsorted dataset
+// d with stride stride like Select but rearranges the strided elements of
+// d in the process.
+func (d FloatSlice) SelectInPlace(stride int, k int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  if k < 0 || k >= n {
+    return 0, gsl.NewError(gsl.EINVAL, "Cannot select element %d of %d.",
+      k, n)
+  }
+  elem := C.gsl_stats_select((*C.double)(&d[0]), C.size_t(stride),
+    C.size_t(n), C.size_t(k))
+  return float64(elem), nil
+}
+
+// Quantile returns the quantile f of the unsorted dataset d with stride
+// stride. f is a fraction between 0 and 1 and the result is identical to
+// QuantileFromSortedData of the sorted dataset. The quantile is found in
+// linear time via selection on a copy of the strided elements, d is not
+// modified. A *gsl.Error with code gsl.EINVAL is returned if f is not in
+// [0, 1]. Requires GSL 2.5 or newer.
+func (d FloatSlice) Quantile(stride int, f float64) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  return d.compact(stride, n).QuantileInPlace(1, f)
+}
+
+// QuantileInPlace returns the quantile f of the unsorted dataset d with
+// stride stride like Quantile but rearranges the strided elements of d in
+// the process.
+func (d FloatSlice) QuantileInPlace(stride int, f float64) (float64,
+  error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  if !(f >= 0 && f <= 1) {
+    return 0, gsl.NewError(gsl.EINVAL, "Quantile %g not in [0, 1].", f)
+  }
+
+  // same interpolation as gsl_stats_quantile_from_sorted_data
+  index := f * float64(n-1)
+  lhs := int(index)
+  delta := index - float64(lhs)
+  lower, err := d.SelectInPlace(stride, lhs)
+  if err != nil || lhs == n-1 {
+    return lower, err
+  }
+  upper, err := d.SelectInPlace(stride, lhs+1)
+  if err != nil {
+    return 0, err
+  }
+  return (1-delta)*lower + delta*upper, nil
+}
+
+// TrmeanFromSortedData computes the trimmed mean of the sorted dataset d
+// with stride stride, i.e., the mean of the elements remaining after
+// discarding the floor(alpha*n) smallest and largest ones. alpha has to
+// be in [0, 0.5], for alpha = 0.5 the median is returned. The elements of
+// the array must be in ascending numerical order, see sort.Sort. Requires
+// GSL 2.5 or newer.
+func (d FloatSlice) TrmeanFromSortedData(stride int, alpha float64) (float64,
+  error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  if !(alpha >= 0 && alpha <= 0.5) {
+    return 0, gsl.NewError(gsl.EINVAL, "Trimming fraction %g not in "+
+      "[0, 0.5].", alpha)
+  }
+  trmean := C.gsl_stats_trmean_from_sorted_data(C.double(alpha),
+    (*C.double)(&d[0]), C.size_t(stride), C.size_t(n))
+  return float64(trmean), nil
+}
+
+// GastwirthFromSortedData computes the Gastwirth location estimator
+// 0.3*Q(1/3) + 0.4*Q(1/2) + 0.3*Q(2/3) of the sorted dataset d with stride
+// stride, where Q(f) are the quantiles of the data. The elements of the
+// array must be in ascending numerical order, see sort.Sort. Requires GSL
+// 2.5 or newer.
+func (d FloatSlice) GastwirthFromSortedData(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  gastwirth := C.gsl_stats_gastwirth_from_sorted_data((*C.double)(&d[0]),
+    C.size_t(stride), C.size_t(n))
+  return float64(gastwirth), nil
+}
+
+// Mad0 computes the median absolute deviation med_i |x_i - med(x)| of
+// the dataset d with stride stride. The data need not be sorted and are
+// not modified. Requires GSL 2.5 or newer.
+func (d FloatSlice) Mad0(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  work := make([]float64, n)
+  mad0 := C.gsl_stats_mad0((*C.double)(&d[0]), C.size_t(stride), C.size_t(n),
+    (*C.double)(&work[0]))
+  return float64(mad0), nil
+}
+
+// Mad computes the median absolute deviation of the dataset d with stride
+// stride scaled by 1.4826 so that it is a consistent estimator of the
+// standard deviation for Gaussian data. The data need not be sorted and
+// are not modified. Requires GSL 2.5 or newer.
+func (d FloatSlice) Mad(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  work := make([]float64, n)
+  mad := C.gsl_stats_mad((*C.double)(&d[0]), C.size_t(stride), C.size_t(n),
+    (*C.double)(&work[0]))
+  return float64(mad), nil
+}
+
+// Sn0FromSortedData computes the unscaled Sn scale estimator
+// lomed_i himed_j |x_i - x_j| of Rousseeuw and Croux of the sorted dataset
+// d with stride stride. The elements of the array must be in ascending
+// numerical order, see sort.Sort. Requires GSL 2.5 or newer.
+func (d FloatSlice) Sn0FromSortedData(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  work := make([]float64, n)
+  sn0 := C.gsl_stats_Sn0_from_sorted_data((*C.double)(&d[0]),
+    C.size_t(stride), C.size_t(n), (*C.double)(&work[0]))
+  return float64(sn0), nil
+}
+
+// SnFromSortedData computes the Sn scale estimator of the sorted dataset
+// d with stride stride. This is Sn0 scaled by 1.1926 and a finite sample
+// correction factor so that it is a consistent estimator of the standard
+// deviation for Gaussian data. The elements of the array must be in
+// ascending numerical order, see sort.Sort. Requires GSL 2.5 or newer.
+func (d FloatSlice) SnFromSortedData(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  work := make([]float64, n)
+  sn := C.gsl_stats_Sn_from_sorted_data((*C.double)(&d[0]),
+    C.size_t(stride), C.size_t(n), (*C.double)(&work[0]))
+  return float64(sn), nil
+}
+
+// Qn0FromSortedData computes the unscaled Qn scale estimator of Rousseeuw
+// and Croux of the sorted dataset d with stride stride, i.e., the k-th
+// order statistic of the pairwise distances |x_i - x_j| with i < j, where
+// k = h(h-1)/2 and h = n/2 + 1. The elements of the array must be in
+// ascending numerical order, see sort.Sort. Requires GSL 2.5 or newer.
+func (d FloatSlice) Qn0FromSortedData(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  work := make([]float64, 3*n)
+  workInt := make([]C.int, 5*n)
+  qn0 := C.gsl_stats_Qn0_from_sorted_data((*C.double)(&d[0]),
+    C.size_t(stride), C.size_t(n), (*C.double)(&work[0]), &workInt[0])
+  return float64(qn0), nil
+}
+
+// QnFromSortedData computes the Qn scale estimator of the sorted dataset
+// d with stride stride. This is Qn0 scaled by 2.21914 and a finite sample
+// correction factor so that it is a consistent estimator of the standard
+// deviation for Gaussian data. The elements of the array must be in
+// ascending numerical order, see sort.Sort. Requires GSL 2.5 or newer.
+func (d FloatSlice) QnFromSortedData(stride int) (float64, error) {
+  n, err := d.length(stride)
+  if err != nil {
+    return 0, err
+  }
+  work := make([]float64, 3*n)
+  workInt := make([]C.int, 5*n)
+  qn := C.gsl_stats_Qn_from_sorted_data((*C.double)(&d[0]),
+    C.size_t(stride), C.size_t(n), (*C.double)(&work[0]), &workInt[0])
+  return float64(qn), nil
 }
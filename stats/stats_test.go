@@ -9,9 +9,12 @@
 package stats
 
 import (
+  "errors"
   "math"
+  "sort"
   "testing"
 
+  "github.com/haskelladdict/gsl"
   "github.com/haskelladdict/gsl/util"
 )
 
@@ -20,28 +23,28 @@ func Test_stats_1(t *testing.T) {
 
   data := FloatSlice{1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0, 9.0, 10.0}
 
-  mean := data.Mean(1)
-  if !util.FloatEqual(mean, 5.5) {
+  mean, err := data.Mean(1)
+  if err != nil || !util.FloatEqual(mean, 5.5) {
     t.Error("Test 1: Failed to compute mean.")
   }
 
-  sigma2 := data.Variance(1)
-  if !util.FloatEqual(sigma2, 9.166666666666666) {
+  sigma2, err := data.Variance(1)
+  if err != nil || !util.FloatEqual(sigma2, 9.166666666666666) {
     t.Error("Test 1: Failed to compute variance.")
   }
 
-  sigma2_m := data.Variance_m(1, mean)
-  if !util.FloatEqual(sigma2_m, 9.166666666666666) {
+  sigma2_m, err := data.Variance_m(1, mean)
+  if err != nil || !util.FloatEqual(sigma2_m, 9.166666666666666) {
     t.Error("Test 1: Failed to compute variance.")
   }
 
-  sigma := data.Sd(1)
-  if !util.FloatEqual(sigma, math.Sqrt(sigma2)) {
+  sigma, err := data.Sd(1)
+  if err != nil || !util.FloatEqual(sigma, math.Sqrt(sigma2)) {
     t.Error("Test 1: Failed to compute standard deviation.")
   }
 
-  sigma_m := data.Sd_m(1, mean)
-  if !util.FloatEqual(sigma_m, math.Sqrt(sigma2)) {
+  sigma_m, err := data.Sd_m(1, mean)
+  if err != nil || !util.FloatEqual(sigma_m, math.Sqrt(sigma2)) {
     t.Error("Test 1: Failed to compute standard deviation with mean.")
   }
 
@@ -50,33 +53,33 @@ func Test_stats_1(t *testing.T) {
     tss_comp += (v - mean) * (v - mean)
   }
 
-  tss := data.Tss(1)
-  if !util.FloatEqual(tss, tss_comp) {
+  tss, err := data.Tss(1)
+  if err != nil || !util.FloatEqual(tss, tss_comp) {
     t.Error("Test 1: Failed to compute total sum of squares.")
   }
 
-  tss_m := data.Tss_m(1, mean)
-  if !util.FloatEqual(tss, tss_m) {
+  tss_m, err := data.Tss_m(1, mean)
+  if err != nil || !util.FloatEqual(tss, tss_m) {
     t.Error("Test 1: Failed to compute total sum of squares with mean.")
   }
 
-  sigma2_fixed := data.Variance_with_fixed_mean(1, mean)
-  if !util.FloatEqual(sigma2_fixed, 8.25) {
+  sigma2_fixed, err := data.Variance_with_fixed_mean(1, mean)
+  if err != nil || !util.FloatEqual(sigma2_fixed, 8.25) {
     t.Error("Test 1: Failed to compute variance with fixed mean.")
   }
 
-  sigma_fixed := data.Sd_with_fixed_mean(1, mean)
-  if !util.FloatEqual(sigma_fixed, math.Sqrt(sigma2_fixed)) {
+  sigma_fixed, err := data.Sd_with_fixed_mean(1, mean)
+  if err != nil || !util.FloatEqual(sigma_fixed, math.Sqrt(sigma2_fixed)) {
     t.Error("Test 1: Failed to compute standard deviation with fixed mean.")
   }
 
-  absdev := data.Absdev(1)
-  if !util.FloatEqual(absdev, 2.5) {
+  absdev, err := data.Absdev(1)
+  if err != nil || !util.FloatEqual(absdev, 2.5) {
     t.Error("Test 1: Failed to compute absolute deviation.")
   }
 
-  absdev_m := data.Absdev_m(1, mean)
-  if !util.FloatEqual(absdev_m, absdev) {
+  absdev_m, err := data.Absdev_m(1, mean)
+  if err != nil || !util.FloatEqual(absdev_m, absdev) {
     t.Error("Test 1: Failed to compute absolute deviation with mean.")
   }
 }
@@ -97,45 +100,45 @@ func Test_stats_2(t *testing.T) {
     2.02904805729977e-03, 8.72682695045760e-04, 3.52595682367445e-04,
     1.33830225764885e-04}
 
-  mean := norm_data.Mean(1)
-  if !util.FloatEqual(mean, 0.12120783192361629654) {
+  mean, err := norm_data.Mean(1)
+  if err != nil || !util.FloatEqual(mean, 0.12120783192361629654) {
     t.Error("Test 2: Failed to compute mean.")
   }
 
-  sd := norm_data.Sd(1)
-  if !util.FloatEqual(sd, 0.1418146888138111239) {
+  sd, err := norm_data.Sd(1)
+  if err != nil || !util.FloatEqual(sd, 0.1418146888138111239) {
     t.Error("Test 2: Failed to compute standard deviation.")
   }
 
-  skew := norm_data.Skew(1)
-  if !util.FloatEqual(skew, 0.79446149715833858096) {
+  skew, err := norm_data.Skew(1)
+  if err != nil || !util.FloatEqual(skew, 0.79446149715833858096) {
     t.Error("Test 2: Failed to compute skew.")
   }
 
-  skew_m_sd := norm_data.Skew_m_sd(1, mean, sd)
-  if !util.FloatEqual(skew_m_sd, skew) {
+  skew_m_sd, err := norm_data.Skew_m_sd(1, mean, sd)
+  if err != nil || !util.FloatEqual(skew_m_sd, skew) {
     t.Error("Test 2: Failed to compute skew with mean and stddev.")
   }
 
-  kurt := norm_data.Kurtosis(1)
-  if !util.FloatEqual(kurt, -0.98591331325429809596) {
+  kurt, err := norm_data.Kurtosis(1)
+  if err != nil || !util.FloatEqual(kurt, -0.98591331325429809596) {
     t.Error("Test 2: Failed to compute kurtosis.")
   }
 
-  kurt_m_sd := norm_data.Kurtosis_m_sd(1, mean, sd)
-  if !util.FloatEqual(kurt_m_sd, kurt) {
+  kurt_m_sd, err := norm_data.Kurtosis_m_sd(1, mean, sd)
+  if err != nil || !util.FloatEqual(kurt_m_sd, kurt) {
     t.Error("Test 2: Failed to compute kurtosis with mean and stddev.")
   }
 
   // XXX: The target values for l1cor have been computed via GSL and
   // were not tested by a third party tool
-  l1corr := norm_data.Lag1_autocorrelation(1)
-  if !util.FloatEqual(l1corr, 0.9500395402358169) {
+  l1corr, err := norm_data.Lag1_autocorrelation(1)
+  if err != nil || !util.FloatEqual(l1corr, 0.9500395402358169) {
     t.Error("Test 2: Failed to compute the lag1 autocorrelation.", l1corr)
   }
 
-  l1corr_m_sd := norm_data.Lag1_autocorrelation_m_sd(1, mean)
-  if !util.FloatEqual(l1corr_m_sd, l1corr) {
+  l1corr_m_sd, err := norm_data.Lag1_autocorrelation_m_sd(1, mean)
+  if err != nil || !util.FloatEqual(l1corr_m_sd, l1corr) {
     t.Error("Test 2: Failed to compute lag1 autocorrelation with mean and stddev.", l1corr_m_sd)
   }
 }
@@ -149,26 +152,32 @@ func Test_stats_3(t *testing.T) {
   data2 := FloatSlice{39.0, 10.0, 34.0, 29.0, 82.0, 54.0, 30.0, 65.0, 56.0,
     55.0, 20.0, 52.0, 96.0, 95.0, 23.0, 51.0, 27.0, 59.0, 31.0, 99.0}
 
-  mean1 := data1.Mean(1)
-  mean2 := data2.Mean(1)
+  mean1, err := data1.Mean(1)
+  if err != nil {
+    t.Fatal(err)
+  }
+  mean2, err := data2.Mean(1)
+  if err != nil {
+    t.Fatal(err)
+  }
 
-  cov := data1.Covariance(1, data2, 1)
-  if !util.FloatEqual(cov, 130.93421052631578) {
+  cov, err := data1.Covariance(1, data2, 1)
+  if err != nil || !util.FloatEqual(cov, 130.93421052631578) {
     t.Error("Test 3: Failed to compute covariance.")
   }
 
-  cov_m := data1.Covariance_m(1, data2, 1, mean1, mean2)
-  if !util.FloatEqual(cov_m, cov) {
+  cov_m, err := data1.Covariance_m(1, data2, 1, mean1, mean2)
+  if err != nil || !util.FloatEqual(cov_m, cov) {
     t.Error("Test 3: Failed to compute covariance with mean1 and mean2.")
   }
 
-  corr := data1.Correlation(1, data2, 1)
-  if !util.FloatEqual(corr, 0.14269187753186113) {
+  corr, err := data1.Correlation(1, data2, 1)
+  if err != nil || !util.FloatEqual(corr, 0.14269187753186113) {
     t.Error("Test 3: Failed to compute Pearson correlation.")
   }
 
-  spear := data1.Spearman(1, data2, 1)
-  if !util.FloatEqual(spear, 0.091729323308270688) {
+  spear, err := data1.Spearman(1, data2, 1)
+  if err != nil || !util.FloatEqual(spear, 0.091729323308270688) {
     t.Error("Test 3: Failed to compute Pearson correlation.")
   }
 }
@@ -182,80 +191,80 @@ func Test_stats_4(t *testing.T) {
   weights := FloatSlice{0.1, 0.2, 0.3, 0.4, 0.1, 0.2, 0.2, 0.1, 0.8,
     0.8, 0.1, 0.2, 0.1, 0.1, 0.2, 0.3, 0.3, 0.3, 0.2, 0.1}
 
-  wmean := data.Wmean(1, weights, 1)
-  if !util.FloatEqual(wmean, 59.098039215686278) {
+  wmean, err := data.Wmean(1, weights, 1)
+  if err != nil || !util.FloatEqual(wmean, 59.098039215686278) {
     t.Error("Test 4: Failed to compute weighted mean.")
   }
 
   // XXX: All values below in this test set were computed via GSL
   // and not confirmened to be correct by a third party application
-  wvar := data.Wvariance(1, weights, 1)
-  if !util.FloatEqual(wvar, 1248.9347280334725) {
+  wvar, err := data.Wvariance(1, weights, 1)
+  if err != nil || !util.FloatEqual(wvar, 1248.9347280334725) {
     t.Error("Test 4: Failed to compute weighted variance.")
   }
 
-  wvar_m := data.Wvariance_m(1, weights, 1, wmean)
-  if !util.FloatEqual(wvar_m, wvar) {
+  wvar_m, err := data.Wvariance_m(1, weights, 1, wmean)
+  if err != nil || !util.FloatEqual(wvar_m, wvar) {
     t.Error("Test 4: Failed to compute weighted variance with mean.")
   }
 
-  wsd := data.Wsd(1, weights, 1)
-  if !util.FloatEqual(wsd, 35.34027062762073) {
+  wsd, err := data.Wsd(1, weights, 1)
+  if err != nil || !util.FloatEqual(wsd, 35.34027062762073) {
     t.Error("Test 4: Failed to compute weighted stddev.")
   }
 
-  wsd_m := data.Wsd_m(1, weights, 1, wmean)
-  if !util.FloatEqual(wsd_m, wsd) {
+  wsd_m, err := data.Wsd_m(1, weights, 1, wmean)
+  if err != nil || !util.FloatEqual(wsd_m, wsd) {
     t.Error("Test 4: Failed to compute weighted stdev with mean.")
   }
 
-  wvariance_fixed_m := data.Wvariance_with_fixed_mean(1, weights, 1, wmean)
-  if !util.FloatEqual(wvariance_fixed_m, 1147.6178392925797) {
+  wvariance_fixed_m, err := data.Wvariance_with_fixed_mean(1, weights, 1, wmean)
+  if err != nil || !util.FloatEqual(wvariance_fixed_m, 1147.6178392925797) {
     t.Error("Test 4: Failed to compute variance with fixed mean.")
   }
 
-  wsd_fixed_m := data.Wsd_with_fixed_mean(1, weights, 1, wmean)
-  if !util.FloatEqual(wsd_fixed_m, math.Sqrt(wvariance_fixed_m)) {
+  wsd_fixed_m, err := data.Wsd_with_fixed_mean(1, weights, 1, wmean)
+  if err != nil || !util.FloatEqual(wsd_fixed_m, math.Sqrt(wvariance_fixed_m)) {
     t.Error("Test 4: Failed to compute stddev with fixed mean.")
   }
 
-  wtss := data.Wtss(1, weights, 1)
-  if !util.FloatEqual(wtss, 5852.850980392157) {
+  wtss, err := data.Wtss(1, weights, 1)
+  if err != nil || !util.FloatEqual(wtss, 5852.850980392157) {
     t.Error("Test 4: Failed to compute weighted sum of squares.")
   }
 
-  wtss_m := data.Wtss_m(1, weights, 1, wmean)
-  if !util.FloatEqual(wtss_m, wtss) {
+  wtss_m, err := data.Wtss_m(1, weights, 1, wmean)
+  if err != nil || !util.FloatEqual(wtss_m, wtss) {
     t.Error("Test 4: Failed to compute weighted sum of squares with mean.")
   }
 
-  wabsdev := data.Wabsdev(1, weights, 1)
-  if !util.FloatEqual(wabsdev, 31.46097654748174) {
+  wabsdev, err := data.Wabsdev(1, weights, 1)
+  if err != nil || !util.FloatEqual(wabsdev, 31.46097654748174) {
     t.Error("Test 4: Failed to compute weighted absolute deviation.")
   }
 
-  wabsdev_m := data.Wabsdev_m(1, weights, 1, wmean)
-  if !util.FloatEqual(wabsdev_m, wabsdev) {
+  wabsdev_m, err := data.Wabsdev_m(1, weights, 1, wmean)
+  if err != nil || !util.FloatEqual(wabsdev_m, wabsdev) {
     t.Error("Test 4: Failed to compute weighted absolute deviation with mean.")
   }
 
-  wskew := data.Wskew(1, weights, 1)
-  if !util.FloatEqual(wskew, -0.28679295109648656) {
+  wskew, err := data.Wskew(1, weights, 1)
+  if err != nil || !util.FloatEqual(wskew, -0.28679295109648656) {
     t.Error("Test 4: Failed to compute weighted skew.")
   }
 
-  wskew_m_sd := data.Wskew_m_sd(1, weights, 1, wmean, wsd)
-  if !util.FloatEqual(wskew_m_sd, wskew) {
+  wskew_m_sd, err := data.Wskew_m_sd(1, weights, 1, wmean, wsd)
+  if err != nil || !util.FloatEqual(wskew_m_sd, wskew) {
     t.Error("Test 4: Failed to compute weighted skew with mean and stddev.")
   }
 
-  wkurtosis := data.Wkurtosis(1, weights, 1)
-  if !util.FloatEqual(wkurtosis, -1.7414231965732037) {
+  wkurtosis, err := data.Wkurtosis(1, weights, 1)
+  if err != nil || !util.FloatEqual(wkurtosis, -1.7414231965732037) {
     t.Error("Test 4: Failed to compute weighted kurtosis.")
   }
 
-  wkurtosis_m_sd := data.Wkurtosis_m_sd(1, weights, 1, wmean, wsd)
-  if !util.FloatEqual(wkurtosis_m_sd, wkurtosis) {
+  wkurtosis_m_sd, err := data.Wkurtosis_m_sd(1, weights, 1, wmean, wsd)
+  if err != nil || !util.FloatEqual(wkurtosis_m_sd, wkurtosis) {
     t.Error("Test 4: Failed to compute weighted kurtosis with mean and stddev.")
   }
 }
@@ -266,33 +275,33 @@ func Test_stats_5(t *testing.T) {
   data := FloatSlice{16.0, 99.0, 26.0, 85.0, 76.0, 50.0, 46.0, 11.0, 79.0,
     97.0, 24.0, 20.0, 100.0, 68.0, 22.0, 15.0, 5.0, 89.0, 45.0, 2.0}
 
-  max := data.Max(1)
-  if !util.FloatEqual(max, 100.0) {
+  max, err := data.Max(1)
+  if err != nil || !util.FloatEqual(max, 100.0) {
     t.Error("Test 5: Failed to compute data max.")
   }
 
-  min := data.Min(1)
-  if !util.FloatEqual(min, 2.0) {
+  min, err := data.Min(1)
+  if err != nil || !util.FloatEqual(min, 2.0) {
     t.Error("Test 5: Failed to compute data min.")
   }
 
-  min1, max1 := data.MinMax(1)
-  if !util.FloatEqual(min, min1) || !util.FloatEqual(max, max1) {
+  min1, max1, err := data.MinMax(1)
+  if err != nil || !util.FloatEqual(min, min1) || !util.FloatEqual(max, max1) {
     t.Error("Test 5: Failed to compute data minmax.")
   }
 
-  maxInd := data.MaxIndex(1)
-  if maxInd != 12 {
+  maxInd, err := data.MaxIndex(1)
+  if err != nil || maxInd != 12 {
     t.Error("Test 5: Failed to compute data max index.")
   }
 
-  minInd := data.MinIndex(1)
-  if minInd != 19 {
+  minInd, err := data.MinIndex(1)
+  if err != nil || minInd != 19 {
     t.Error("Test 5: Failed to compute min index.")
   }
 
-  minInd1, maxInd1 := data.MinMaxIndex(1)
-  if (minInd != minInd1) || (maxInd != maxInd1) {
+  minInd1, maxInd1, err := data.MinMaxIndex(1)
+  if err != nil || (minInd != minInd1) || (maxInd != maxInd1) {
     t.Error("Test 5: Failed to compute data minmax index.")
   }
 }
@@ -302,18 +311,543 @@ func Test_stats_6(t *testing.T) {
 
   data := FloatSlice{1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0, 9.0, 10.0}
 
-  median := data.MedianFromSortedData(1)
-  if !util.FloatEqual(median, 5.5) {
+  median, err := data.MedianFromSortedData(1)
+  if err != nil || !util.FloatEqual(median, 5.5) {
     t.Error("Test 6: Failed to compute median value.")
   }
 
-  quantile1 := data.QuantileFromSortedData(1, 0.15)
-  if !util.FloatEqual(quantile1, 2.35) {
+  quantile1, err := data.QuantileFromSortedData(1, 0.15)
+  if err != nil || !util.FloatEqual(quantile1, 2.35) {
     t.Error("Test 6: Failed to compute quantile1 value.")
   }
 
-  quantile2 := data.QuantileFromSortedData(1, 0.92)
-  if !util.FloatEqual(quantile2, 9.28) {
+  quantile2, err := data.QuantileFromSortedData(1, 0.92)
+  if err != nil || !util.FloatEqual(quantile2, 9.28) {
     t.Error("Test 6: Failed to compute quantile2 value.")
   }
 }
+
+// test set 7
+func Test_stats_7(t *testing.T) {
+
+  data1 := FloatSlice{1.0, 2.0, 3.0, 4.0, 5.0}
+  data2 := FloatSlice{1.0, 2.0, 3.0}
+
+  if _, err := data1.Covariance(1, data2, 1); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 7: Expected EBADLEN for covariance of mismatched data, "+
+      "got ", err)
+  }
+
+  if _, err := data1.Correlation(1, data2, 1); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 7: Expected EBADLEN for correlation of mismatched data, "+
+      "got ", err)
+  }
+
+  if _, err := data2.Spearman(1, data1, 1); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 7: Expected EBADLEN for Spearman of mismatched data, "+
+      "got ", err)
+  }
+
+  if _, err := data1.Wmean(1, data2, 1); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 7: Expected EBADLEN for weighted mean with mismatched "+
+      "weights, got ", err)
+  }
+
+  var gslErr *gsl.Error
+  _, err := data1.Wkurtosis(1, data2, 1)
+  if !errors.As(err, &gslErr) || gslErr.Errno != gsl.EBADLEN {
+    t.Error("Test 7: Expected *gsl.Error for weighted kurtosis with "+
+      "mismatched weights, got ", err)
+  }
+}
+
+// strided returns every stride-th element of d starting at the first one
+func strided(d FloatSlice, stride int) FloatSlice {
+  var s FloatSlice
+  for i := 0; i < len(d); i += stride {
+    s = append(s, d[i])
+  }
+  return s
+}
+
+// test set 8
+func Test_stats_8(t *testing.T) {
+
+  data := FloatSlice{16.0, 99.0, 26.0, 85.0, 76.0, 50.0, 46.0, 11.0, 79.0,
+    97.0, 24.0, 20.0, 100.0, 68.0, 22.0, 15.0, 5.0, 89.0, 45.0, 2.0, 57.0}
+
+  weights := FloatSlice{0.1, 0.2, 0.3, 0.4, 0.1, 0.2, 0.2, 0.1, 0.8,
+    0.8, 0.1, 0.2, 0.1, 0.1, 0.2, 0.3, 0.3, 0.3, 0.2, 0.1, 0.4}
+
+  sorted := FloatSlice{2.0, 5.0, 11.0, 15.0, 16.0, 20.0, 22.0, 24.0, 26.0,
+    45.0, 46.0, 50.0, 57.0, 68.0, 76.0, 79.0, 85.0, 89.0, 97.0, 99.0, 100.0}
+
+  // all single dataset functions
+  single := map[string]func(d FloatSlice, stride int) (float64, error){
+    "Mean":     FloatSlice.Mean,
+    "Variance": FloatSlice.Variance,
+    "Variance_m": func(d FloatSlice, stride int) (float64, error) {
+      return d.Variance_m(stride, 50.0)
+    },
+    "Sd": FloatSlice.Sd,
+    "Sd_m": func(d FloatSlice, stride int) (float64, error) {
+      return d.Sd_m(stride, 50.0)
+    },
+    "Tss": FloatSlice.Tss,
+    "Tss_m": func(d FloatSlice, stride int) (float64, error) {
+      return d.Tss_m(stride, 50.0)
+    },
+    "Variance_with_fixed_mean": func(d FloatSlice, stride int) (float64,
+      error) {
+      return d.Variance_with_fixed_mean(stride, 50.0)
+    },
+    "Sd_with_fixed_mean": func(d FloatSlice, stride int) (float64, error) {
+      return d.Sd_with_fixed_mean(stride, 50.0)
+    },
+    "Absdev": FloatSlice.Absdev,
+    "Absdev_m": func(d FloatSlice, stride int) (float64, error) {
+      return d.Absdev_m(stride, 50.0)
+    },
+    "Skew": FloatSlice.Skew,
+    "Skew_m_sd": func(d FloatSlice, stride int) (float64, error) {
+      return d.Skew_m_sd(stride, 50.0, 30.0)
+    },
+    "Kurtosis": FloatSlice.Kurtosis,
+    "Kurtosis_m_sd": func(d FloatSlice, stride int) (float64, error) {
+      return d.Kurtosis_m_sd(stride, 50.0, 30.0)
+    },
+    "Lag1_autocorrelation": FloatSlice.Lag1_autocorrelation,
+    "Lag1_autocorrelation_m_sd": func(d FloatSlice, stride int) (float64,
+      error) {
+      return d.Lag1_autocorrelation_m_sd(stride, 50.0)
+    },
+    "Max": FloatSlice.Max,
+    "Min": FloatSlice.Min,
+    "MinMax": func(d FloatSlice, stride int) (float64, error) {
+      min, max, err := d.MinMax(stride)
+      return max - min, err
+    },
+    "MaxIndex": func(d FloatSlice, stride int) (float64, error) {
+      i, err := d.MaxIndex(stride)
+      return float64(i), err
+    },
+    "MinIndex": func(d FloatSlice, stride int) (float64, error) {
+      i, err := d.MinIndex(stride)
+      return float64(i), err
+    },
+    "MinMaxIndex": func(d FloatSlice, stride int) (float64, error) {
+      i, j, err := d.MinMaxIndex(stride)
+      return float64(100*i + j), err
+    },
+  }
+
+  // functions requiring sorted data
+  sortedFuncs := map[string]func(d FloatSlice, stride int) (float64, error){
+    "MedianFromSortedData": FloatSlice.MedianFromSortedData,
+    "QuantileFromSortedData": func(d FloatSlice, stride int) (float64,
+      error) {
+      return d.QuantileFromSortedData(stride, 0.3)
+    },
+  }
+
+  // functions of two datasets, the second one is always weights
+  paired := map[string]func(d FloatSlice, s1 int, w FloatSlice,
+    s2 int) (float64, error){
+    "Covariance": FloatSlice.Covariance,
+    "Covariance_m": func(d FloatSlice, s1 int, w FloatSlice, s2 int) (float64,
+      error) {
+      return d.Covariance_m(s1, w, s2, 50.0, 0.3)
+    },
+    "Correlation": FloatSlice.Correlation,
+    "Spearman":    FloatSlice.Spearman,
+    "Wmean":       FloatSlice.Wmean,
+    "Wvariance":   FloatSlice.Wvariance,
+    "Wvariance_m": func(d FloatSlice, s1 int, w FloatSlice, s2 int) (float64,
+      error) {
+      return d.Wvariance_m(s1, w, s2, 50.0)
+    },
+    "Wsd": FloatSlice.Wsd,
+    "Wsd_m": func(d FloatSlice, s1 int, w FloatSlice, s2 int) (float64,
+      error) {
+      return d.Wsd_m(s1, w, s2, 50.0)
+    },
+    "Wvariance_with_fixed_mean": func(d FloatSlice, s1 int, w FloatSlice,
+      s2 int) (float64, error) {
+      return d.Wvariance_with_fixed_mean(s1, w, s2, 50.0)
+    },
+    "Wsd_with_fixed_mean": func(d FloatSlice, s1 int, w FloatSlice,
+      s2 int) (float64, error) {
+      return d.Wsd_with_fixed_mean(s1, w, s2, 50.0)
+    },
+    "Wtss": FloatSlice.Wtss,
+    "Wtss_m": func(d FloatSlice, s1 int, w FloatSlice, s2 int) (float64,
+      error) {
+      return d.Wtss_m(s1, w, s2, 50.0)
+    },
+    "Wabsdev": FloatSlice.Wabsdev,
+    "Wabsdev_m": func(d FloatSlice, s1 int, w FloatSlice, s2 int) (float64,
+      error) {
+      return d.Wabsdev_m(s1, w, s2, 50.0)
+    },
+    "Wskew": FloatSlice.Wskew,
+    "Wskew_m_sd": func(d FloatSlice, s1 int, w FloatSlice, s2 int) (float64,
+      error) {
+      return d.Wskew_m_sd(s1, w, s2, 50.0, 30.0)
+    },
+    "Wkurtosis": FloatSlice.Wkurtosis,
+    "Wkurtosis_m_sd": func(d FloatSlice, s1 int, w FloatSlice,
+      s2 int) (float64, error) {
+      return d.Wkurtosis_m_sd(s1, w, s2, 50.0, 30.0)
+    },
+  }
+
+  // test 1: strided access yields the same result as unit stride access
+  // of the extracted elements, including strides which do not divide
+  // the length of the data
+  for _, stride := range []int{1, 2, 3, 4, 5, 20} {
+    for name, f := range single {
+      got, err := f(data, stride)
+      want, errWant := f(strided(data, stride), 1)
+      if err != nil || errWant != nil || !util.FloatEqual(got, want) {
+        t.Error("Test 1: Strided", name, "with stride", stride, "failed:",
+          got, want, err)
+      }
+    }
+
+    for name, f := range sortedFuncs {
+      got, err := f(sorted, stride)
+      want, errWant := f(strided(sorted, stride), 1)
+      if err != nil || errWant != nil || !util.FloatEqual(got, want) {
+        t.Error("Test 1: Strided", name, "with stride", stride, "failed:",
+          got, want, err)
+      }
+    }
+  }
+
+  // the paired functions need at least 3 elements for meaningful results
+  for _, stride := range []int{1, 2, 3, 5} {
+    for name, f := range paired {
+      got, err := f(data, stride, weights, stride)
+      want, errWant := f(strided(data, stride), 1, strided(weights, stride), 1)
+      if err != nil || errWant != nil || !util.FloatEqual(got, want) {
+        t.Error("Test 1: Strided", name, "with stride", stride, "failed:",
+          got, want, err)
+      }
+    }
+  }
+
+  // test 2: the two datasets may use different strides
+  long_weights := make(FloatSlice, 2*len(weights))
+  for i, w := range weights {
+    long_weights[2*i] = w
+  }
+  for name, f := range paired {
+    got, err := f(data, 1, long_weights, 2)
+    want, errWant := f(data, 1, weights, 1)
+    if err != nil || errWant != nil || !util.FloatEqual(got, want) {
+      t.Error("Test 2: Paired", name, "with different strides failed:", got,
+        want, err)
+    }
+  }
+
+  // test 3: invalid strides, empty data and mismatched datasets
+  for name, f := range single {
+    for _, stride := range []int{0, -1} {
+      if _, err := f(data, stride); !errors.Is(err, gsl.EINVAL) {
+        t.Error("Test 3:", name, "accepted invalid stride", stride)
+      }
+    }
+    if _, err := f(FloatSlice{}, 1); !errors.Is(err, gsl.EBADLEN) {
+      t.Error("Test 3:", name, "accepted empty data.")
+    }
+  }
+
+  for name, f := range sortedFuncs {
+    if _, err := f(sorted, 0); !errors.Is(err, gsl.EINVAL) {
+      t.Error("Test 3:", name, "accepted invalid stride 0.")
+    }
+    if _, err := f(nil, 1); !errors.Is(err, gsl.EBADLEN) {
+      t.Error("Test 3:", name, "accepted empty data.")
+    }
+  }
+
+  for name, f := range paired {
+    if _, err := f(data, 1, weights, 0); !errors.Is(err, gsl.EINVAL) {
+      t.Error("Test 3:", name, "accepted invalid stride 0.")
+    }
+    if _, err := f(FloatSlice{}, 1, FloatSlice{}, 1); !errors.Is(err,
+      gsl.EBADLEN) {
+      t.Error("Test 3:", name, "accepted empty data.")
+    }
+    if _, err := f(data, 2, weights, 1); !errors.Is(err, gsl.EBADLEN) {
+      t.Error("Test 3:", name, "accepted mismatched datasets.")
+    }
+  }
+}
+
+// test set 9
+func Test_stats_9(t *testing.T) {
+
+  data := FloatSlice{16.0, 99.0, 26.0, 85.0, 76.0, 50.0, 46.0, 11.0, 79.0,
+    97.0, 24.0, 20.0, 100.0, 68.0, 22.0, 15.0, 5.0, 89.0, 45.0, 2.0, 57.0}
+
+  sorted := FloatSlice{2.0, 5.0, 11.0, 15.0, 16.0, 20.0, 22.0, 24.0, 26.0,
+    45.0, 46.0, 50.0, 57.0, 68.0, 76.0, 79.0, 85.0, 89.0, 97.0, 99.0, 100.0}
+
+  orig := append(FloatSlice(nil), data...)
+  unchanged := func() bool {
+    for i := range data {
+      if data[i] != orig[i] {
+        return false
+      }
+    }
+    return true
+  }
+
+  // test 1
+  median, err := data.Median(1)
+  if err != nil || median != 46.0 || !unchanged() {
+    t.Error("Test 1: Failed to compute median of unsorted data.", median, err)
+  }
+
+  // strided elements: 16, 26, 76, 46, 79, 24, 100, 22, 5, 45, 57
+  median, err = data.Median(2)
+  if err != nil || median != 45.0 || !unchanged() {
+    t.Error("Test 1: Failed to compute strided median.", median, err)
+  }
+
+  even := FloatSlice{4.0, 1.0, 3.0, 2.0}
+  median, err = even.Median(1)
+  if err != nil || median != 2.5 {
+    t.Error("Test 1: Failed to compute median of even length data.", median,
+      err)
+  }
+
+  // test 2
+  for k := range sorted {
+    elem, err := data.Select(1, k)
+    if err != nil || elem != sorted[k] {
+      t.Error("Test 2: Failed to select element", k, elem, err)
+    }
+  }
+  if !unchanged() {
+    t.Error("Test 2: Select modified the data.")
+  }
+
+  // test 3
+  for _, f := range []float64{0.0, 0.1, 0.25, 0.33, 0.5, 0.75, 0.9, 1.0} {
+    quant, err := data.Quantile(1, f)
+    expected, _ := sorted.QuantileFromSortedData(1, f)
+    if err != nil || !util.FloatEqual(quant, expected) {
+      t.Error("Test 3: Failed to compute quantile", f, quant, expected, err)
+    }
+  }
+  if !unchanged() {
+    t.Error("Test 3: Quantile modified the data.")
+  }
+
+  // test 4
+  inPlace := append(FloatSlice(nil), data...)
+  median, err = inPlace.MedianInPlace(1)
+  if err != nil || median != 46.0 {
+    t.Error("Test 4: Failed to compute median in place.", median, err)
+  }
+  elem, err := inPlace.SelectInPlace(1, 3)
+  if err != nil || elem != 15.0 {
+    t.Error("Test 4: Failed to select element in place.", elem, err)
+  }
+  quant, err := inPlace.QuantileInPlace(1, 0.75)
+  if err != nil || quant != 79.0 {
+    t.Error("Test 4: Failed to compute quantile in place.", quant, err)
+  }
+
+  // test 5
+  if _, err := data.Select(1, len(data)); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 5: Select accepted out of range k.")
+  }
+  if _, err := data.Select(2, -1); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 5: Select accepted negative k.")
+  }
+  if _, err := data.Quantile(1, 1.5); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 5: Quantile accepted fraction larger than 1.")
+  }
+  if _, err := data.Median(0); !errors.Is(err, gsl.EINVAL) {
+    t.Error("Test 5: Median accepted invalid stride 0.")
+  }
+  if _, err := (FloatSlice{}).Median(1); !errors.Is(err, gsl.EBADLEN) {
+    t.Error("Test 5: Median accepted empty data.")
+  }
+}
+
+// test set 10
+//
+// NOTE: MAD0 is checked against the worked example {1, 1, 2, 2, 4, 6, 9}
+//       with a MAD of 1 from the Wikipedia article "Median absolute
+//       deviation". Sn0 and Qn0 are checked against a direct O(n^2)
+//       evaluation of their definitions in Rousseeuw and Croux,
+//       "Alternatives to the Median Absolute Deviation", JASA 88 (1993),
+//       1273-1283, which is independent of the O(n log n) algorithms used
+//       by gsl. The scaled estimators Mad, Sn and Qn are consistent
+//       estimators of the standard deviation for Gaussian data according
+//       to the same paper and are checked to return 1 for the normal scores
+//       of a large sample.
+func Test_stats_10(t *testing.T) {
+
+  data := FloatSlice{16.0, 99.0, 26.0, 85.0, 76.0, 50.0, 46.0, 11.0, 79.0,
+    97.0, 24.0, 20.0, 100.0, 68.0, 22.0, 15.0, 5.0, 89.0, 45.0, 2.0, 57.0}
+
+  sorted := FloatSlice{2.0, 5.0, 11.0, 15.0, 16.0, 20.0, 22.0, 24.0, 26.0,
+    45.0, 46.0, 50.0, 57.0, 68.0, 76.0, 79.0, 85.0, 89.0, 97.0, 99.0, 100.0}
+
+  // test 1
+  trmean, err := sorted.TrmeanFromSortedData(1, 0.1)
+  if err != nil || !util.FloatEqual(trmean, 48.588235294117645) {
+    t.Error("Test 1: Failed to compute trimmed mean.", trmean, err)
+  }
+
+  trmean, err = sorted.TrmeanFromSortedData(1, 0.2)
+  if err != nil || !util.FloatEqual(trmean, 47.23076923076923) {
+    t.Error("Test 1: Failed to compute trimmed mean.", trmean, err)
+  }
+
+  trmean, err = sorted.TrmeanFromSortedData(1, 0.0)
+  mean, _ := data.Mean(1)
+  if err != nil || !util.FloatEqual(trmean, mean) {
+    t.Error("Test 1: Untrimmed mean differs from mean.", trmean, mean, err)
+  }
+
+  trmean, err = sorted.TrmeanFromSortedData(1, 0.5)
+  if err != nil || trmean != 46.0 {
+    t.Error("Test 1: Fully trimmed mean differs from median.", trmean, err)
+  }
+
+  // test 2
+  gastwirth, err := sorted.GastwirthFromSortedData(1)
+  if err != nil || !util.FloatEqual(gastwirth, 46.6) {
+    t.Error("Test 2: Failed to compute Gastwirth estimator.", gastwirth, err)
+  }
+
+  // test 3
+  wiki := FloatSlice{1.0, 1.0, 2.0, 2.0, 4.0, 6.0, 9.0}
+  mad0, err := wiki.Mad0(1)
+  if err != nil || mad0 != 1.0 {
+    t.Error("Test 3: Failed to compute MAD0.", mad0, err)
+  }
+
+  orig := append(FloatSlice(nil), data...)
+  mad0, err = data.Mad0(1)
+  if err != nil || mad0 != 30.0 {
+    t.Error("Test 3: Failed to compute MAD0.", mad0, err)
+  }
+  for i := range data {
+    if data[i] != orig[i] {
+      t.Error("Test 3: MAD modified the data.")
+      break
+    }
+  }
+
+  // test 4
+  samples := []FloatSlice{sorted, sorted[:10], sorted[3:14],
+    {1.0, 3.0, 3.0, 7.0, 12.0}, {-5.0, -1.0, 0.0, 0.5, 2.0, 8.0, 8.0,
+      9.0, 30.0}}
+  for _, sample := range samples {
+    sn0, err := sample.Sn0FromSortedData(1)
+    if err != nil || sn0 != naiveSn0(sample) {
+      t.Error("Test 4: Failed to compute Sn0.", sample, sn0, err)
+    }
+    qn0, err := sample.Qn0FromSortedData(1)
+    if err != nil || qn0 != naiveQn0(sample) {
+      t.Error("Test 4: Failed to compute Qn0.", sample, qn0, err)
+    }
+  }
+
+  // test 5
+  scores := make(FloatSlice, 2001)
+  for i := range scores {
+    p := float64(i+1) / float64(len(scores)+1)
+    scores[i] = math.Sqrt2 * math.Erfinv(2*p-1)
+  }
+  for name, f := range map[string]func(d FloatSlice,
+    stride int) (float64, error){
+    "Mad": FloatSlice.Mad,
+    "Sn":  FloatSlice.SnFromSortedData,
+    "Qn":  FloatSlice.QnFromSortedData,
+  } {
+    sigma, err := f(scores, 1)
+    if err != nil || math.Abs(sigma-1) > 0.01 {
+      t.Error("Test 5:", name, "is not consistent for Gaussian data:",
+        sigma, err)
+    }
+  }
+
+  // test 6: strided access
+  long := make(FloatSlice, 2*len(sorted))
+  for i, x := range sorted {
+    long[2*i] = x
+    long[2*i+1] = -x
+  }
+  for name, f := range map[string]func(d FloatSlice,
+    stride int) (float64, error){
+    "Gastwirth": FloatSlice.GastwirthFromSortedData,
+    "Mad0":      FloatSlice.Mad0,
+    "Mad":       FloatSlice.Mad,
+    "Sn0":       FloatSlice.Sn0FromSortedData,
+    "Sn":        FloatSlice.SnFromSortedData,
+    "Qn0":       FloatSlice.Qn0FromSortedData,
+    "Qn":        FloatSlice.QnFromSortedData,
+  } {
+    got, err := f(long, 2)
+    want, errWant := f(sorted, 1)
+    if err != nil || errWant != nil || !util.FloatEqual(got, want) {
+      t.Error("Test 6: Strided", name, "failed:", got, want, err)
+    }
+    if _, err := f(long, 0); !errors.Is(err, gsl.EINVAL) {
+      t.Error("Test 6:", name, "accepted invalid stride 0.")
+    }
+    if _, err := f(nil, 1); !errors.Is(err, gsl.EBADLEN) {
+      t.Error("Test 6:", name, "accepted empty data.")
+    }
+  }
+
+  // test 7
+  if _, err := sorted.TrmeanFromSortedData(1, 0.6); !errors.Is(err,
+    gsl.EINVAL) {
+    t.Error("Test 7: Trimmed mean accepted invalid trimming fraction.")
+  }
+}
+
+// orderStatistic returns the k-th smallest element of x
+func orderStatistic(x []float64, k int) float64 {
+  sorted := append([]float64(nil), x...)
+  sort.Float64s(sorted)
+  return sorted[k-1]
+}
+
+// naiveSn0 evaluates lomed_i himed_j |x_i - x_j| directly, where the low
+// median of n numbers is their order statistic (n+1)/2 and the high
+// median their order statistic n/2 + 1
+func naiveSn0(x []float64) float64 {
+  n := len(x)
+  inner := make([]float64, n)
+  for i := range x {
+    dist := make([]float64, n)
+    for j := range x {
+      dist[j] = math.Abs(x[i] - x[j])
+    }
+    inner[i] = orderStatistic(dist, n/2+1)
+  }
+  return orderStatistic(inner, (n+1)/2)
+}
+
+// naiveQn0 evaluates the k-th order statistic of the pairwise distances
+// |x_i - x_j| with i < j directly, where k = h(h-1)/2 and h = n/2 + 1
+func naiveQn0(x []float64) float64 {
+  var dist []float64
+  for i := range x {
+    for j := i + 1; j < len(x); j++ {
+      dist = append(dist, math.Abs(x[i]-x[j]))
+    }
+  }
+  h := len(x)/2 + 1
+  return orderStatistic(dist, h*(h-1)/2)
+}